@@ -2,14 +2,35 @@ package main
 
 import (
 	"ai-learn-english/config"
+	"ai-learn-english/internal/api/auth"
+	"ai-learn-english/internal/api/conversation"
+	"ai-learn-english/internal/api/document"
+	"ai-learn-english/internal/api/exercise"
+	"ai-learn-english/internal/api/placement"
+	"ai-learn-english/internal/api/profile"
+	"ai-learn-english/internal/api/quiz"
+	"ai-learn-english/internal/api/review"
+	"ai-learn-english/internal/api/search"
 	"ai-learn-english/internal/api/teacher"
+	"ai-learn-english/internal/api/wordbank"
+	"ai-learn-english/internal/collections"
+	"ai-learn-english/internal/database"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/internal/ingest"
+	"ai-learn-english/internal/jobqueue"
+	"ai-learn-english/internal/llm"
+	"ai-learn-english/internal/memory"
+	"ai-learn-english/internal/middleware"
+	"ai-learn-english/internal/retrieval"
+	"ai-learn-english/internal/srs"
+	"ai-learn-english/internal/token"
+	"ai-learn-english/internal/vectorstore"
 	"context"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/gofiber/fiber/v3"
-	malvus "github.com/milvus-io/milvus-sdk-go/v2/client"
 )
 
 func main() {
@@ -17,24 +38,86 @@ func main() {
 		log.Printf("config init error: %v", err)
 	}
 
-	app := fiber.New()
+	if config.Cfg.Auth.JWTSecret == "" {
+		log.Fatalf("auth.jwt_secret must be set")
+	}
+
+	if _, err := database.Init(config.Cfg.Dns); err != nil {
+		log.Fatalf("database init error: %v", err)
+	}
+
+	app := fiber.New(fiber.Config{
+		ErrorHandler: middleware.ErrorHandler,
+		BodyLimit:    config.Cfg.Storage.MaxUploadMB * 1024 * 1024,
+	})
 
 	app.Get("/health", func(c fiber.Ctx) error {
 		return c.SendString("ok")
 	})
 
-	// Milvus connectivity check on startup
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	cli, err := malvus.NewClient(ctx, malvus.Config{Address: "localhost:19530"})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	store, err := vectorstore.New(ctx, config.Cfg.VectorStore)
 	cancel()
 	if err != nil {
-		log.Printf("milvus connect error: %v", err)
+		log.Fatalf("vector store init error: %v", err)
 	}
-	cli.Close()
-	log.Println("milvus ok")
+	defer store.Close()
+
+	embeddings := collections.NewManager(query.Q, store, config.Cfg.Embedding, config.Cfg.OpenAI, config.Cfg.Gemini)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	_, err = embeddings.Active(ctx)
+	cancel()
+	if err != nil {
+		log.Fatalf("embedding init error: %v", err)
+	}
+	chatModel, err := llm.New(config.Cfg.LLM, config.Cfg.OpenAI, config.Cfg.Gemini)
+	if err != nil {
+		log.Fatalf("llm init error: %v", err)
+	}
+	scheduler := ingest.NewScheduler(jobqueue.New(query.Q))
+	retriever := retrieval.New(query.Q, embeddings, store, config.Cfg.Retrieval)
 
 	// routes
-	teacher.RegisterRoutes(app)
+	authSvc := auth.NewService(auth.NewRepository(query.Q), token.NewManager(config.Cfg.Auth))
+	auth.RegisterRoutes(app, auth.NewHandler(authSvc))
+
+	documentSvc := document.NewService(document.NewRepository(query.Q), scheduler, config.Cfg.Storage.Dir)
+	document.RegisterRoutes(app, document.NewHandler(documentSvc))
+
+	searchSvc := search.NewService(search.NewRepository(query.Q), retriever)
+	search.RegisterRoutes(app, search.NewHandler(searchSvc))
+
+	conversationSvc := conversation.NewService(conversation.NewRepository(query.Q))
+	conversation.RegisterRoutes(app, conversation.NewHandler(conversationSvc))
+
+	memories := memory.New(query.Q, chatModel, config.Cfg.Memory)
+	teacherSvc := teacher.NewService(teacher.NewRepository(query.Q), retriever, chatModel, memories, config.Cfg.Retrieval.TopK)
+	teacher.RegisterRoutes(app, teacher.NewHandler(teacherSvc))
+
+	wordbankSvc := wordbank.NewService(wordbank.NewRepository(query.Q), srs.SystemClock)
+	wordbank.RegisterRoutes(app, wordbank.NewHandler(wordbankSvc))
+
+	reviewSvc, err := review.NewService(review.NewRepository(query.Q), config.Cfg.SRS, srs.SystemClock)
+	if err != nil {
+		log.Fatalf("srs init error: %v", err)
+	}
+	review.RegisterRoutes(app, review.NewHandler(reviewSvc))
+
+	placementEngine, err := placement.New(config.Cfg.Placement)
+	if err != nil {
+		log.Fatalf("placement init error: %v", err)
+	}
+	placementSvc := placement.NewService(placement.NewRepository(query.Q), placementEngine)
+	placement.RegisterRoutes(app, placement.NewHandler(placementSvc))
+
+	profileSvc := profile.NewService(profile.NewRepository(query.Q))
+	profile.RegisterRoutes(app, profile.NewHandler(profileSvc))
+
+	quizSvc := quiz.NewService(quiz.NewRepository(query.Q), chatModel)
+	quiz.RegisterRoutes(app, quiz.NewHandler(quizSvc))
+
+	exerciseSvc := exercise.NewService(exercise.NewRepository(query.Q))
+	exercise.RegisterRoutes(app, exercise.NewHandler(exerciseSvc))
 
 	addr := fmt.Sprintf(":%d", config.Cfg.Server.Port)
 	if err := app.Listen(addr); err != nil {
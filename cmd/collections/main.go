@@ -0,0 +1,89 @@
+package main
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/internal/collections"
+	"ai-learn-english/internal/database"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/internal/jobqueue"
+	"ai-learn-english/internal/vectorstore"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+const usage = `usage: collections <command> [name]
+
+commands:
+  status         list embedding collections and backfill progress
+  backfill       register a collection for the configured embedding model
+                 and queue a worker job that fills it
+  cutover <name> make a fully backfilled collection the active one
+  cleanup        drop retired collections from the vector store`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	cmd := os.Args[1]
+
+	if err := config.Init("config.yaml"); err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	if _, err := database.Init(config.Cfg.Dns); err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	store, err := vectorstore.New(ctx, config.Cfg.VectorStore)
+	cancel()
+	if err != nil {
+		log.Fatalf("failed to connect to vector store: %v", err)
+	}
+	defer store.Close()
+
+	m := collections.NewManager(query.Q, store, config.Cfg.Embedding, config.Cfg.OpenAI, config.Cfg.Gemini)
+	ctx = context.Background()
+
+	switch cmd {
+	case "status":
+		if _, err := m.Active(ctx); err != nil {
+			log.Fatalf("failed to load active collection: %v", err)
+		}
+		rows, err := m.List(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, row := range rows {
+			fmt.Printf("%-9s %-60s %d/%d\n", row.State, row.Name, row.BackfillDone, row.BackfillTotal)
+		}
+	case "backfill":
+		c, err := m.Backfill(ctx, jobqueue.New(query.Q))
+		if err != nil {
+			log.Fatalf("backfill failed: %v", err)
+		}
+		fmt.Printf("queued backfill of %s; run the worker and check progress with \"collections status\"\n", c.Name)
+	case "cutover":
+		if len(os.Args) < 3 {
+			log.Fatalf("cutover needs a collection name")
+		}
+		if err := m.Cutover(ctx, os.Args[2]); err != nil {
+			log.Fatalf("cutover failed: %v", err)
+		}
+		fmt.Printf("%s is now active\n", os.Args[2])
+	case "cleanup":
+		dropped, err := m.Cleanup(ctx)
+		for _, name := range dropped {
+			fmt.Printf("dropped %s\n", name)
+		}
+		if err != nil {
+			log.Fatalf("cleanup failed: %v", err)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
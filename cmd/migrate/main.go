@@ -0,0 +1,98 @@
+package main
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/internal/database"
+	"ai-learn-english/internal/database/migrate"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+)
+
+const usage = `usage: migrate <command> [n]
+
+commands:
+  up [n]    apply all pending migrations, or the next n
+  down [n]  revert the last migration, or the last n
+  redo      revert and re-apply the last migration
+  status    list migrations and whether they are applied`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	cmd := os.Args[1]
+	n := 0
+	if len(os.Args) > 2 {
+		v, err := strconv.Atoi(os.Args[2])
+		if err != nil || v < 0 {
+			log.Fatalf("invalid count %q", os.Args[2])
+		}
+		n = v
+	}
+
+	if err := config.Init("config.yaml"); err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	db, err := database.Init(config.Cfg.Dns)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("failed to get sql.DB: %v", err)
+	}
+	defer sqlDB.Close()
+
+	m, err := migrate.New(sqlDB)
+	if err != nil {
+		log.Fatalf("failed to load migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	switch cmd {
+	case "up":
+		applied, err := m.Up(ctx, n)
+		for _, mig := range applied {
+			fmt.Printf("applied  %04d %s %s\n", mig.Seq, mig.Revision, mig.Name)
+		}
+		if err != nil {
+			log.Fatalf("up: %v", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("database is up to date")
+		}
+	case "down":
+		reverted, err := m.Down(ctx, n)
+		for _, mig := range reverted {
+			fmt.Printf("reverted %04d %s %s\n", mig.Seq, mig.Revision, mig.Name)
+		}
+		if err != nil {
+			log.Fatalf("down: %v", err)
+		}
+	case "redo":
+		mig, err := m.Redo(ctx)
+		if err != nil {
+			log.Fatalf("redo: %v", err)
+		}
+		fmt.Printf("redone   %04d %s %s\n", mig.Seq, mig.Revision, mig.Name)
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			log.Fatalf("status: %v", err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied"
+			}
+			fmt.Printf("%-8s %04d %s %s\n", state, s.Seq, s.Revision, s.Name)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/internal/database"
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/internal/ingest"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"gorm.io/gen"
+)
+
+// readability scores the chunks of documents that are not scored yet, such
+// as documents chunked before scoring existed. Scoring needs no model, so it
+// runs here instead of on the worker.
+func main() {
+	all := flag.Bool("all", false, "re-score every chunked document, e.g. after the word list changed")
+	flag.Parse()
+
+	if err := config.Init("config.yaml"); err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	if _, err := database.Init(config.Cfg.Dns); err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+
+	ctx := context.Background()
+	d := query.Q.Document
+	do := d.WithContext(ctx).Where(d.ChunksTotal.Gt(0))
+	if !*all {
+		do = do.Where(d.CefrLevel.IsNull())
+	}
+
+	scored := 0
+	var docs []*model.Document
+	err := do.FindInBatches(&docs, 100, func(tx gen.Dao, batch int) error {
+		for _, doc := range docs {
+			if err := ingest.ScoreReadability(ctx, query.Q, doc); err != nil {
+				return err
+			}
+			scored++
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("failed to score readability: %v", err)
+	}
+	fmt.Printf("scored readability of %d documents\n", scored)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/internal/collections"
+	"ai-learn-english/internal/database"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/internal/reconcile"
+	"ai-learn-english/internal/vectorstore"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+func main() {
+	repair := flag.Bool("repair", false, "delete orphan vectors and re-embed chunks with missing vectors (default is a dry run)")
+	out := flag.String("out", "", "write the JSON report to this file instead of stdout")
+	flag.Parse()
+
+	if err := config.Init("config.yaml"); err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	if _, err := database.Init(config.Cfg.Dns); err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	store, err := vectorstore.New(ctx, config.Cfg.VectorStore)
+	cancel()
+	if err != nil {
+		log.Fatalf("failed to connect to vector store: %v", err)
+	}
+	defer store.Close()
+
+	embeddings := collections.NewManager(query.Q, store, config.Cfg.Embedding, config.Cfg.OpenAI, config.Cfg.Gemini)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	_, err = embeddings.Active(ctx)
+	cancel()
+	if err != nil {
+		log.Fatalf("failed to create embedder: %v", err)
+	}
+
+	report, err := reconcile.New(query.Q, embeddings, store).Run(context.Background(), *repair)
+	if err != nil {
+		log.Fatalf("reconcile failed: %v", err)
+	}
+
+	w := os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("failed to create report: %v", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		log.Fatalf("failed to write report: %v", err)
+	}
+	fmt.Fprintln(os.Stderr, report.Summary())
+}
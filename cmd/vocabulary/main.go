@@ -0,0 +1,55 @@
+package main
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/internal/database"
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/internal/ingest"
+	"ai-learn-english/internal/jobqueue"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"gorm.io/gen"
+)
+
+// vocabulary queues vocabulary extraction for ready documents that do not
+// have it yet, such as documents ingested before the stage existed. The
+// worker does the extraction.
+func main() {
+	all := flag.Bool("all", false, "re-extract the vocabulary of every ready document, e.g. after the word list changed")
+	flag.Parse()
+
+	if err := config.Init("config.yaml"); err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	if _, err := database.Init(config.Cfg.Dns); err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+
+	ctx := context.Background()
+	scheduler := ingest.NewScheduler(jobqueue.New(query.Q))
+	d := query.Q.Document
+	do := d.WithContext(ctx).Where(d.Status.Eq(ingest.StatusReady))
+	if !*all {
+		do = do.Where(d.VocabularyAt.IsNull())
+	}
+
+	queued := 0
+	var docs []*model.Document
+	err := do.FindInBatches(&docs, 500, func(tx gen.Dao, batch int) error {
+		for _, doc := range docs {
+			if err := scheduler.ScheduleVocabulary(ctx, doc.ID); err != nil {
+				return err
+			}
+			queued++
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("failed to queue vocabulary extraction: %v", err)
+	}
+	fmt.Printf("queued vocabulary extraction for %d documents\n", queued)
+}
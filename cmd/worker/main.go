@@ -1,12 +1,74 @@
 package main
 
 import (
-	"fmt"
-
 	"ai-learn-english/config"
+	"ai-learn-english/internal/collections"
+	"ai-learn-english/internal/database"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/internal/ingest"
+	"ai-learn-english/internal/jobqueue"
+	"ai-learn-english/internal/reconcile"
+	"ai-learn-english/internal/vectorstore"
+	"ai-learn-english/pkg/logger"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
 )
 
 func main() {
-	config.Init("config.yaml")
-	fmt.Println("Hello, World!")
-}
\ No newline at end of file
+	if err := config.Init("config.yaml"); err != nil {
+		log.Printf("config init error: %v", err)
+	}
+
+	if _, err := database.Init(config.Cfg.Dns); err != nil {
+		log.Fatalf("database init error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	store, err := vectorstore.New(ctx, config.Cfg.VectorStore)
+	cancel()
+	if err != nil {
+		log.Fatalf("vector store init error: %v", err)
+	}
+	defer store.Close()
+
+	embeddings := collections.NewManager(query.Q, store, config.Cfg.Embedding, config.Cfg.OpenAI, config.Cfg.Gemini)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	_, err = embeddings.Active(ctx)
+	cancel()
+	if err != nil {
+		log.Fatalf("embedding init error: %v", err)
+	}
+
+	workDir := filepath.Join(config.Cfg.Storage.Dir, "work")
+	pipeline := ingest.NewPipeline(query.Q, ingest.NewChunker(config.Cfg.Chunker), embeddings, store, workDir)
+
+	hostname, _ := os.Hostname()
+	queue := jobqueue.New(query.Q)
+	worker := jobqueue.NewWorker(queue, jobqueue.WorkerOptions{
+		ID:           fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		Concurrency:  config.Cfg.Worker.Concurrency,
+		PollInterval: time.Duration(config.Cfg.Worker.PollIntervalMs) * time.Millisecond,
+		Lease:        time.Duration(config.Cfg.Worker.LeaseSeconds) * time.Second,
+	})
+	ingest.RegisterHandlers(worker, queue, pipeline)
+	collections.RegisterHandlers(worker, embeddings)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if minutes := config.Cfg.Reconcile.IntervalMinutes; minutes > 0 {
+		go reconcile.New(query.Q, embeddings, store).Every(ctx, time.Duration(minutes)*time.Minute, config.Cfg.Reconcile.Repair)
+	}
+
+	logger.Info("worker started")
+	if err := worker.Run(ctx); err != nil {
+		log.Fatalf("worker error: %v", err)
+	}
+	logger.Info("worker stopped")
+}
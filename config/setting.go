@@ -37,22 +37,125 @@ type DatabaseConfig struct {
 }
 
 type OpenAIConfig struct {
-	Key   string `koanf:"key"`
-	Model string `koanf:"model"`
+	Key            string `koanf:"key"`
+	Model          string `koanf:"model"`
+	TimeoutSeconds int    `koanf:"timeout_seconds"`
+	MaxRetries     int    `koanf:"max_retries"`
 }
 
 type GeminiConfig struct {
-	Key   string `koanf:"key"`
-	Model string `koanf:"model"`
+	Key            string `koanf:"key"`
+	Model          string `koanf:"model"`
+	TimeoutSeconds int    `koanf:"timeout_seconds"`
+	MaxRetries     int    `koanf:"max_retries"`
+}
+
+type AuthConfig struct {
+	JWTSecret        string `koanf:"jwt_secret"`
+	AccessTTLMinutes int    `koanf:"access_ttl_minutes"`
+	RefreshTTLHours  int    `koanf:"refresh_ttl_hours"`
+}
+
+type LLMConfig struct {
+	Provider string `koanf:"provider"`
+	Fallback string `koanf:"fallback"`
+}
+
+type RetrievalConfig struct {
+	TopK              int     `koanf:"top_k"`
+	Candidates        int     `koanf:"candidates"`
+	VectorWeight      float64 `koanf:"vector_weight"`
+	KeywordWeight     float64 `koanf:"keyword_weight"`
+	RRFK              int     `koanf:"rrf_k"`
+	KeywordCacheUsers int     `koanf:"keyword_cache_users"`
+}
+
+// MemoryConfig bounds the conversation history sent to the teacher. The
+// last RecentTurns exchanges are replayed verbatim; older ones are folded
+// into a running summary of at most SummaryTokens tokens. PromptBudget caps
+// the tokens of the whole prompt, including the retrieved passages.
+type MemoryConfig struct {
+	RecentTurns   int `koanf:"recent_turns"`
+	PromptBudget  int `koanf:"prompt_budget"`
+	SummaryTokens int `koanf:"summary_tokens"`
+}
+
+// SRSConfig configures flashcard scheduling. Algorithm is "sm2" or "fsrs"
+// and applies to cards reviewed for the first time; DesiredRetention is the
+// recall probability FSRS schedules for.
+type SRSConfig struct {
+	Algorithm        string  `koanf:"algorithm"`
+	DesiredRetention float64 `koanf:"desired_retention"`
+	MaxIntervalDays  int     `koanf:"max_interval_days"`
+}
+
+// PlacementConfig tunes the adaptive placement test. Tests start at
+// StartLevel and ask between MinItems and MaxItems questions.
+type PlacementConfig struct {
+	StartLevel string `koanf:"start_level"`
+	MinItems   int    `koanf:"min_items"`
+	MaxItems   int    `koanf:"max_items"`
+}
+
+type EmbeddingConfig struct {
+	Provider  string `koanf:"provider"`
+	Model     string `koanf:"model"`
+	Dimension int    `koanf:"dimension"`
+	BatchSize int    `koanf:"batch_size"`
+}
+
+type StorageConfig struct {
+	Dir         string `koanf:"dir"`
+	MaxUploadMB int    `koanf:"max_upload_mb"`
+}
+
+type ChunkerConfig struct {
+	MaxTokens     int `koanf:"max_tokens"`
+	OverlapTokens int `koanf:"overlap_tokens"`
+	PreviewChars  int `koanf:"preview_chars"`
+}
+
+type WorkerConfig struct {
+	Concurrency    int `koanf:"concurrency"`
+	PollIntervalMs int `koanf:"poll_interval_ms"`
+	LeaseSeconds   int `koanf:"lease_seconds"`
+}
+
+type ReconcileConfig struct {
+	IntervalMinutes int  `koanf:"interval_minutes"`
+	Repair          bool `koanf:"repair"`
+}
+
+type MilvusConfig struct {
+	Address  string `koanf:"address"`
+	Username string `koanf:"username"`
+	Password string `koanf:"password"`
+}
+
+type VectorStoreConfig struct {
+	Driver string       `koanf:"driver"`
+	Milvus MilvusConfig `koanf:"milvus"`
 }
 
 type Config struct {
-	Server   ServerConfig   `koanf:"server"`
-	Database DatabaseConfig `koanf:"database"`
-	OpenAI   OpenAIConfig   `koanf:"openai"`
-	Gemini   GeminiConfig   `koanf:"gemini"`
-	LogLevel LogLevel       `koanf:"log_level"`
-	Dns      string         `koanf:"dns"`
+	Server      ServerConfig      `koanf:"server"`
+	Database    DatabaseConfig    `koanf:"database"`
+	OpenAI      OpenAIConfig      `koanf:"openai"`
+	Gemini      GeminiConfig      `koanf:"gemini"`
+	Auth        AuthConfig        `koanf:"auth"`
+	LLM         LLMConfig         `koanf:"llm"`
+	Embedding   EmbeddingConfig   `koanf:"embedding"`
+	Retrieval   RetrievalConfig   `koanf:"retrieval"`
+	Memory      MemoryConfig      `koanf:"memory"`
+	SRS         SRSConfig         `koanf:"srs"`
+	Placement   PlacementConfig   `koanf:"placement"`
+	Storage     StorageConfig     `koanf:"storage"`
+	Chunker     ChunkerConfig     `koanf:"chunker"`
+	VectorStore VectorStoreConfig `koanf:"vector_store"`
+	Worker      WorkerConfig      `koanf:"worker"`
+	Reconcile   ReconcileConfig   `koanf:"reconcile"`
+	LogLevel    LogLevel          `koanf:"log_level"`
+	Dns         string            `koanf:"dns"`
 }
 
 func buildMySQLDSN(cfg DatabaseConfig) string {
@@ -78,12 +181,75 @@ var defaultConfig = Config{
 		Name:     "testdb",
 	},
 	OpenAI: OpenAIConfig{
-		Key:   "",
-		Model: "default",
+		Key:            "",
+		Model:          "default",
+		TimeoutSeconds: 60,
+		MaxRetries:     2,
 	},
 	Gemini: GeminiConfig{
-		Key:   "",
-		Model: "default",
+		Key:            "",
+		Model:          "default",
+		TimeoutSeconds: 60,
+		MaxRetries:     2,
+	},
+	Auth: AuthConfig{
+		AccessTTLMinutes: 15,
+		RefreshTTLHours:  24 * 30,
+	},
+	LLM: LLMConfig{
+		Provider: "openai",
+	},
+	Embedding: EmbeddingConfig{
+		Provider:  "local",
+		Model:     "hashing",
+		Dimension: 256,
+		BatchSize: 64,
+	},
+	Retrieval: RetrievalConfig{
+		TopK:              5,
+		Candidates:        20,
+		VectorWeight:      1,
+		KeywordWeight:     1,
+		RRFK:              60,
+		KeywordCacheUsers: 100,
+	},
+	Memory: MemoryConfig{
+		RecentTurns:   6,
+		PromptBudget:  6000,
+		SummaryTokens: 300,
+	},
+	SRS: SRSConfig{
+		Algorithm:        "sm2",
+		DesiredRetention: 0.9,
+		MaxIntervalDays:  365,
+	},
+	Placement: PlacementConfig{
+		StartLevel: "A2",
+		MinItems:   10,
+		MaxItems:   20,
+	},
+	Storage: StorageConfig{
+		Dir:         "data/uploads",
+		MaxUploadMB: 100,
+	},
+	Chunker: ChunkerConfig{
+		MaxTokens:     400,
+		OverlapTokens: 50,
+		PreviewChars:  200,
+	},
+	VectorStore: VectorStoreConfig{
+		Driver: "milvus",
+		Milvus: MilvusConfig{
+			Address: "localhost:19530",
+		},
+	},
+	Worker: WorkerConfig{
+		Concurrency:    2,
+		PollIntervalMs: 1000,
+		LeaseSeconds:   60,
+	},
+	Reconcile: ReconcileConfig{
+		IntervalMinutes: 60,
 	},
 	LogLevel: INFO,
 }
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"ai-learn-english/pkg/apperror"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+var ErrInvalidBody = apperror.New("invalid_body", "request body is not valid JSON")
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Register handles POST /auth/register.
+func (h *Handler) Register(c fiber.Ctx) error {
+	var req RegisterRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return ErrInvalidBody
+	}
+	res, err := h.svc.Register(c.Context(), req)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(res)
+}
+
+// Login handles POST /auth/login.
+func (h *Handler) Login(c fiber.Ctx) error {
+	var req LoginRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return ErrInvalidBody
+	}
+	res, err := h.svc.Login(c.Context(), req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// Refresh handles POST /auth/refresh.
+func (h *Handler) Refresh(c fiber.Ctx) error {
+	var req RefreshRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return ErrInvalidBody
+	}
+	res, err := h.svc.Refresh(c.Context(), req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// Logout handles POST /auth/logout.
+func (h *Handler) Logout(c fiber.Ctx) error {
+	var req RefreshRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return ErrInvalidBody
+	}
+	if err := h.svc.Logout(c.Context(), req); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository persists users and refresh tokens through the generated query package.
+type Repository struct {
+	q *query.Query
+}
+
+func NewRepository(q *query.Query) *Repository {
+	return &Repository{q: q}
+}
+
+func (r *Repository) CreateUser(ctx context.Context, user *model.User) error {
+	return r.q.User.WithContext(ctx).Create(user)
+}
+
+// FindUserByLogin returns the user whose email equals login when it
+// contains "@", and whose username equals it otherwise, or nil when there is
+// none.
+func (r *Repository) FindUserByLogin(ctx context.Context, login string) (*model.User, error) {
+	u := r.q.User
+	cond := u.Username.Eq(login)
+	if strings.Contains(login, "@") {
+		cond = u.Email.Eq(login)
+	}
+	user, err := u.WithContext(ctx).Where(cond).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *Repository) FindUser(ctx context.Context, id int64) (*model.User, error) {
+	u := r.q.User
+	return u.WithContext(ctx).Where(u.ID.Eq(id)).First()
+}
+
+func (r *Repository) CreateRefreshToken(ctx context.Context, t *model.RefreshToken) error {
+	return r.q.RefreshToken.WithContext(ctx).Create(t)
+}
+
+var (
+	// errTokenReused is returned by Rotate when a revoked refresh token is
+	// presented again, which means it has leaked.
+	errTokenReused  = errors.New("refresh token reused")
+	errTokenExpired = errors.New("refresh token expired")
+)
+
+// Rotate revokes the refresh token jti of userID and stores next as its
+// replacement in one transaction. When jti was already revoked every
+// refresh token of the user is revoked and errTokenReused is returned.
+func (r *Repository) Rotate(ctx context.Context, userID int64, jti string, next *model.RefreshToken) error {
+	var outcome error
+	err := r.q.Transaction(func(tx *query.Query) error {
+		rt := tx.RefreshToken
+		current, err := rt.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(rt.Jti.Eq(jti), rt.UserID.Eq(userID)).First()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		switch outcome = rotation(current, now); outcome {
+		case errTokenReused:
+			_, err = rt.WithContext(ctx).Where(rt.UserID.Eq(userID), rt.RevokedAt.IsNull()).Update(rt.RevokedAt, now)
+			return err
+		case errTokenExpired:
+			return nil
+		}
+		if err := rt.WithContext(ctx).Create(next); err != nil {
+			return err
+		}
+		_, err = rt.WithContext(ctx).Where(rt.ID.Eq(current.ID)).
+			UpdateSimple(rt.RevokedAt.Value(now), rt.ReplacedBy.Value(next.Jti))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return outcome
+}
+
+// rotation reports whether the stored refresh token current can be
+// rotated at now: nil when it can, errTokenReused when it was revoked
+// already and errTokenExpired when it has expired.
+func rotation(current *model.RefreshToken, now time.Time) error {
+	switch {
+	case current.RevokedAt != nil:
+		return errTokenReused
+	case current.ExpiresAt.Before(now):
+		return errTokenExpired
+	}
+	return nil
+}
+
+// Revoke marks the refresh token jti of userID as revoked.
+func (r *Repository) Revoke(ctx context.Context, userID int64, jti string) error {
+	rt := r.q.RefreshToken
+	_, err := rt.WithContext(ctx).Where(rt.Jti.Eq(jti), rt.UserID.Eq(userID), rt.RevokedAt.IsNull()).Update(rt.RevokedAt, time.Now())
+	return err
+}
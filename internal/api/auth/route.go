@@ -0,0 +1,15 @@
+package auth
+
+import (
+	"github.com/gofiber/fiber/v3"
+)
+
+// RegisterRoutes registers authentication routes on the provided router.
+func RegisterRoutes(r fiber.Router, h *Handler) {
+	grp := r.Group("/auth")
+
+	grp.Post("/register", h.Register)
+	grp.Post("/login", h.Login)
+	grp.Post("/refresh", h.Refresh)
+	grp.Post("/logout", h.Logout)
+}
@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/token"
+	"ai-learn-english/pkg/apperror"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	minPasswordLength = 8
+	// maxPasswordBytes is the most bcrypt can hash.
+	maxPasswordBytes = 72
+	// maxUsernameLength matches users.username.
+	maxUsernameLength = 100
+)
+
+var (
+	ErrInvalidEmail        = apperror.New("invalid_email", "email address is not valid")
+	ErrWeakPassword        = apperror.New("weak_password", fmt.Sprintf("password must be at least %d characters", minPasswordLength))
+	ErrPasswordTooLong     = apperror.New("password_too_long", fmt.Sprintf("password must be at most %d bytes", maxPasswordBytes))
+	ErrInvalidUsername     = apperror.New("invalid_username", fmt.Sprintf("username must be at most %d characters and must not contain @", maxUsernameLength))
+	ErrUserExists          = apperror.New("user_exists", "email or username is already registered").WithStatus(http.StatusConflict)
+	ErrInvalidCredentials  = apperror.New("invalid_credentials", "login or password is incorrect").WithStatus(http.StatusUnauthorized)
+	ErrInvalidRefreshToken = apperror.New("invalid_refresh_token", "refresh token is invalid, expired or revoked").WithStatus(http.StatusUnauthorized)
+)
+
+// dummyHash is compared against when the login matches no password, so an
+// unknown login costs as much time as a wrong password and response times
+// do not reveal which accounts exist.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not a real password"), bcrypt.DefaultCost)
+
+// Store keeps users and their refresh tokens. Repository is the database
+// implementation.
+type Store interface {
+	CreateUser(ctx context.Context, user *model.User) error
+	FindUserByLogin(ctx context.Context, login string) (*model.User, error)
+	CreateRefreshToken(ctx context.Context, t *model.RefreshToken) error
+	Rotate(ctx context.Context, userID int64, jti string, next *model.RefreshToken) error
+	Revoke(ctx context.Context, userID int64, jti string) error
+}
+
+// Service registers users and manages their sessions.
+type Service struct {
+	repo   Store
+	tokens *token.Manager
+}
+
+func NewService(repo Store, tokens *token.Manager) *Service {
+	return &Service{repo: repo, tokens: tokens}
+}
+
+// Register creates a user with a bcrypt password hash and starts a session.
+func (s *Service) Register(ctx context.Context, req RegisterRequest) (*TokenResponse, error) {
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return nil, ErrInvalidEmail
+	}
+	if len(req.Password) < minPasswordLength {
+		return nil, ErrWeakPassword
+	}
+	if len(req.Password) > maxPasswordBytes {
+		return nil, ErrPasswordTooLong
+	}
+	// Logins containing "@" are looked up by email only, so a username
+	// must not look like one.
+	username := strings.TrimSpace(req.Username)
+	if strings.Contains(username, "@") || utf8.RuneCountInString(username) > maxUsernameLength {
+		return nil, ErrInvalidUsername
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("hash password: %w", err)
+	}
+	passwordHash := string(hash)
+	user := &model.User{Email: email, PasswordHash: &passwordHash}
+	if username != "" {
+		user.Username = &username
+	}
+	if err := s.repo.CreateUser(ctx, user); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrUserExists
+		}
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+	return s.startSession(ctx, user)
+}
+
+// Login checks the password of the user identified by email or username.
+func (s *Service) Login(ctx context.Context, req LoginRequest) (*TokenResponse, error) {
+	login := strings.TrimSpace(req.Login)
+	if strings.Contains(login, "@") {
+		login = strings.ToLower(login)
+	}
+	user, err := s.repo.FindUserByLogin(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	if user == nil || user.PasswordHash == nil {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(req.Password))
+		return nil, ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(*user.PasswordHash), []byte(req.Password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	return s.startSession(ctx, user)
+}
+
+// Refresh exchanges a refresh token for a new token pair. The presented
+// token is revoked; presenting it again revokes every session of the user.
+func (s *Service) Refresh(ctx context.Context, req RefreshRequest) (*TokenResponse, error) {
+	userID, jti, err := s.tokens.Parse(req.RefreshToken, token.TypeRefresh)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	refresh, err := s.tokens.IssueRefresh(userID)
+	if err != nil {
+		return nil, err
+	}
+	next := &model.RefreshToken{UserID: userID, Jti: refresh.ID, ExpiresAt: refresh.ExpiresAt}
+	switch err := s.repo.Rotate(ctx, userID, jti, next); {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, errTokenExpired), errors.Is(err, errTokenReused):
+		return nil, ErrInvalidRefreshToken
+	case err != nil:
+		return nil, fmt.Errorf("rotate refresh token: %w", err)
+	}
+
+	access, err := s.tokens.IssueAccess(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.tokenResponse(nil, access, refresh), nil
+}
+
+// Logout revokes the refresh token. Access tokens stay valid until they
+// expire, which is why they are short lived.
+func (s *Service) Logout(ctx context.Context, req RefreshRequest) error {
+	userID, jti, err := s.tokens.Parse(req.RefreshToken, token.TypeRefresh)
+	if err != nil {
+		return ErrInvalidRefreshToken
+	}
+	return s.repo.Revoke(ctx, userID, jti)
+}
+
+func (s *Service) startSession(ctx context.Context, user *model.User) (*TokenResponse, error) {
+	access, err := s.tokens.IssueAccess(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := s.tokens.IssueRefresh(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateRefreshToken(ctx, &model.RefreshToken{UserID: user.ID, Jti: refresh.ID, ExpiresAt: refresh.ExpiresAt}); err != nil {
+		return nil, fmt.Errorf("store refresh token: %w", err)
+	}
+	return s.tokenResponse(&UserResponse{ID: user.ID, Email: user.Email, Username: user.Username}, access, refresh), nil
+}
+
+func (s *Service) tokenResponse(user *UserResponse, access, refresh *token.Issued) *TokenResponse {
+	return &TokenResponse{
+		User:         user,
+		AccessToken:  access.Token,
+		RefreshToken: refresh.Token,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.tokens.AccessTTL().Seconds()),
+	}
+}
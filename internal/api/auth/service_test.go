@@ -0,0 +1,272 @@
+package auth
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/token"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// memStore keeps users and refresh tokens in memory and rotates tokens the
+// way Repository does.
+type memStore struct {
+	mu     sync.Mutex
+	users  []*model.User
+	tokens map[string]*model.RefreshToken
+}
+
+func newMemStore() *memStore {
+	return &memStore{tokens: make(map[string]*model.RefreshToken)}
+}
+
+func (s *memStore) CreateUser(ctx context.Context, user *model.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, u := range s.users {
+		if u.Email == user.Email || (u.Username != nil && user.Username != nil && *u.Username == *user.Username) {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	user.ID = int64(len(s.users) + 1)
+	s.users = append(s.users, user)
+	return nil
+}
+
+func (s *memStore) FindUserByLogin(ctx context.Context, login string) (*model.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, u := range s.users {
+		if strings.Contains(login, "@") && u.Email == login || !strings.Contains(login, "@") && u.Username != nil && *u.Username == login {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *memStore) CreateRefreshToken(ctx context.Context, t *model.RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[t.Jti] = t
+	return nil
+}
+
+func (s *memStore) Rotate(ctx context.Context, userID int64, jti string, next *model.RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, ok := s.tokens[jti]
+	if !ok || current.UserID != userID {
+		return gorm.ErrRecordNotFound
+	}
+	now := time.Now()
+	switch err := rotation(current, now); err {
+	case errTokenReused:
+		for _, t := range s.tokens {
+			if t.UserID == userID && t.RevokedAt == nil {
+				t.RevokedAt = &now
+			}
+		}
+		return err
+	case errTokenExpired:
+		return err
+	}
+	s.tokens[next.Jti] = next
+	current.RevokedAt, current.ReplacedBy = &now, &next.Jti
+	return nil
+}
+
+func (s *memStore) Revoke(ctx context.Context, userID int64, jti string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, ok := s.tokens[jti]; ok && t.UserID == userID && t.RevokedAt == nil {
+		now := time.Now()
+		t.RevokedAt = &now
+	}
+	return nil
+}
+
+func (s *memStore) live(userID int64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, t := range s.tokens {
+		if t.UserID == userID && t.RevokedAt == nil {
+			n++
+		}
+	}
+	return n
+}
+
+func newTestService(store *memStore) *Service {
+	return NewService(store, token.NewManager(config.AuthConfig{JWTSecret: "test-secret", AccessTTLMinutes: 5, RefreshTTLHours: 1}))
+}
+
+func TestRegisterValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  RegisterRequest
+		want error
+	}{
+		{"ok", RegisterRequest{Email: " Ann@Example.com ", Username: " ann ", Password: "password1"}, nil},
+		{"no username", RegisterRequest{Email: "ann@example.com", Password: "password1"}, nil},
+		{"longest password", RegisterRequest{Email: "ann@example.com", Password: strings.Repeat("p", maxPasswordBytes)}, nil},
+		{"longest username", RegisterRequest{Email: "ann@example.com", Username: strings.Repeat("ă", maxUsernameLength), Password: "password1"}, nil},
+		{"invalid email", RegisterRequest{Email: "ann", Password: "password1"}, ErrInvalidEmail},
+		{"email with a name", RegisterRequest{Email: "Ann <ann@example.com>", Password: "password1"}, ErrInvalidEmail},
+		{"short password", RegisterRequest{Email: "ann@example.com", Password: "short"}, ErrWeakPassword},
+		{"password too long", RegisterRequest{Email: "ann@example.com", Password: strings.Repeat("p", maxPasswordBytes+1)}, ErrPasswordTooLong},
+		{"multibyte password too long", RegisterRequest{Email: "ann@example.com", Password: strings.Repeat("ă", 37)}, ErrPasswordTooLong},
+		{"username like an email", RegisterRequest{Email: "ann@example.com", Username: "bob@example.com", Password: "password1"}, ErrInvalidUsername},
+		{"username too long", RegisterRequest{Email: "ann@example.com", Username: strings.Repeat("a", maxUsernameLength+1), Password: "password1"}, ErrInvalidUsername},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemStore()
+			res, err := newTestService(store).Register(context.Background(), tt.req)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				if len(store.users) != 0 {
+					t.Error("an invalid registration created a user")
+				}
+				return
+			}
+			if res.User.Email != strings.ToLower(strings.TrimSpace(tt.req.Email)) || res.AccessToken == "" || res.RefreshToken == "" {
+				t.Errorf("response = %+v", res)
+			}
+			if name := strings.TrimSpace(tt.req.Username); (name == "") != (res.User.Username == nil) || name != "" && *res.User.Username != name {
+				t.Errorf("username = %v, want %q", res.User.Username, name)
+			}
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	svc := newTestService(store)
+	if _, err := svc.Register(ctx, RegisterRequest{Email: "ann@example.com", Username: "ann", Password: "password1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Register(ctx, RegisterRequest{Email: "ann@example.com", Username: "other", Password: "password1"}); !errors.Is(err, ErrUserExists) {
+		t.Errorf("duplicate email: %v", err)
+	}
+
+	tests := []struct {
+		login, password string
+		want            error
+	}{
+		{"ann@example.com", "password1", nil},
+		{" ANN@example.com ", "password1", nil},
+		{"ann", "password1", nil},
+		{"ann", "password2", ErrInvalidCredentials},
+		{"bob", "password1", ErrInvalidCredentials},
+		{"", "password1", ErrInvalidCredentials},
+	}
+	for _, tt := range tests {
+		res, err := svc.Login(ctx, LoginRequest{Login: tt.login, Password: tt.password})
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Login(%q, %q) err = %v, want %v", tt.login, tt.password, err, tt.want)
+		}
+		if err == nil && res.User.ID != 1 {
+			t.Errorf("Login(%q) logged in user %d", tt.login, res.User.ID)
+		}
+	}
+}
+
+func TestRefreshRotation(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	svc := newTestService(store)
+	first, err := svc.Register(ctx, RegisterRequest{Email: "ann@example.com", Password: "password1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := svc.Login(ctx, LoginRequest{Login: "ann@example.com", Password: "password1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := svc.Refresh(ctx, RefreshRequest{RefreshToken: first.RefreshToken})
+	if err != nil {
+		t.Fatalf("first refresh: %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken || second.AccessToken == "" || second.User != nil {
+		t.Errorf("refresh response = %+v", second)
+	}
+	if n := store.live(1); n != 2 {
+		t.Fatalf("%d live sessions after rotating one of two, want 2", n)
+	}
+
+	// Presenting the rotated token again means it leaked: every session of
+	// the user ends, including the one from the other login.
+	if _, err := svc.Refresh(ctx, RefreshRequest{RefreshToken: first.RefreshToken}); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("reused token: err = %v, want ErrInvalidRefreshToken", err)
+	}
+	if n := store.live(1); n != 0 {
+		t.Errorf("%d live sessions after reuse, want none", n)
+	}
+	for name, raw := range map[string]string{"rotated": second.RefreshToken, "other login": other.RefreshToken} {
+		if _, err := svc.Refresh(ctx, RefreshRequest{RefreshToken: raw}); !errors.Is(err, ErrInvalidRefreshToken) {
+			t.Errorf("%s token still refreshes: %v", name, err)
+		}
+	}
+}
+
+func TestRefreshRejects(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	svc := newTestService(store)
+	res, err := svc.Register(ctx, RegisterRequest{Email: "ann@example.com", Password: "password1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknown, err := token.NewManager(config.AuthConfig{JWTSecret: "test-secret", RefreshTTLHours: 1}).IssueRefresh(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, raw := range map[string]string{
+		"access token": res.AccessToken,
+		"garbage":      "garbage",
+		"not stored":   unknown.Token,
+		"logged out":   res.RefreshToken,
+		"empty":        "",
+	} {
+		if name == "logged out" {
+			if err := svc.Logout(ctx, RefreshRequest{RefreshToken: raw}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := svc.Refresh(ctx, RefreshRequest{RefreshToken: raw}); !errors.Is(err, ErrInvalidRefreshToken) {
+			t.Errorf("%s: err = %v, want ErrInvalidRefreshToken", name, err)
+		}
+	}
+}
+
+func TestRotation(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	revoked := now.Add(-time.Minute)
+	tests := []struct {
+		name string
+		rt   model.RefreshToken
+		want error
+	}{
+		{"live", model.RefreshToken{ExpiresAt: now.Add(time.Hour)}, nil},
+		{"expired", model.RefreshToken{ExpiresAt: now.Add(-time.Second)}, errTokenExpired},
+		{"revoked", model.RefreshToken{ExpiresAt: now.Add(time.Hour), RevokedAt: &revoked}, errTokenReused},
+		{"revoked and expired", model.RefreshToken{ExpiresAt: now.Add(-time.Hour), RevokedAt: &revoked}, errTokenReused},
+	}
+	for _, tt := range tests {
+		if got := rotation(&tt.rt, now); got != tt.want {
+			t.Errorf("%s: rotation = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package auth
+
+type RegisterRequest struct {
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// LoginRequest accepts either the email or the username as Login.
+type LoginRequest struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type UserResponse struct {
+	ID       int64   `json:"id"`
+	Email    string  `json:"email"`
+	Username *string `json:"username"`
+}
+
+type TokenResponse struct {
+	User         *UserResponse `json:"user,omitempty"`
+	AccessToken  string        `json:"access_token"`
+	RefreshToken string        `json:"refresh_token"`
+	TokenType    string        `json:"token_type"`
+	ExpiresIn    int64         `json:"expires_in"`
+}
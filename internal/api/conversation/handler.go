@@ -0,0 +1,121 @@
+package conversation
+
+import (
+	"ai-learn-english/internal/middleware"
+	"ai-learn-english/pkg/apperror"
+	"strconv"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+var (
+	ErrInvalidBody  = apperror.New("invalid_body", "request body is not valid JSON")
+	ErrInvalidQuery = apperror.New("invalid_query", "query parameters are not valid")
+)
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// List handles GET /conversations?archived=.
+func (h *Handler) List(c fiber.Ctx) error {
+	var req ListRequest
+	if err := c.Bind().Query(&req); err != nil {
+		return ErrInvalidQuery
+	}
+
+	res, err := h.svc.List(c.Context(), middleware.UserID(c), req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// Create handles POST /conversations.
+func (h *Handler) Create(c fiber.Ctx) error {
+	var req CreateRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return ErrInvalidBody
+	}
+
+	res, err := h.svc.Create(c.Context(), middleware.UserID(c), req)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(res)
+}
+
+// Get handles GET /conversations/:id.
+func (h *Handler) Get(c fiber.Ctx) error {
+	id, err := conversationID(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.svc.Get(c.Context(), middleware.UserID(c), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// Update handles PATCH /conversations/:id.
+func (h *Handler) Update(c fiber.Ctx) error {
+	id, err := conversationID(c)
+	if err != nil {
+		return err
+	}
+	var req UpdateRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return ErrInvalidBody
+	}
+
+	res, err := h.svc.Update(c.Context(), middleware.UserID(c), id, req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// Delete handles DELETE /conversations/:id.
+func (h *Handler) Delete(c fiber.Ctx) error {
+	id, err := conversationID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.svc.Delete(c.Context(), middleware.UserID(c), id); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Messages handles GET /conversations/:id/messages?before=&limit=.
+func (h *Handler) Messages(c fiber.Ctx) error {
+	id, err := conversationID(c)
+	if err != nil {
+		return err
+	}
+	var req MessagesRequest
+	if err := c.Bind().Query(&req); err != nil {
+		return ErrInvalidQuery
+	}
+
+	res, err := h.svc.Messages(c.Context(), middleware.UserID(c), id, req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+func conversationID(c fiber.Ctx) (int64, error) {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidID
+	}
+	return id, nil
+}
@@ -0,0 +1,77 @@
+package conversation
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"context"
+	"errors"
+
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+)
+
+// Repository persists conversations through the generated query package.
+type Repository struct {
+	q *query.Query
+}
+
+func NewRepository(q *query.Query) *Repository {
+	return &Repository{q: q}
+}
+
+// List returns the user's conversations, most recently active first.
+func (r *Repository) List(ctx context.Context, userID int64, archived bool) ([]*model.Conversation, error) {
+	c := r.q.Conversation
+	do := c.WithContext(ctx).Where(c.UserID.Eq(userID))
+	if !archived {
+		do = do.Where(c.ArchivedAt.IsNull())
+	}
+	return do.Order(c.UpdatedAt.Desc(), c.ID.Desc()).Find()
+}
+
+// FindOwned returns the conversation with id if it belongs to userID, or
+// nil when there is none.
+func (r *Repository) FindOwned(ctx context.Context, userID, id int64) (*model.Conversation, error) {
+	c := r.q.Conversation
+	conv, err := c.WithContext(ctx).Where(c.ID.Eq(id), c.UserID.Eq(userID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return conv, err
+}
+
+// DocumentOwned reports whether documentID exists and belongs to userID.
+func (r *Repository) DocumentOwned(ctx context.Context, userID, documentID int64) (bool, error) {
+	d := r.q.Document
+	n, err := d.WithContext(ctx).Where(d.ID.Eq(documentID), d.UserID.Eq(userID)).Count()
+	return n > 0, err
+}
+
+func (r *Repository) Create(ctx context.Context, conv *model.Conversation) error {
+	return r.q.Conversation.WithContext(ctx).Create(conv)
+}
+
+func (r *Repository) Update(ctx context.Context, id int64, columns ...field.AssignExpr) error {
+	c := r.q.Conversation
+	_, err := c.WithContext(ctx).Where(c.ID.Eq(id)).UpdateSimple(columns...)
+	return err
+}
+
+// Delete removes the conversation; its messages go with it through the
+// foreign key.
+func (r *Repository) Delete(ctx context.Context, id int64) error {
+	c := r.q.Conversation
+	_, err := c.WithContext(ctx).Where(c.ID.Eq(id)).Delete()
+	return err
+}
+
+// Messages returns up to limit messages of the conversation with an id
+// below before, newest first. A before of 0 starts from the latest message.
+func (r *Repository) Messages(ctx context.Context, conversationID, before int64, limit int) ([]*model.Message, error) {
+	m := r.q.Message
+	do := m.WithContext(ctx).Where(m.ConversationID.Eq(conversationID))
+	if before > 0 {
+		do = do.Where(m.ID.Lt(before))
+	}
+	return do.Order(m.ID.Desc()).Limit(limit).Find()
+}
@@ -0,0 +1,19 @@
+package conversation
+
+import (
+	"ai-learn-english/internal/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// RegisterRoutes registers conversation-related routes on the provided router.
+func RegisterRoutes(r fiber.Router, h *Handler) {
+	grp := r.Group("/conversations", middleware.RequireUser())
+
+	grp.Get("/", h.List)
+	grp.Post("/", h.Create)
+	grp.Get("/:id", h.Get)
+	grp.Patch("/:id", h.Update)
+	grp.Delete("/:id", h.Delete)
+	grp.Get("/:id/messages", h.Messages)
+}
@@ -0,0 +1,195 @@
+package conversation
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/pkg/apperror"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gen/field"
+)
+
+const (
+	defaultMessagesLimit = 50
+	maxMessagesLimit     = 200
+
+	// maxTitleLength matches the title and topic columns.
+	maxTitleLength = 255
+)
+
+var (
+	ErrInvalidID            = apperror.New("invalid_id", "conversation id must be a positive integer")
+	ErrConversationNotFound = apperror.New("conversation_not_found", "conversation not found").WithStatus(http.StatusNotFound)
+	ErrDocumentNotFound     = apperror.New("document_not_found", "document not found").WithStatus(http.StatusNotFound)
+	ErrEmptyTitle           = apperror.New("empty_title", "title must not be empty")
+	ErrTitleTooLong         = apperror.New("title_too_long", fmt.Sprintf("title and topic must be at most %d characters", maxTitleLength))
+)
+
+// Service manages a user's conversation threads with the teacher.
+type Service struct {
+	repo *Repository
+}
+
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// List returns the user's conversations, leaving archived ones out unless
+// req.Archived is set.
+func (s *Service) List(ctx context.Context, userID int64, req ListRequest) (*ListResponse, error) {
+	convs, err := s.repo.List(ctx, userID, req.Archived)
+	if err != nil {
+		return nil, fmt.Errorf("list conversations: %w", err)
+	}
+	return &ListResponse{Conversations: convs}, nil
+}
+
+// Create starts an empty conversation. Without a title one is generated
+// from the first exchange with the teacher.
+func (s *Service) Create(ctx context.Context, userID int64, req CreateRequest) (*model.Conversation, error) {
+	title, err := optionalText(req.Title)
+	if err != nil {
+		return nil, err
+	}
+	topic, err := optionalText(req.Topic)
+	if err != nil {
+		return nil, err
+	}
+	if req.DocumentID != nil {
+		owned, err := s.repo.DocumentOwned(ctx, userID, *req.DocumentID)
+		if err != nil {
+			return nil, fmt.Errorf("check document: %w", err)
+		}
+		if !owned {
+			return nil, ErrDocumentNotFound
+		}
+	}
+
+	conv := &model.Conversation{UserID: userID, Title: title, Topic: topic, DocumentID: req.DocumentID}
+	if err := s.repo.Create(ctx, conv); err != nil {
+		return nil, fmt.Errorf("create conversation: %w", err)
+	}
+	return s.get(ctx, userID, conv.ID)
+}
+
+// Get returns one of the user's conversations.
+func (s *Service) Get(ctx context.Context, userID, id int64) (*model.Conversation, error) {
+	return s.get(ctx, userID, id)
+}
+
+// Update renames, re-topics, archives or restores a conversation.
+func (s *Service) Update(ctx context.Context, userID, id int64, req UpdateRequest) (*model.Conversation, error) {
+	conv, err := s.get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	c := s.repo.q.Conversation
+	var columns []field.AssignExpr
+	if req.Title != nil {
+		title, err := optionalText(req.Title)
+		if err != nil {
+			return nil, err
+		}
+		if title == nil {
+			return nil, ErrEmptyTitle
+		}
+		columns = append(columns, c.Title.Value(*title))
+	}
+	if req.Topic != nil {
+		topic, err := optionalText(req.Topic)
+		if err != nil {
+			return nil, err
+		}
+		if topic == nil {
+			columns = append(columns, c.Topic.Null())
+		} else {
+			columns = append(columns, c.Topic.Value(*topic))
+		}
+	}
+	if req.Archived != nil {
+		switch {
+		case *req.Archived && conv.ArchivedAt == nil:
+			columns = append(columns, c.ArchivedAt.Value(time.Now()))
+		case !*req.Archived && conv.ArchivedAt != nil:
+			columns = append(columns, c.ArchivedAt.Null())
+		}
+	}
+	if len(columns) == 0 {
+		return conv, nil
+	}
+
+	if err := s.repo.Update(ctx, id, columns...); err != nil {
+		return nil, fmt.Errorf("update conversation %d: %w", id, err)
+	}
+	return s.get(ctx, userID, id)
+}
+
+// Delete removes a conversation together with its messages.
+func (s *Service) Delete(ctx context.Context, userID, id int64) error {
+	if _, err := s.get(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete conversation %d: %w", id, err)
+	}
+	return nil
+}
+
+// Messages returns a page of the conversation's messages ending just before
+// the req.Before cursor, or at the latest message when it is unset.
+func (s *Service) Messages(ctx context.Context, userID, id int64, req MessagesRequest) (*MessagesResponse, error) {
+	if _, err := s.get(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultMessagesLimit
+	}
+	limit = min(limit, maxMessagesLimit)
+
+	// One extra row tells whether there is an older page.
+	msgs, err := s.repo.Messages(ctx, id, req.Before, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("list messages of conversation %d: %w", id, err)
+	}
+	res := &MessagesResponse{}
+	if len(msgs) > limit {
+		msgs = msgs[:limit]
+		res.NextBefore = &msgs[limit-1].ID
+	}
+	slices.Reverse(msgs)
+	res.Messages = msgs
+	return res, nil
+}
+
+func (s *Service) get(ctx context.Context, userID, id int64) (*model.Conversation, error) {
+	conv, err := s.repo.FindOwned(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("find conversation %d: %w", id, err)
+	}
+	if conv == nil {
+		return nil, ErrConversationNotFound
+	}
+	return conv, nil
+}
+
+// optionalText trims s and returns nil for a missing or blank value.
+func optionalText(s *string) (*string, error) {
+	if s == nil {
+		return nil, nil
+	}
+	v := strings.TrimSpace(*s)
+	if v == "" {
+		return nil, nil
+	}
+	if utf8.RuneCountInString(v) > maxTitleLength {
+		return nil, ErrTitleTooLong
+	}
+	return &v, nil
+}
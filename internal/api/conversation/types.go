@@ -0,0 +1,44 @@
+package conversation
+
+import "ai-learn-english/internal/database/model"
+
+// CreateRequest is the body of POST /conversations. DocumentID scopes the
+// teacher's retrieval in this conversation to one of the user's documents.
+type CreateRequest struct {
+	Title      *string `json:"title"`
+	Topic      *string `json:"topic"`
+	DocumentID *int64  `json:"document_id"`
+}
+
+// UpdateRequest is the body of PATCH /conversations/:id. Fields left out
+// are not changed.
+type UpdateRequest struct {
+	Title    *string `json:"title"`
+	Topic    *string `json:"topic"`
+	Archived *bool   `json:"archived"`
+}
+
+// ListRequest holds the query string of GET /conversations. Archived
+// conversations are only listed when Archived is set.
+type ListRequest struct {
+	Archived bool `query:"archived"`
+}
+
+type ListResponse struct {
+	Conversations []*model.Conversation `json:"conversations"`
+}
+
+// MessagesRequest holds the query string of GET /conversations/:id/messages.
+// Before is the cursor: only messages with a smaller id are returned.
+type MessagesRequest struct {
+	Before int64 `query:"before"`
+	Limit  int   `query:"limit"`
+}
+
+// MessagesResponse is one page of messages in chronological order.
+// NextBefore is the cursor for the page of older messages, null when this
+// page reaches the start of the conversation.
+type MessagesResponse struct {
+	Messages   []*model.Message `json:"messages"`
+	NextBefore *int64           `json:"next_before"`
+}
@@ -0,0 +1,108 @@
+package document
+
+import (
+	"ai-learn-english/internal/middleware"
+	"ai-learn-english/pkg/apperror"
+	"ai-learn-english/pkg/sse"
+	"bufio"
+	"context"
+	"strconv"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+var ErrInvalidQuery = apperror.New("invalid_query", "query parameters are not valid")
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Upload handles multipart POST /documents with the PDF in the "file" field
+// and an optional "title" field. New documents are answered with 202 since
+// they are processed in the background.
+func (h *Handler) Upload(c fiber.Ctx) error {
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return ErrMissingFile
+	}
+
+	res, err := h.svc.Upload(c.Context(), middleware.UserID(c), fh, c.FormValue("title"))
+	if err != nil {
+		return err
+	}
+
+	status := fiber.StatusAccepted
+	if res.Duplicate {
+		status = fiber.StatusOK
+	}
+	return c.Status(status).JSON(res)
+}
+
+// Status handles GET /documents/:id/status. Clients that accept
+// text/event-stream, such as EventSource, get a stream of "status" events
+// instead, ending once the document is ready or failed.
+func (h *Handler) Status(c fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return ErrInvalidID
+	}
+	userID := middleware.UserID(c)
+
+	res, err := h.svc.Status(c.Context(), userID, id)
+	if err != nil {
+		return err
+	}
+	if !sse.Accepts(c.Get(fiber.HeaderAccept)) {
+		return c.JSON(res)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// The writer runs after the handler has returned, so it must not use c.
+	return c.SendStreamWriter(func(w *bufio.Writer) {
+		events := sse.NewWriter(w)
+		h.svc.Watch(context.Background(), userID, id,
+			events.Event,
+			func() error { return events.Comment("keep-alive") },
+		)
+	})
+}
+
+// List handles GET /documents?level=&min_level=&max_level=&status=&limit=&offset=.
+func (h *Handler) List(c fiber.Ctx) error {
+	var req ListRequest
+	if err := c.Bind().Query(&req); err != nil {
+		return ErrInvalidQuery
+	}
+
+	res, err := h.svc.List(c.Context(), middleware.UserID(c), req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// Vocabulary handles GET /documents/:id/vocabulary?level=&min_level=&limit=&offset=.
+func (h *Handler) Vocabulary(c fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return ErrInvalidID
+	}
+	var req VocabularyRequest
+	if err := c.Bind().Query(&req); err != nil {
+		return ErrInvalidQuery
+	}
+
+	res, err := h.svc.Vocabulary(c.Context(), middleware.UserID(c), id, req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
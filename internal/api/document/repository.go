@@ -0,0 +1,109 @@
+package document
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/internal/ingest"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Repository persists documents through the generated query package.
+type Repository struct {
+	q *query.Query
+}
+
+func NewRepository(q *query.Query) *Repository {
+	return &Repository{q: q}
+}
+
+// FindByUserAndSha256 returns the user's document with the given hash, or nil
+// when the user has not uploaded that file yet.
+func (r *Repository) FindByUserAndSha256(ctx context.Context, userID int64, sha256 string) (*model.Document, error) {
+	d := r.q.Document
+	doc, err := d.WithContext(ctx).Where(d.UserID.Eq(userID), d.Sha256.Eq(sha256)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return doc, err
+}
+
+func (r *Repository) Create(ctx context.Context, doc *model.Document) error {
+	return r.q.Document.WithContext(ctx).Create(doc)
+}
+
+func (r *Repository) Delete(ctx context.Context, id int64) error {
+	_, err := r.q.Document.WithContext(ctx).Where(r.q.Document.ID.Eq(id)).Delete()
+	return err
+}
+
+// FindOwned returns the document with id when it belongs to userID, or nil.
+func (r *Repository) FindOwned(ctx context.Context, userID, id int64) (*model.Document, error) {
+	d := r.q.Document
+	doc, err := d.WithContext(ctx).Where(d.ID.Eq(id), d.UserID.Eq(userID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return doc, err
+}
+
+// Requeue moves a failed document back to uploaded so it can be processed
+// again. It reports false when the document was not failed.
+func (r *Repository) Requeue(ctx context.Context, doc *model.Document) (bool, error) {
+	d := r.q.Document
+	now := time.Now()
+	info, err := d.WithContext(ctx).Where(d.ID.Eq(doc.ID), d.Status.Eq(ingest.StatusFailed)).
+		UpdateSimple(d.Status.Value(ingest.StatusUploaded), d.StatusReason.Null(), d.StatusUpdatedAt.Value(now))
+	if err != nil || info.RowsAffected == 0 {
+		return false, err
+	}
+	doc.Status = ingest.StatusUploaded
+	doc.StatusReason = nil
+	doc.StatusUpdatedAt = &now
+	return true, nil
+}
+
+// Vocabulary returns a page of the vocabulary of documentID restricted to
+// levels, most frequent first, and the number of matching words.
+func (r *Repository) Vocabulary(ctx context.Context, documentID int64, levels []string, offset, limit int) ([]*model.DocumentVocabulary, int64, error) {
+	v := r.q.DocumentVocabulary
+	do := v.WithContext(ctx).Where(v.DocumentID.Eq(documentID))
+	if len(levels) > 0 {
+		do = do.Where(v.CefrLevel.In(levels...))
+	}
+	return do.Order(v.Occurrences.Desc(), v.Lemma).FindByPage(offset, limit)
+}
+
+// WordBank returns the user's word bank entries for lemmas by lemma.
+func (r *Repository) WordBank(ctx context.Context, userID int64, lemmas []string) (map[string]*model.WordBank, error) {
+	out := make(map[string]*model.WordBank)
+	if len(lemmas) == 0 {
+		return out, nil
+	}
+	w := r.q.WordBank
+	entries, err := w.WithContext(ctx).Where(w.UserID.Eq(userID), w.Lemma.In(lemmas...)).Find()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		out[e.Lemma] = e
+	}
+	return out, nil
+}
+
+// List returns a page of the user's documents restricted to levels and
+// status when given, newest first, and the number of matching documents.
+func (r *Repository) List(ctx context.Context, userID int64, levels []string, status string, offset, limit int) ([]*model.Document, int64, error) {
+	d := r.q.Document
+	do := d.WithContext(ctx).Where(d.UserID.Eq(userID))
+	if len(levels) > 0 {
+		do = do.Where(d.CefrLevel.In(levels...))
+	}
+	if status != "" {
+		do = do.Where(d.Status.Eq(status))
+	}
+	return do.Order(d.UploadedAt.Desc(), d.ID.Desc()).FindByPage(offset, limit)
+}
@@ -0,0 +1,17 @@
+package document
+
+import (
+	"ai-learn-english/internal/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// RegisterRoutes registers document-related routes on the provided router.
+func RegisterRoutes(r fiber.Router, h *Handler) {
+	grp := r.Group("/documents", middleware.RequireUser())
+
+	grp.Get("/", h.List)
+	grp.Post("/", h.Upload)
+	grp.Get("/:id/status", h.Status)
+	grp.Get("/:id/vocabulary", h.Vocabulary)
+}
@@ -0,0 +1,377 @@
+package document
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/ingest"
+	"ai-learn-english/pkg/apperror"
+	"ai-learn-english/pkg/logger"
+	"ai-learn-english/pkg/vocab"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrMissingFile         = apperror.New("missing_file", "a file must be uploaded in the \"file\" field")
+	ErrUnsupportedFileType = apperror.New("unsupported_file_type", "only PDF documents are supported").WithStatus(http.StatusUnsupportedMediaType)
+	ErrInvalidID           = apperror.New("invalid_id", "document id must be a positive integer")
+	ErrDocumentNotFound    = apperror.New("document_not_found", "document not found").WithStatus(http.StatusNotFound)
+	ErrInvalidLevel        = apperror.New("invalid_level", "level must be one of "+strings.Join(vocab.Levels, ", "))
+	ErrInvalidLevelRange   = apperror.New("invalid_level_range", "min_level must not be above max_level")
+	ErrInvalidStatus       = apperror.New("invalid_status", "status must be one of "+strings.Join(statuses, ", "))
+)
+
+// statuses lists the document states a listing can be filtered by.
+var statuses = []string{
+	ingest.StatusUploaded, ingest.StatusExtracting, ingest.StatusChunking,
+	ingest.StatusEmbedding, ingest.StatusReady, ingest.StatusFailed,
+}
+
+const (
+	defaultVocabularyLimit = 100
+	maxVocabularyLimit     = 500
+
+	defaultListLimit = 20
+	maxListLimit     = 100
+
+	// statusPollInterval is how often the status stream checks for changes.
+	statusPollInterval = time.Second
+	// keepAliveInterval bounds the silence on a status stream, which is
+	// also how long it takes to notice a client that went away.
+	keepAliveInterval = 15 * time.Second
+)
+
+// Scheduler queues a stored document for ingestion.
+type Scheduler interface {
+	Schedule(ctx context.Context, doc *model.Document) error
+}
+
+// Service stores uploaded files on disk and records them as documents.
+type Service struct {
+	repo       *Repository
+	scheduler  Scheduler
+	storageDir string
+}
+
+func NewService(repo *Repository, scheduler Scheduler, storageDir string) *Service {
+	return &Service{repo: repo, scheduler: scheduler, storageDir: storageDir}
+}
+
+// Upload streams the file to disk while hashing it. When the user already has
+// a document with the same SHA-256 the stored document is returned and the
+// new copy is discarded, so repeated uploads of the same file are idempotent.
+// New documents are queued for ingestion and returned right away; uploading
+// a document whose processing failed queues it again.
+func (s *Service) Upload(ctx context.Context, userID int64, fh *multipart.FileHeader, title string) (*UploadResponse, error) {
+	if fh == nil {
+		return nil, ErrMissingFile
+	}
+	ext := strings.ToLower(filepath.Ext(fh.Filename))
+	if ext != ".pdf" {
+		return nil, ErrUnsupportedFileType
+	}
+
+	userDir := filepath.Join(s.storageDir, strconv.FormatInt(userID, 10))
+	if err := os.MkdirAll(userDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create storage dir: %w", err)
+	}
+
+	tmpPath, sum, err := s.writeTemp(userDir, fh)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmpPath)
+
+	existing, err := s.repo.FindByUserAndSha256(ctx, userID, sum)
+	if err != nil {
+		return nil, fmt.Errorf("find document by sha256: %w", err)
+	}
+	if existing != nil {
+		if err := s.retryFailed(ctx, existing); err != nil {
+			return nil, err
+		}
+		return &UploadResponse{Document: existing, Duplicate: true}, nil
+	}
+
+	finalPath := filepath.Join(userDir, sum+ext)
+	if err := os.Rename(tmpPath, finalPath); err != nil {
+		return nil, fmt.Errorf("move upload into place: %w", err)
+	}
+
+	if title == "" {
+		title = strings.TrimSuffix(fh.Filename, filepath.Ext(fh.Filename))
+	}
+	filename := fh.Filename
+	doc := &model.Document{
+		UserID:           userID,
+		Title:            &title,
+		OriginalFilename: &filename,
+		FilePath:         &finalPath,
+		Sha256:           &sum,
+		Status:           ingest.StatusUploaded,
+	}
+	if err := s.repo.Create(ctx, doc); err != nil {
+		// A concurrent upload of the same file won the race; return its row.
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			existing, findErr := s.repo.FindByUserAndSha256(ctx, userID, sum)
+			if findErr == nil && existing != nil {
+				return &UploadResponse{Document: existing, Duplicate: true}, nil
+			}
+		} else {
+			// The winner of a duplicate race owns the same path, so the
+			// file is only ours to remove when no row can point at it.
+			os.Remove(finalPath)
+		}
+		return nil, fmt.Errorf("create document: %w", err)
+	}
+
+	if err := s.scheduler.Schedule(ctx, doc); err != nil {
+		// Without a job nothing would ever process the row, so drop it and
+		// let the client retry the upload.
+		if delErr := s.repo.Delete(ctx, doc.ID); delErr != nil {
+			logger.Error(delErr, "delete unscheduled document %d", doc.ID)
+		}
+		os.Remove(finalPath)
+		return nil, fmt.Errorf("schedule document %d: %w", doc.ID, err)
+	}
+	return &UploadResponse{Document: doc}, nil
+}
+
+// retryFailed schedules doc again when its previous processing failed.
+func (s *Service) retryFailed(ctx context.Context, doc *model.Document) error {
+	requeued, err := s.repo.Requeue(ctx, doc)
+	if err != nil {
+		return fmt.Errorf("requeue document %d: %w", doc.ID, err)
+	}
+	if !requeued {
+		return nil
+	}
+	if err := s.scheduler.Schedule(ctx, doc); err != nil {
+		return fmt.Errorf("schedule document %d: %w", doc.ID, err)
+	}
+	return nil
+}
+
+// Status returns the processing status of the user's document id.
+func (s *Service) Status(ctx context.Context, userID, id int64) (*StatusResponse, error) {
+	doc, err := s.repo.FindOwned(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("find document %d: %w", id, err)
+	}
+	if doc == nil {
+		return nil, ErrDocumentNotFound
+	}
+	return newStatusResponse(doc), nil
+}
+
+// Watch polls the status of the user's document and sends a "status"
+// event with every change, starting with the current status, until the
+// document is ready or failed, send fails or ctx is done. A document that
+// disappears, e.g. because it was deleted, ends the watch with an "error"
+// event; other errors are logged and the next poll tries again. Errors from
+// send end the watch quietly since they mean the client has gone away;
+// keepAlive is called during long stretches without changes for the same
+// reason.
+func (s *Service) Watch(ctx context.Context, userID, id int64, send func(event string, data any) error, keepAlive func() error) {
+	var last *StatusResponse
+	ticker := time.NewTicker(statusPollInterval)
+	defer ticker.Stop()
+	lastSent := time.Now()
+
+	for {
+		cur, err := s.Status(ctx, userID, id)
+		switch {
+		case errors.Is(err, ErrDocumentNotFound):
+			send("error", ErrDocumentNotFound)
+			return
+		case err != nil:
+			logger.Error(err, "watch status of document %d", id)
+		case last == nil || statusChanged(last, cur):
+			if send("status", cur) != nil {
+				return
+			}
+			last, lastSent = cur, time.Now()
+			if ingest.Terminal(cur.Status) {
+				return
+			}
+		}
+		if time.Since(lastSent) >= keepAliveInterval {
+			if keepAlive() != nil {
+				return
+			}
+			lastSent = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func statusChanged(a, b *StatusResponse) bool {
+	return a.Status != b.Status || a.ChunksTotal != b.ChunksTotal || a.ChunksEmbedded != b.ChunksEmbedded
+}
+
+func newStatusResponse(doc *model.Document) *StatusResponse {
+	res := &StatusResponse{
+		DocumentID:     doc.ID,
+		Status:         doc.Status,
+		Reason:         doc.StatusReason,
+		ChunksTotal:    doc.ChunksTotal,
+		ChunksEmbedded: doc.ChunksEmbedded,
+		UpdatedAt:      doc.StatusUpdatedAt,
+	}
+	switch {
+	case doc.Status == ingest.StatusReady:
+		res.Progress = 1
+	case doc.ChunksTotal > 0:
+		res.Progress = float64(doc.ChunksEmbedded) / float64(doc.ChunksTotal)
+	}
+	return res
+}
+
+// writeTemp copies the upload into a temporary file in dir and returns its
+// path together with the hex encoded SHA-256 of the content.
+func (s *Service) writeTemp(dir string, fh *multipart.FileHeader) (string, string, error) {
+	src, err := fh.Open()
+	if err != nil {
+		return "", "", fmt.Errorf("open upload: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.CreateTemp(dir, "upload-*.tmp")
+	if err != nil {
+		return "", "", fmt.Errorf("create temp file: %w", err)
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(dst, h), src); err != nil {
+		dst.Close()
+		os.Remove(dst.Name())
+		return "", "", fmt.Errorf("write upload: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(dst.Name())
+		return "", "", fmt.Errorf("close upload: %w", err)
+	}
+	return dst.Name(), hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Vocabulary returns a page of the candidate vocabulary of one of the
+// user's documents.
+func (s *Service) Vocabulary(ctx context.Context, userID, id int64, req VocabularyRequest) (*VocabularyResponse, error) {
+	var levels []string
+	switch {
+	case req.Level != "":
+		if !slices.Contains(vocab.Levels, req.Level) {
+			return nil, ErrInvalidLevel
+		}
+		levels = []string{req.Level}
+	case req.MinLevel != "":
+		i := slices.Index(vocab.Levels, req.MinLevel)
+		if i < 0 {
+			return nil, ErrInvalidLevel
+		}
+		levels = vocab.Levels[i:]
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultVocabularyLimit
+	}
+	limit = min(limit, maxVocabularyLimit)
+
+	doc, err := s.repo.FindOwned(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("find document %d: %w", id, err)
+	}
+	if doc == nil {
+		return nil, ErrDocumentNotFound
+	}
+
+	words, total, err := s.repo.Vocabulary(ctx, doc.ID, levels, max(req.Offset, 0), limit)
+	if err != nil {
+		return nil, fmt.Errorf("list vocabulary of document %d: %w", doc.ID, err)
+	}
+	lemmas := make([]string, len(words))
+	for i, w := range words {
+		lemmas[i] = w.Lemma
+	}
+	saved, err := s.repo.WordBank(ctx, userID, lemmas)
+	if err != nil {
+		return nil, fmt.Errorf("load word bank: %w", err)
+	}
+
+	res := &VocabularyResponse{DocumentID: doc.ID, ExtractedAt: doc.VocabularyAt, Total: total, Items: make([]VocabularyItem, len(words))}
+	for i, w := range words {
+		entry := saved[w.Lemma]
+		res.Items[i] = VocabularyItem{DocumentVocabulary: w, InWordBank: entry != nil, Known: entry != nil && entry.Known}
+	}
+	return res, nil
+}
+
+// List returns a page of the user's documents, optionally restricted to a
+// CEFR level or range of levels and to a processing status.
+func (s *Service) List(ctx context.Context, userID int64, req ListRequest) (*ListResponse, error) {
+	levels, err := levelRange(req.Level, req.MinLevel, req.MaxLevel)
+	if err != nil {
+		return nil, err
+	}
+	if req.Status != "" && !slices.Contains(statuses, req.Status) {
+		return nil, ErrInvalidStatus
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	limit = min(limit, maxListLimit)
+
+	docs, total, err := s.repo.List(ctx, userID, levels, req.Status, max(req.Offset, 0), limit)
+	if err != nil {
+		return nil, fmt.Errorf("list documents: %w", err)
+	}
+	return &ListResponse{Total: total, Items: docs}, nil
+}
+
+// levelRange returns the CEFR levels selected by an exact level or by
+// inclusive bounds, or nil when nothing restricts the level.
+func levelRange(level, minLevel, maxLevel string) ([]string, error) {
+	if level != "" {
+		if !slices.Contains(vocab.Levels, level) {
+			return nil, ErrInvalidLevel
+		}
+		return []string{level}, nil
+	}
+	if minLevel == "" && maxLevel == "" {
+		return nil, nil
+	}
+	lo, hi := 0, len(vocab.Levels)-1
+	if minLevel != "" {
+		if lo = slices.Index(vocab.Levels, minLevel); lo < 0 {
+			return nil, ErrInvalidLevel
+		}
+	}
+	if maxLevel != "" {
+		if hi = slices.Index(vocab.Levels, maxLevel); hi < 0 {
+			return nil, ErrInvalidLevel
+		}
+	}
+	if lo > hi {
+		return nil, ErrInvalidLevelRange
+	}
+	return vocab.Levels[lo : hi+1], nil
+}
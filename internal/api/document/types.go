@@ -0,0 +1,73 @@
+package document
+
+import (
+	"ai-learn-english/internal/database/model"
+	"time"
+)
+
+// UploadResponse is returned by POST /documents. Duplicate is true when the
+// same file had already been uploaded by the user and the stored document
+// is returned instead of a new one.
+type UploadResponse struct {
+	Document  *model.Document `json:"document"`
+	Duplicate bool            `json:"duplicate"`
+}
+
+// StatusResponse is returned by GET /documents/:id/status and sent as the
+// "status" event of its event stream. Progress goes from 0 to 1 and only
+// moves while chunks are being embedded.
+type StatusResponse struct {
+	DocumentID     int64      `json:"document_id"`
+	Status         string     `json:"status"`
+	Reason         *string    `json:"reason,omitempty"`
+	ChunksTotal    int32      `json:"chunks_total"`
+	ChunksEmbedded int32      `json:"chunks_embedded"`
+	Progress       float64    `json:"progress"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
+
+// VocabularyRequest holds the query string of GET /documents/:id/vocabulary.
+// Level keeps only words of that CEFR level, MinLevel words of that level
+// or above.
+type VocabularyRequest struct {
+	Level    string `query:"level"`
+	MinLevel string `query:"min_level"`
+	Limit    int    `query:"limit"`
+	Offset   int    `query:"offset"`
+}
+
+// VocabularyItem is a candidate word of a document. InWordBank and Known
+// tell whether the user has saved the word and marked it known.
+type VocabularyItem struct {
+	*model.DocumentVocabulary
+	InWordBank bool `json:"in_word_bank"`
+	Known      bool `json:"known"`
+}
+
+// VocabularyResponse is one page of a document's vocabulary, most frequent
+// words first. ExtractedAt is null while the vocabulary has not been
+// extracted yet.
+type VocabularyResponse struct {
+	DocumentID  int64            `json:"document_id"`
+	ExtractedAt *time.Time       `json:"extracted_at"`
+	Total       int64            `json:"total"`
+	Items       []VocabularyItem `json:"items"`
+}
+
+// ListRequest holds the query string of GET /documents. Level keeps only
+// documents of that CEFR level, MinLevel and MaxLevel bound the level from
+// either side. Documents not scored yet only match when no level is asked.
+type ListRequest struct {
+	Level    string `query:"level"`
+	MinLevel string `query:"min_level"`
+	MaxLevel string `query:"max_level"`
+	Status   string `query:"status"`
+	Limit    int    `query:"limit"`
+	Offset   int    `query:"offset"`
+}
+
+// ListResponse is one page of the user's documents, newest first.
+type ListResponse struct {
+	Total int64             `json:"total"`
+	Items []*model.Document `json:"items"`
+}
@@ -0,0 +1,49 @@
+package exercise
+
+import (
+	"ai-learn-english/internal/middleware"
+	"ai-learn-english/pkg/apperror"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+var (
+	ErrInvalidBody  = apperror.New("invalid_body", "request body is not valid JSON")
+	ErrInvalidQuery = apperror.New("invalid_query", "query parameters are not valid")
+)
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Cloze handles GET /exercises/cloze?document_id=&chunk_id=&seed=&target=&blanks=.
+func (h *Handler) Cloze(c fiber.Ctx) error {
+	var req ClozeRequest
+	if err := c.Bind().Query(&req); err != nil {
+		return ErrInvalidQuery
+	}
+
+	res, err := h.svc.Cloze(c.Context(), middleware.UserID(c), req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// Check handles POST /exercises/cloze/check.
+func (h *Handler) Check(c fiber.Ctx) error {
+	var req CheckRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return ErrInvalidBody
+	}
+
+	res, err := h.svc.Check(c.Context(), middleware.UserID(c), req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
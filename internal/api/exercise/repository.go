@@ -0,0 +1,62 @@
+package exercise
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// Repository reads the passages and words exercises are built from through
+// the generated query package.
+type Repository struct {
+	q *query.Query
+}
+
+func NewRepository(q *query.Query) *Repository {
+	return &Repository{q: q}
+}
+
+// DocumentOwned reports whether documentID exists and belongs to userID.
+func (r *Repository) DocumentOwned(ctx context.Context, userID, documentID int64) (bool, error) {
+	d := r.q.Document
+	n, err := d.WithContext(ctx).Where(d.ID.Eq(documentID), d.UserID.Eq(userID)).Count()
+	return n > 0, err
+}
+
+// ChunkIDs returns the ids of the document's chunks in reading order.
+func (r *Repository) ChunkIDs(ctx context.Context, documentID int64) ([]int64, error) {
+	c := r.q.Chunk
+	var ids []int64
+	err := c.WithContext(ctx).Where(c.DocumentID.Eq(documentID)).Order(c.ChunkIndex).Pluck(c.ID, &ids)
+	return ids, err
+}
+
+// Chunk returns the document's chunk with id, or nil when there is none.
+func (r *Repository) Chunk(ctx context.Context, documentID, id int64) (*model.Chunk, error) {
+	c := r.q.Chunk
+	chunk, err := c.WithContext(ctx).Where(c.ID.Eq(id), c.DocumentID.Eq(documentID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return chunk, err
+}
+
+// Vocabulary returns up to limit words of the document, most frequent
+// first. The order is stable so exercises can be rebuilt.
+func (r *Repository) Vocabulary(ctx context.Context, documentID int64, limit int) ([]*model.DocumentVocabulary, error) {
+	v := r.q.DocumentVocabulary
+	return v.WithContext(ctx).Where(v.DocumentID.Eq(documentID)).
+		Order(v.Occurrences.Desc(), v.Lemma).Limit(limit).Find()
+}
+
+// StudyLemmas returns the lemmas of the user's word bank not yet marked
+// known.
+func (r *Repository) StudyLemmas(ctx context.Context, userID int64) ([]string, error) {
+	w := r.q.WordBank
+	var lemmas []string
+	err := w.WithContext(ctx).Where(w.UserID.Eq(userID), w.Known.Is(false)).Pluck(w.Lemma, &lemmas)
+	return lemmas, err
+}
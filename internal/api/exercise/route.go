@@ -0,0 +1,15 @@
+package exercise
+
+import (
+	"ai-learn-english/internal/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// RegisterRoutes registers exercise routes on the provided router.
+func RegisterRoutes(r fiber.Router, h *Handler) {
+	grp := r.Group("/exercises", middleware.RequireUser())
+
+	grp.Get("/cloze", h.Cloze)
+	grp.Post("/cloze/check", h.Check)
+}
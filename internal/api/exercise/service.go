@@ -0,0 +1,184 @@
+package exercise
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/pkg/apperror"
+	"ai-learn-english/pkg/cloze"
+	"ai-learn-english/pkg/vocab"
+	"cmp"
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const (
+	maxBlanks = 15
+	// poolSize bounds the document words distractors are drawn from.
+	poolSize = 500
+	// maxSeed keeps generated seeds exact in JSON numbers.
+	maxSeed = 1 << 53
+)
+
+var targets = append([]string{TargetAuto, TargetWordBank}, vocab.PartsOfSpeech...)
+
+var (
+	ErrInvalidDocument  = apperror.New("invalid_document", "document_id must be a positive integer")
+	ErrInvalidTarget    = apperror.New("invalid_target", "target must be one of "+strings.Join(targets, ", "))
+	ErrInvalidBlanks    = apperror.New("invalid_blanks", fmt.Sprintf("blanks must be between 1 and %d", maxBlanks))
+	ErrTooManyAnswers   = apperror.New("too_many_answers", "there are more answers than blanks")
+	ErrDocumentNotFound = apperror.New("document_not_found", "document not found").WithStatus(http.StatusNotFound)
+	ErrChunkNotFound    = apperror.New("chunk_not_found", "chunk not found in this document").WithStatus(http.StatusNotFound)
+	ErrNoPassages       = apperror.New("document_not_chunked", "the document has no text to build exercises from yet").WithStatus(http.StatusConflict)
+	ErrNoBlanks         = apperror.New("no_blanks", "no word of this passage fits the target, try another passage or target").WithStatus(http.StatusConflict)
+	ErrExerciseChanged  = apperror.New("exercise_changed", "the exercise has changed since it was loaded, load it again").WithStatus(http.StatusConflict)
+)
+
+// Service builds reading exercises from the user's documents.
+type Service struct {
+	repo *Repository
+}
+
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Cloze builds a fill-in-the-blank exercise from a passage of a document.
+func (s *Service) Cloze(ctx context.Context, userID int64, req ClozeRequest) (*ClozeResponse, error) {
+	seed := rand.Uint64N(maxSeed)
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+	target := cmp.Or(req.Target, TargetAuto)
+
+	chunk, ex, err := s.build(ctx, userID, req.DocumentID, req.ChunkID, seed, target, req.Blanks)
+	if err != nil {
+		return nil, err
+	}
+	return &ClozeResponse{
+		Exercise:    ex,
+		DocumentID:  req.DocumentID,
+		ChunkID:     chunk.ID,
+		PageIndex:   chunk.PageIndex,
+		Seed:        seed,
+		Target:      target,
+		Fingerprint: ex.Fingerprint(),
+	}, nil
+}
+
+// Check rebuilds the exercise described by req and marks its answers.
+// Missing answers are wrong.
+func (s *Service) Check(ctx context.Context, userID int64, req CheckRequest) (*CheckResponse, error) {
+	chunkID := req.ChunkID
+	_, ex, err := s.build(ctx, userID, req.DocumentID, &chunkID, req.Seed, cmp.Or(req.Target, TargetAuto), req.BlankCount)
+	if err != nil {
+		return nil, err
+	}
+	if ex.Fingerprint() != req.Fingerprint {
+		return nil, ErrExerciseChanged
+	}
+	if len(req.Answers) > len(ex.Blanks) {
+		return nil, ErrTooManyAnswers
+	}
+
+	res := &CheckResponse{Total: len(ex.Blanks), Results: make([]BlankResult, len(ex.Blanks))}
+	for i, b := range ex.Blanks {
+		r := BlankResult{Number: b.Number, Expected: b.Answer}
+		if i < len(req.Answers) {
+			r.Answer = strings.TrimSpace(req.Answers[i])
+			r.Correct = b.Check(r.Answer)
+		}
+		if r.Correct {
+			res.Score++
+		}
+		res.Results[i] = r
+	}
+	return res, nil
+}
+
+// build returns the passage and the exercise for the given parameters.
+// The same parameters give the same exercise as long as the document's
+// vocabulary and, for word bank targets, the user's word bank do not
+// change.
+func (s *Service) build(ctx context.Context, userID, documentID int64, chunkID *int64, seed uint64, target string, blanks int) (*model.Chunk, *cloze.Exercise, error) {
+	if documentID <= 0 {
+		return nil, nil, ErrInvalidDocument
+	}
+	if !slices.Contains(targets, target) {
+		return nil, nil, ErrInvalidTarget
+	}
+	if blanks == 0 {
+		blanks = cloze.DefaultBlanks
+	}
+	if blanks < 1 || blanks > maxBlanks {
+		return nil, nil, ErrInvalidBlanks
+	}
+
+	owned, err := s.repo.DocumentOwned(ctx, userID, documentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("check document: %w", err)
+	}
+	if !owned {
+		return nil, nil, ErrDocumentNotFound
+	}
+	chunk, err := s.passage(ctx, documentID, chunkID, seed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	words, err := s.repo.Vocabulary(ctx, documentID, poolSize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load document vocabulary: %w", err)
+	}
+	pool := make([]cloze.Word, len(words))
+	for i, w := range words {
+		pool[i] = cloze.Word{Lemma: w.Lemma, Form: w.Form, Band: int(w.FrequencyBand)}
+	}
+
+	opts := cloze.Options{Blanks: blanks, Seed: seed}
+	switch target {
+	case TargetAuto, TargetWordBank:
+		lemmas, err := s.repo.StudyLemmas(ctx, userID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("load word bank: %w", err)
+		}
+		opts.Targets = make(map[string]bool, len(lemmas))
+		for _, l := range lemmas {
+			opts.Targets[l] = true
+		}
+		opts.OnlyTargets = target == TargetWordBank
+	default:
+		opts.POS = target
+	}
+
+	ex := cloze.Generate(chunk.Content, pool, opts)
+	if len(ex.Blanks) == 0 {
+		return nil, nil, ErrNoBlanks
+	}
+	return chunk, ex, nil
+}
+
+// passage returns the document's chunk with chunkID, or the one seed picks
+// when chunkID is nil.
+func (s *Service) passage(ctx context.Context, documentID int64, chunkID *int64, seed uint64) (*model.Chunk, error) {
+	if chunkID == nil {
+		ids, err := s.repo.ChunkIDs(ctx, documentID)
+		if err != nil {
+			return nil, fmt.Errorf("list chunks: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil, ErrNoPassages
+		}
+		chunkID = &ids[seed%uint64(len(ids))]
+	}
+	chunk, err := s.repo.Chunk(ctx, documentID, *chunkID)
+	if err != nil {
+		return nil, fmt.Errorf("load chunk %d: %w", *chunkID, err)
+	}
+	if chunk == nil {
+		return nil, ErrChunkNotFound
+	}
+	return chunk, nil
+}
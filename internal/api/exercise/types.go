@@ -0,0 +1,62 @@
+package exercise
+
+import "ai-learn-english/pkg/cloze"
+
+// Cloze targets. TargetAuto blanks words of the learner's word bank first
+// and other content words after them; TargetWordBank blanks only word bank
+// words. The parts of speech of vocab.PartsOfSpeech are targets too.
+const (
+	TargetAuto     = "auto"
+	TargetWordBank = "word_bank"
+)
+
+// ClozeRequest holds the query string of GET /exercises/cloze. Without
+// ChunkID the passage is picked from the document by the seed, and without
+// Seed a random one is used; the response tells both, so the same exercise
+// can be asked for again.
+type ClozeRequest struct {
+	DocumentID int64   `query:"document_id"`
+	ChunkID    *int64  `query:"chunk_id"`
+	Seed       *uint64 `query:"seed"`
+	Target     string  `query:"target"`
+	Blanks     int     `query:"blanks"`
+}
+
+// ClozeResponse is a cloze exercise without its answers. Fingerprint
+// identifies it when the answers are checked.
+type ClozeResponse struct {
+	*cloze.Exercise
+	DocumentID  int64  `json:"document_id"`
+	ChunkID     int64  `json:"chunk_id"`
+	PageIndex   *int32 `json:"page_index"`
+	Seed        uint64 `json:"seed"`
+	Target      string `json:"target"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// CheckRequest is the body of POST /exercises/cloze/check. It repeats the
+// parameters of the exercise as returned by GET /exercises/cloze, with
+// BlankCount the number of its blanks; Answers holds the answer to each
+// blank in order.
+type CheckRequest struct {
+	DocumentID  int64    `json:"document_id"`
+	ChunkID     int64    `json:"chunk_id"`
+	Seed        uint64   `json:"seed"`
+	Target      string   `json:"target"`
+	BlankCount  int      `json:"blank_count"`
+	Fingerprint string   `json:"fingerprint"`
+	Answers     []string `json:"answers"`
+}
+
+type BlankResult struct {
+	Number   int    `json:"number"`
+	Answer   string `json:"answer"`
+	Correct  bool   `json:"correct"`
+	Expected string `json:"expected"`
+}
+
+type CheckResponse struct {
+	Score   int           `json:"score"`
+	Total   int           `json:"total"`
+	Results []BlankResult `json:"results"`
+}
@@ -0,0 +1,149 @@
+// Package placement estimates a learner's CEFR level with an adaptive
+// placement test.
+package placement
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/pkg/vocab"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// Item skills.
+const (
+	Grammar    = "grammar"
+	Vocabulary = "vocabulary"
+	Reading    = "reading"
+)
+
+// Skills lists the item skills in the order tests cycle through them.
+var Skills = []string{Grammar, Vocabulary, Reading}
+
+const (
+	initialStep = 1
+	minStep     = 0.25
+)
+
+var ErrInvalidConfig = errors.New("invalid placement config")
+
+// State is the progress of one test.
+type State struct {
+	Ability float64
+	Step    float64
+	// LastCorrect is nil before the first answer.
+	LastCorrect *bool
+	Answered    int
+}
+
+// Engine decides what to ask next and when a test is over. It is a
+// staircase: ability is measured on the index of vocab.Levels (0 is A1, 5 is
+// C2), a correct answer moves it up by the current step, a wrong one moves
+// it down, and the step halves every time the direction reverses, so the
+// estimate settles around the hardest level the learner still answers
+// correctly.
+type Engine struct {
+	start    float64
+	minItems int
+	maxItems int
+}
+
+// New returns an engine configured from cfg.
+func New(cfg config.PlacementConfig) (*Engine, error) {
+	start := slices.Index(vocab.Levels, cfg.StartLevel)
+	if start < 0 {
+		return nil, fmt.Errorf("%w: start level %q", ErrInvalidConfig, cfg.StartLevel)
+	}
+	if cfg.MinItems <= 0 || cfg.MaxItems < cfg.MinItems {
+		return nil, fmt.Errorf("%w: need 0 < min_items <= max_items", ErrInvalidConfig)
+	}
+	return &Engine{start: float64(start), minItems: cfg.MinItems, maxItems: cfg.MaxItems}, nil
+}
+
+// Start returns the state of a new test.
+func (e *Engine) Start() State {
+	return State{Ability: e.start, Step: initialStep}
+}
+
+// Answer returns s after an answer.
+func (e *Engine) Answer(s State, correct bool) State {
+	if s.LastCorrect != nil && *s.LastCorrect != correct {
+		s.Step = max(s.Step/2, minStep)
+	}
+	if correct {
+		s.Ability += s.Step
+	} else {
+		s.Ability -= s.Step
+	}
+	s.Ability = min(max(s.Ability, 0), float64(len(vocab.Levels)-1))
+	s.LastCorrect = &correct
+	s.Answered++
+	return s
+}
+
+// Done reports whether the test has asked enough: either the maximum number
+// of items, or the minimum once the step has shrunk to its smallest size.
+func (e *Engine) Done(s State) bool {
+	return s.Answered >= e.maxItems || (s.Answered >= e.minItems && s.Step <= minStep)
+}
+
+// Level returns the CEFR level of ability: the hardest level the learner is
+// placed at or above. A learner who answers B1 items but not B2 ones ends up
+// between the two, and is placed at B1.
+func Level(ability float64) string {
+	return vocab.Levels[levelIndex(math.Floor(ability))]
+}
+
+func levelIndex(ability float64) int {
+	return min(max(int(ability), 0), len(vocab.Levels)-1)
+}
+
+// Candidate is an item that can still be asked.
+type Candidate struct {
+	ID    int64
+	Level string
+	Skill string
+}
+
+// Pick chooses the next item for s among candidates: one of the level
+// closest to the current ability, preferring the skill whose turn it is, at
+// random among equals. ok is false when there are no candidates left.
+func (e *Engine) Pick(s State, candidates []Candidate) (id int64, ok bool) {
+	// Items closest to the ability probe both sides of it.
+	target := levelIndex(math.Round(s.Ability))
+	skill := Skills[s.Answered%len(Skills)]
+
+	var best []int64
+	bestScore := math.MaxInt
+	for _, c := range candidates {
+		level := slices.Index(vocab.Levels, c.Level)
+		if level < 0 {
+			continue
+		}
+		// Distance in levels matters more than the skill.
+		score := 2 * abs(level-target)
+		if c.Skill != skill {
+			score++
+		}
+		switch {
+		case score < bestScore:
+			bestScore = score
+			best = append(best[:0], c.ID)
+		case score == bestScore:
+			best = append(best, c.ID)
+		}
+	}
+	if len(best) == 0 {
+		return 0, false
+	}
+	return best[rand.IntN(len(best))], true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
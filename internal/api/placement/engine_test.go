@@ -0,0 +1,191 @@
+package placement
+
+import (
+	"ai-learn-english/config"
+	"errors"
+	"testing"
+)
+
+func newEngine(t *testing.T, start string, minItems, maxItems int) *Engine {
+	t.Helper()
+	e, err := New(config.PlacementConfig{StartLevel: start, MinItems: minItems, MaxItems: maxItems})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.PlacementConfig
+		ok   bool
+	}{
+		{"ok", config.PlacementConfig{StartLevel: "B1", MinItems: 8, MaxItems: 20}, true},
+		{"min equals max", config.PlacementConfig{StartLevel: "A1", MinItems: 5, MaxItems: 5}, true},
+		{"unknown level", config.PlacementConfig{StartLevel: "D1", MinItems: 8, MaxItems: 20}, false},
+		{"lowercase level", config.PlacementConfig{StartLevel: "b1", MinItems: 8, MaxItems: 20}, false},
+		{"no minimum", config.PlacementConfig{StartLevel: "B1", MinItems: 0, MaxItems: 20}, false},
+		{"max below min", config.PlacementConfig{StartLevel: "B1", MinItems: 8, MaxItems: 7}, false},
+	}
+	for _, tt := range tests {
+		_, err := New(tt.cfg)
+		if tt.ok != (err == nil) || !tt.ok && !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("%s: err = %v", tt.name, err)
+		}
+	}
+	if s := newEngine(t, "B2", 1, 1).Start(); s.Ability != 3 || s.Step != initialStep || s.LastCorrect != nil || s.Answered != 0 {
+		t.Errorf("Start = %+v", s)
+	}
+}
+
+func TestAnswerStaircase(t *testing.T) {
+	e := newEngine(t, "B1", 1, 20)
+	steps := []struct {
+		correct bool
+		ability float64
+		step    float64
+	}{
+		{true, 3, 1},        // up a whole level
+		{false, 2.5, 0.5},   // reversal: the step halves first
+		{false, 2, 0.5},     // same direction: the step stays
+		{true, 2.25, 0.25},  // reversal
+		{true, 2.5, 0.25},   // same direction
+		{false, 2.25, 0.25}, // reversal, but the step is already at its minimum
+	}
+	s := e.Start()
+	for i, st := range steps {
+		s = e.Answer(s, st.correct)
+		if s.Ability != st.ability || s.Step != st.step {
+			t.Fatalf("answer %d (%v): ability %v step %v, want %v and %v", i+1, st.correct, s.Ability, s.Step, st.ability, st.step)
+		}
+		if s.Answered != i+1 || s.LastCorrect == nil || *s.LastCorrect != st.correct {
+			t.Fatalf("answer %d: state %+v", i+1, s)
+		}
+	}
+}
+
+func TestAnswerClampsAbility(t *testing.T) {
+	top := newEngine(t, "C2", 1, 20)
+	s := top.Answer(top.Start(), true)
+	if s.Ability != 5 {
+		t.Errorf("correct at C2: ability %v, want 5", s.Ability)
+	}
+	bottom := newEngine(t, "A1", 1, 20)
+	s = bottom.Answer(bottom.Start(), false)
+	s = bottom.Answer(s, false)
+	if s.Ability != 0 {
+		t.Errorf("wrong at A1: ability %v, want 0", s.Ability)
+	}
+	// A clamped answer still counts as a direction for the staircase.
+	if s = bottom.Answer(s, true); s.Ability != 0.5 || s.Step != 0.5 {
+		t.Errorf("up from A1: ability %v step %v, want 0.5 and 0.5", s.Ability, s.Step)
+	}
+}
+
+func TestDone(t *testing.T) {
+	e := newEngine(t, "B1", 3, 5)
+	tests := []struct {
+		answered int
+		step     float64
+		want     bool
+	}{
+		{0, initialStep, false},
+		{2, minStep, false}, // settled, but fewer than the minimum asked
+		{3, minStep, true},
+		{3, 0.5, false}, // enough asked, but the estimate is still moving
+		{4, initialStep, false},
+		{5, initialStep, true}, // the maximum ends the test regardless
+		{6, 0.5, true},
+	}
+	for _, tt := range tests {
+		if got := e.Done(State{Ability: 2, Step: tt.step, Answered: tt.answered}); got != tt.want {
+			t.Errorf("Done(%d answered, step %v) = %v, want %v", tt.answered, tt.step, got, tt.want)
+		}
+	}
+}
+
+func TestLevel(t *testing.T) {
+	tests := []struct {
+		ability float64
+		want    string
+	}{
+		{0, "A1"},
+		{0.75, "A1"},
+		{2, "B1"},
+		{2.99, "B1"},
+		{3, "B2"},
+		{4.5, "C1"},
+		{5, "C2"},
+		{-0.5, "A1"},
+		{5.5, "C2"},
+	}
+	for _, tt := range tests {
+		if got := Level(tt.ability); got != tt.want {
+			t.Errorf("Level(%v) = %s, want %s", tt.ability, got, tt.want)
+		}
+	}
+}
+
+func TestPick(t *testing.T) {
+	e := newEngine(t, "B1", 1, 20)
+	// After one answer it is vocabulary's turn.
+	state := State{Ability: 2.4, Step: 0.5, Answered: 1}
+	tests := []struct {
+		name       string
+		state      State
+		candidates []Candidate
+		want       []int64
+	}{
+		{"nearest level and skill", state, []Candidate{
+			{1, "B1", Grammar}, {2, "B2", Vocabulary}, {3, "B1", Vocabulary}, {4, "A1", Vocabulary},
+		}, []int64{3}},
+		{"nearest level over skill", state, []Candidate{
+			{1, "B1", Reading}, {2, "B2", Vocabulary}, {3, "A1", Vocabulary},
+		}, []int64{1}},
+		{"skill breaks a tie in distance", state, []Candidate{
+			{1, "B2", Grammar}, {2, "A2", Vocabulary},
+		}, []int64{2}},
+		{"rounds the ability", State{Ability: 2.5, Step: 0.5, Answered: 1}, []Candidate{
+			{1, "B1", Vocabulary}, {2, "B2", Vocabulary},
+		}, []int64{2}},
+		{"skill cycles", State{Ability: 2, Step: 0.5, Answered: 2}, []Candidate{
+			{1, "B1", Grammar}, {2, "B1", Vocabulary}, {3, "B1", Reading},
+		}, []int64{3}},
+		{"random among equals", state, []Candidate{
+			{1, "B1", Vocabulary}, {2, "B1", Grammar}, {3, "B1", Vocabulary},
+		}, []int64{1, 3}},
+		{"unknown level skipped", state, []Candidate{
+			{1, "X9", Vocabulary}, {2, "C2", Grammar},
+		}, []int64{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := make(map[int64]bool)
+			for range 200 {
+				id, ok := e.Pick(tt.state, tt.candidates)
+				if !ok {
+					t.Fatal("no item picked")
+				}
+				seen[id] = true
+			}
+			if len(seen) != len(tt.want) {
+				t.Errorf("picked %v, want %v", seen, tt.want)
+			}
+			for _, id := range tt.want {
+				if !seen[id] {
+					t.Errorf("picked %v, want %v", seen, tt.want)
+				}
+			}
+		})
+	}
+
+	for name, candidates := range map[string][]Candidate{
+		"none":          nil,
+		"unknown level": {{1, "X9", Grammar}},
+	} {
+		if _, ok := e.Pick(state, candidates); ok {
+			t.Errorf("%s: picked an item", name)
+		}
+	}
+}
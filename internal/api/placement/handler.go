@@ -0,0 +1,82 @@
+package placement
+
+import (
+	"ai-learn-english/internal/middleware"
+	"ai-learn-english/pkg/apperror"
+	"strconv"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+var ErrInvalidBody = apperror.New("invalid_body", "request body is not valid JSON")
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Start handles POST /placement/sessions.
+func (h *Handler) Start(c fiber.Ctx) error {
+	res, err := h.svc.Start(c.Context(), middleware.UserID(c))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(res)
+}
+
+// Get handles GET /placement/sessions/:id.
+func (h *Handler) Get(c fiber.Ctx) error {
+	id, err := sessionID(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.svc.Get(c.Context(), middleware.UserID(c), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// Answer handles POST /placement/sessions/:id/answers.
+func (h *Handler) Answer(c fiber.Ctx) error {
+	id, err := sessionID(c)
+	if err != nil {
+		return err
+	}
+	var req AnswerRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return ErrInvalidBody
+	}
+
+	res, err := h.svc.Answer(c.Context(), middleware.UserID(c), id, req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// Finish handles POST /placement/sessions/:id/finish.
+func (h *Handler) Finish(c fiber.Ctx) error {
+	id, err := sessionID(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.svc.Finish(c.Context(), middleware.UserID(c), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+func sessionID(c fiber.Ctx) (int64, error) {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidID
+	}
+	return id, nil
+}
@@ -0,0 +1,187 @@
+package placement
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Session statuses.
+const (
+	StatusInProgress = "in_progress"
+	StatusFinished   = "finished"
+)
+
+// Repository persists placement items, sessions and answers through the
+// generated query package.
+type Repository struct {
+	q *query.Query
+}
+
+func NewRepository(q *query.Query) *Repository {
+	return &Repository{q: q}
+}
+
+// InProgress returns the user's unfinished test, or nil when there is none.
+func (r *Repository) InProgress(ctx context.Context, userID int64) (*model.PlacementSession, error) {
+	s := r.q.PlacementSession
+	session, err := s.WithContext(ctx).
+		Where(s.UserID.Eq(userID), s.Status.Eq(StatusInProgress)).
+		Order(s.ID.Desc()).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return session, err
+}
+
+// Session returns the user's test with id, or nil when it does not exist.
+func (r *Repository) Session(ctx context.Context, userID, id int64) (*model.PlacementSession, error) {
+	s := r.q.PlacementSession
+	session, err := s.WithContext(ctx).Where(s.ID.Eq(id), s.UserID.Eq(userID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return session, err
+}
+
+func (r *Repository) Create(ctx context.Context, session *model.PlacementSession) error {
+	return r.q.PlacementSession.WithContext(ctx).Create(session)
+}
+
+// Item returns the item with id, or nil when it does not exist.
+func (r *Repository) Item(ctx context.Context, id int64) (*model.PlacementItem, error) {
+	i := r.q.PlacementItem
+	item, err := i.WithContext(ctx).Where(i.ID.Eq(id)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return item, err
+}
+
+// Candidates returns the active items the test with sessionID has not asked
+// yet.
+func (r *Repository) Candidates(ctx context.Context, sessionID int64) ([]Candidate, error) {
+	return candidates(ctx, r.q, sessionID)
+}
+
+func candidates(ctx context.Context, q *query.Query, sessionID int64) ([]Candidate, error) {
+	a := q.PlacementAnswer
+	var asked []int64
+	if err := a.WithContext(ctx).Where(a.SessionID.Eq(sessionID)).Pluck(a.ItemID, &asked); err != nil {
+		return nil, err
+	}
+
+	i := q.PlacementItem
+	do := i.WithContext(ctx).Select(i.ID, i.CefrLevel, i.Skill).Where(i.Active.Is(true))
+	if len(asked) > 0 {
+		do = do.Where(i.ID.NotIn(asked...))
+	}
+	items, err := do.Find()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Candidate, len(items))
+	for n, item := range items {
+		out[n] = Candidate{ID: item.ID, Level: item.CefrLevel, Skill: item.Skill}
+	}
+	return out, nil
+}
+
+// SetCurrentItem records the item the test asks next; nil means none is
+// left.
+func (r *Repository) SetCurrentItem(ctx context.Context, sessionID int64, itemID *int64) error {
+	s := r.q.PlacementSession
+	do := s.WithContext(ctx).Where(s.ID.Eq(sessionID))
+	var err error
+	if itemID == nil {
+		_, err = do.UpdateSimple(s.CurrentItemID.Null())
+	} else {
+		_, err = do.UpdateSimple(s.CurrentItemID.Value(*itemID))
+	}
+	return err
+}
+
+// Answer locks the user's test with id, lets answer update it and stores
+// the session together with the answer and the item next picks from the
+// items still unasked. answer returns nil to leave the test unchanged.
+// Answer returns a nil session when the test does not exist.
+func (r *Repository) Answer(ctx context.Context, userID, id int64, answer func(*model.PlacementSession) (*model.PlacementAnswer, error), next func(*model.PlacementSession, []Candidate) *int64) (*model.PlacementSession, error) {
+	var session *model.PlacementSession
+	err := r.q.Transaction(func(tx *query.Query) error {
+		s := tx.PlacementSession
+		var err error
+		session, err = s.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(s.ID.Eq(id), s.UserID.Eq(userID)).First()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			session = nil
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		ans, err := answer(session)
+		if err != nil || ans == nil {
+			return err
+		}
+		if err := tx.PlacementAnswer.WithContext(ctx).Create(ans); err != nil {
+			return err
+		}
+		// The next item is picked while the session is still locked, so a
+		// concurrent answer cannot see the item just answered as current.
+		left, err := candidates(ctx, tx, session.ID)
+		if err != nil {
+			return err
+		}
+		session.CurrentItemID = next(session, left)
+		current := s.CurrentItemID.Null()
+		if session.CurrentItemID != nil {
+			current = s.CurrentItemID.Value(*session.CurrentItemID)
+		}
+		_, err = s.WithContext(ctx).Where(s.ID.Eq(session.ID)).UpdateSimple(
+			s.Ability.Value(session.Ability),
+			s.Step.Value(session.Step),
+			s.LastCorrect.Value(*session.LastCorrect),
+			s.Answered.Value(session.Answered),
+			s.Correct.Value(session.Correct),
+			current,
+		)
+		return err
+	})
+	return session, err
+}
+
+// Finish marks the test finished at level and stores the level on the
+// user's learner profile.
+func (r *Repository) Finish(ctx context.Context, session *model.PlacementSession, level string, now time.Time) error {
+	return r.q.Transaction(func(tx *query.Query) error {
+		s := tx.PlacementSession
+		_, err := s.WithContext(ctx).Where(s.ID.Eq(session.ID)).UpdateSimple(
+			s.Status.Value(StatusFinished),
+			s.CefrLevel.Value(level),
+			s.CurrentItemID.Null(),
+			s.FinishedAt.Value(now),
+		)
+		if err != nil {
+			return err
+		}
+
+		p := tx.LearnerProfile
+		return p.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: p.UserID.ColumnName().String()}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				p.CefrLevel.ColumnName().String(),
+				p.PlacementSessionID.ColumnName().String(),
+			}),
+		}).Create(&model.LearnerProfile{
+			UserID:             session.UserID,
+			CefrLevel:          &level,
+			PlacementSessionID: &session.ID,
+		})
+	})
+}
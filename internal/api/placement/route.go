@@ -0,0 +1,17 @@
+package placement
+
+import (
+	"ai-learn-english/internal/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// RegisterRoutes registers placement test routes on the provided router.
+func RegisterRoutes(r fiber.Router, h *Handler) {
+	grp := r.Group("/placement/sessions", middleware.RequireUser())
+
+	grp.Post("/", h.Start)
+	grp.Get("/:id", h.Get)
+	grp.Post("/:id/answers", h.Answer)
+	grp.Post("/:id/finish", h.Finish)
+}
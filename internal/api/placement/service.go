@@ -0,0 +1,253 @@
+package placement
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/pkg/apperror"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrInvalidID        = apperror.New("invalid_id", "placement session id must be a positive integer")
+	ErrInvalidChoice    = apperror.New("invalid_choice", "choice must be the index of one of the item's options")
+	ErrNoAnswers        = apperror.New("no_answers", "answer at least one question before finishing the test")
+	ErrSessionNotFound  = apperror.New("session_not_found", "placement session not found").WithStatus(http.StatusNotFound)
+	ErrSessionFinished  = apperror.New("session_finished", "placement session is already finished").WithStatus(http.StatusConflict)
+	ErrItemNotCurrent   = apperror.New("item_not_current", "item is not the question the test is waiting for").WithStatus(http.StatusConflict)
+	ErrSessionCompleted = apperror.New("session_complete", "the test has no more questions; finish it to get the result").WithStatus(http.StatusConflict)
+)
+
+// Service runs placement tests.
+type Service struct {
+	repo   *Repository
+	engine *Engine
+	now    func() time.Time
+}
+
+func NewService(repo *Repository, engine *Engine) *Service {
+	return &Service{repo: repo, engine: engine, now: time.Now}
+}
+
+// Start begins a placement test, or resumes the user's unfinished one.
+func (s *Service) Start(ctx context.Context, userID int64) (*SessionResponse, error) {
+	session, err := s.repo.InProgress(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find unfinished test: %w", err)
+	}
+	if session != nil {
+		return s.view(ctx, session)
+	}
+
+	state := s.engine.Start()
+	session = &model.PlacementSession{
+		UserID:    userID,
+		Status:    StatusInProgress,
+		Ability:   state.Ability,
+		Step:      state.Step,
+		StartedAt: s.now(),
+	}
+	if err := s.repo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("create placement session: %w", err)
+	}
+	if err := s.advance(ctx, session); err != nil {
+		return nil, err
+	}
+	return s.view(ctx, session)
+}
+
+// Get returns the state of one of the user's tests.
+func (s *Service) Get(ctx context.Context, userID, id int64) (*SessionResponse, error) {
+	session, err := s.repo.Session(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("get placement session %d: %w", id, err)
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+	return s.view(ctx, session)
+}
+
+// Answer records the answer to the question the test is waiting for and
+// picks the next one.
+func (s *Service) Answer(ctx context.Context, userID, id int64, req AnswerRequest) (*AnswerResponse, error) {
+	var correct bool
+	session, err := s.repo.Answer(ctx, userID, id, func(session *model.PlacementSession) (*model.PlacementAnswer, error) {
+		if session.Status != StatusInProgress {
+			return nil, ErrSessionFinished
+		}
+		if session.CurrentItemID == nil {
+			return nil, ErrSessionCompleted
+		}
+		if *session.CurrentItemID != req.ItemID {
+			return nil, ErrItemNotCurrent
+		}
+		item, err := s.repo.Item(ctx, req.ItemID)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil {
+			return nil, ErrItemNotCurrent
+		}
+		options, err := decodeOptions(item)
+		if err != nil {
+			return nil, err
+		}
+		if req.Choice < 0 || req.Choice >= len(options) {
+			return nil, ErrInvalidChoice
+		}
+
+		correct = int32(req.Choice) == item.Answer
+		state := s.engine.Answer(stateOf(session), correct)
+		session.Ability = state.Ability
+		session.Step = state.Step
+		session.LastCorrect = state.LastCorrect
+		session.Answered = int32(state.Answered)
+		if correct {
+			session.Correct++
+		}
+		return &model.PlacementAnswer{
+			SessionID:  session.ID,
+			ItemID:     item.ID,
+			Choice:     int32(req.Choice),
+			Correct:    correct,
+			Ability:    session.Ability,
+			AnsweredAt: s.now(),
+		}, nil
+	}, s.next)
+	if err != nil {
+		return nil, fmt.Errorf("answer placement session %d: %w", id, err)
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	view, err := s.view(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	return &AnswerResponse{SessionResponse: *view, Correct: correct}, nil
+}
+
+// Finish ends a test and stores the estimated level on the user's profile.
+// A test can be finished before it is complete; the estimate is then less
+// reliable. Finishing a finished test returns its result again.
+func (s *Service) Finish(ctx context.Context, userID, id int64) (*FinishResponse, error) {
+	session, err := s.repo.Session(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("get placement session %d: %w", id, err)
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+	if session.Status == StatusFinished && session.CefrLevel != nil {
+		return &FinishResponse{Session: session, Level: *session.CefrLevel}, nil
+	}
+	if session.Answered == 0 {
+		return nil, ErrNoAnswers
+	}
+
+	level := Level(session.Ability)
+	now := s.now()
+	if err := s.repo.Finish(ctx, session, level, now); err != nil {
+		return nil, fmt.Errorf("finish placement session %d: %w", id, err)
+	}
+	session.Status = StatusFinished
+	session.CefrLevel = &level
+	session.CurrentItemID = nil
+	session.FinishedAt = &now
+	return &FinishResponse{Session: session, Level: level}, nil
+}
+
+// advance picks the next question of an unfinished test, or clears the
+// current one when the test has asked enough or has run out of items.
+func (s *Service) advance(ctx context.Context, session *model.PlacementSession) error {
+	var next *int64
+	if !s.engine.Done(stateOf(session)) {
+		candidates, err := s.repo.Candidates(ctx, session.ID)
+		if err != nil {
+			return fmt.Errorf("list placement items: %w", err)
+		}
+		next = s.next(session, candidates)
+	}
+	if err := s.repo.SetCurrentItem(ctx, session.ID, next); err != nil {
+		return fmt.Errorf("set next placement item: %w", err)
+	}
+	session.CurrentItemID = next
+	return nil
+}
+
+// next returns the item to ask after the answers recorded in session, or
+// nil when the test is done.
+func (s *Service) next(session *model.PlacementSession, candidates []Candidate) *int64 {
+	state := stateOf(session)
+	if s.engine.Done(state) {
+		return nil
+	}
+	if id, ok := s.engine.Pick(state, candidates); ok {
+		return &id
+	}
+	return nil
+}
+
+func (s *Service) view(ctx context.Context, session *model.PlacementSession) (*SessionResponse, error) {
+	if session.Status != StatusInProgress {
+		return &SessionResponse{Session: session, Complete: session.CurrentItemID == nil}, nil
+	}
+	item, err := s.current(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	// Deleting the item the test was waiting for clears current_item_id.
+	// Unless the test has asked enough, that does not end it: ask another.
+	if item == nil && !s.engine.Done(stateOf(session)) {
+		if err := s.advance(ctx, session); err != nil {
+			return nil, err
+		}
+		if item, err = s.current(ctx, session); err != nil {
+			return nil, err
+		}
+	}
+	res := &SessionResponse{Session: session, Complete: item == nil}
+	if item == nil {
+		return res, nil
+	}
+	options, err := decodeOptions(item)
+	if err != nil {
+		return nil, err
+	}
+	res.Item = &Item{ID: item.ID, Skill: item.Skill, Passage: item.Passage, Prompt: item.Prompt, Options: options}
+	return res, nil
+}
+
+// current returns the item session is waiting for, or nil if there is none
+// or it has been deleted.
+func (s *Service) current(ctx context.Context, session *model.PlacementSession) (*model.PlacementItem, error) {
+	if session.CurrentItemID == nil {
+		return nil, nil
+	}
+	item, err := s.repo.Item(ctx, *session.CurrentItemID)
+	if err != nil {
+		return nil, fmt.Errorf("get placement item %d: %w", *session.CurrentItemID, err)
+	}
+	return item, nil
+}
+
+func decodeOptions(item *model.PlacementItem) ([]string, error) {
+	var options []string
+	if err := json.Unmarshal([]byte(item.Options), &options); err != nil {
+		return nil, fmt.Errorf("decode options of placement item %d: %w", item.ID, err)
+	}
+	return options, nil
+}
+
+func stateOf(session *model.PlacementSession) State {
+	return State{
+		Ability:     session.Ability,
+		Step:        session.Step,
+		LastCorrect: session.LastCorrect,
+		Answered:    int(session.Answered),
+	}
+}
@@ -0,0 +1,40 @@
+package placement
+
+import "ai-learn-english/internal/database/model"
+
+// Item is a placement item as shown to the learner, without its answer or
+// level.
+type Item struct {
+	ID      int64    `json:"id"`
+	Skill   string   `json:"skill"`
+	Passage *string  `json:"passage,omitempty"`
+	Prompt  string   `json:"prompt"`
+	Options []string `json:"options"`
+}
+
+// SessionResponse is the state of a test. Item is the question to answer
+// next and is nil once Complete is true; the test is then ready to finish.
+type SessionResponse struct {
+	Session  *model.PlacementSession `json:"session"`
+	Item     *Item                   `json:"item"`
+	Complete bool                    `json:"complete"`
+}
+
+// AnswerRequest is the body of POST /placement/sessions/:id/answers. Choice
+// is the 0-based index into the item's options.
+type AnswerRequest struct {
+	ItemID int64 `json:"item_id"`
+	Choice int   `json:"choice"`
+}
+
+type AnswerResponse struct {
+	SessionResponse
+	Correct bool `json:"correct"`
+}
+
+// FinishResponse is returned by POST /placement/sessions/:id/finish. Level
+// is also stored on the learner's profile.
+type FinishResponse struct {
+	Session *model.PlacementSession `json:"session"`
+	Level   string                  `json:"level"`
+}
@@ -0,0 +1,41 @@
+package profile
+
+import (
+	"ai-learn-english/internal/middleware"
+	"ai-learn-english/pkg/apperror"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+var ErrInvalidBody = apperror.New("invalid_body", "request body is not valid JSON")
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Get handles GET /me/profile.
+func (h *Handler) Get(c fiber.Ctx) error {
+	res, err := h.svc.Get(c.Context(), middleware.UserID(c))
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// Update handles PUT /me/profile.
+func (h *Handler) Update(c fiber.Ctx) error {
+	var req UpdateRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return ErrInvalidBody
+	}
+
+	res, err := h.svc.Update(c.Context(), middleware.UserID(c), req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
@@ -0,0 +1,44 @@
+package profile
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/internal/learner"
+	"context"
+
+	"gorm.io/gorm/clause"
+)
+
+// Repository persists learner profiles through the generated query package.
+type Repository struct {
+	q *query.Query
+}
+
+func NewRepository(q *query.Query) *Repository {
+	return &Repository{q: q}
+}
+
+func (r *Repository) Get(ctx context.Context, userID int64) (*learner.Profile, error) {
+	return learner.Load(ctx, r.q, userID)
+}
+
+// Save stores the editable fields of m, creating the user's profile when
+// there is none yet. The stored CEFR level is only replaced when level is
+// true, so a level set by the placement test survives edits of the other
+// fields. The placement session is left as it is.
+func (r *Repository) Save(ctx context.Context, m *model.LearnerProfile, level bool) error {
+	p := r.q.LearnerProfile
+	columns := []string{
+		p.NativeLanguage.ColumnName().String(),
+		p.ExplanationLanguage.ColumnName().String(),
+		p.Goals.ColumnName().String(),
+		p.Interests.ColumnName().String(),
+	}
+	if level {
+		columns = append(columns, p.CefrLevel.ColumnName().String())
+	}
+	return p.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: p.UserID.ColumnName().String()}},
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).Create(m)
+}
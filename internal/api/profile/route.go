@@ -0,0 +1,15 @@
+package profile
+
+import (
+	"ai-learn-english/internal/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// RegisterRoutes registers learner profile routes on the provided router.
+func RegisterRoutes(r fiber.Router, h *Handler) {
+	grp := r.Group("/me", middleware.RequireUser())
+
+	grp.Get("/profile", h.Get)
+	grp.Put("/profile", h.Update)
+}
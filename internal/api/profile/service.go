@@ -0,0 +1,112 @@
+package profile
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/learner"
+	"ai-learn-english/pkg/apperror"
+	"ai-learn-english/pkg/vocab"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxInterests      = 10
+	maxInterestLength = 40
+)
+
+var (
+	ErrInvalidLevel    = apperror.New("invalid_level", "cefr_level must be one of "+strings.Join(vocab.Levels, ", "))
+	ErrInvalidLanguage = apperror.New("invalid_language", "languages must be one of "+strings.Join(slices.Sorted(maps.Keys(learner.Languages)), ", "))
+	ErrInvalidGoal     = apperror.New("invalid_goal", "goals must be among "+strings.Join(learner.Goals, ", "))
+	ErrInvalidInterest = apperror.New("invalid_interest", fmt.Sprintf("at most %d interests of at most %d characters each", maxInterests, maxInterestLength))
+)
+
+// Service manages learner profiles.
+type Service struct {
+	repo *Repository
+}
+
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Get returns the user's profile, empty when it was never filled in.
+func (s *Service) Get(ctx context.Context, userID int64) (*learner.Profile, error) {
+	p, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get learner profile: %w", err)
+	}
+	return p, nil
+}
+
+// Update replaces the user's profile with req. A cefr_level left out or
+// null keeps the stored level; a blank one clears it.
+func (s *Service) Update(ctx context.Context, userID int64, req UpdateRequest) (*learner.Profile, error) {
+	level := optional(req.CEFRLevel, strings.ToUpper)
+	if level != nil && !slices.Contains(vocab.Levels, *level) {
+		return nil, ErrInvalidLevel
+	}
+	native := optional(req.NativeLanguage, strings.ToLower)
+	explanation := optional(req.ExplanationLanguage, strings.ToLower)
+	for _, lang := range []*string{native, explanation} {
+		if lang == nil {
+			continue
+		}
+		if _, ok := learner.Languages[*lang]; !ok {
+			return nil, ErrInvalidLanguage
+		}
+	}
+
+	var goals []string
+	for _, g := range req.Goals {
+		g = strings.ToLower(strings.TrimSpace(g))
+		if !slices.Contains(learner.Goals, g) {
+			return nil, ErrInvalidGoal
+		}
+		if !slices.Contains(goals, g) {
+			goals = append(goals, g)
+		}
+	}
+	var interests []string
+	for _, i := range req.Interests {
+		i = strings.Join(strings.Fields(i), " ")
+		if i == "" || utf8.RuneCountInString(i) > maxInterestLength {
+			return nil, ErrInvalidInterest
+		}
+		if !slices.Contains(interests, i) {
+			interests = append(interests, i)
+		}
+	}
+	if len(interests) > maxInterests {
+		return nil, ErrInvalidInterest
+	}
+
+	m := &model.LearnerProfile{
+		UserID:              userID,
+		CefrLevel:           level,
+		NativeLanguage:      native,
+		ExplanationLanguage: explanation,
+		Goals:               learner.EncodeList(goals),
+		Interests:           learner.EncodeList(interests),
+	}
+	if err := s.repo.Save(ctx, m, req.CEFRLevel != nil); err != nil {
+		return nil, fmt.Errorf("save learner profile: %w", err)
+	}
+	return s.Get(ctx, userID)
+}
+
+// optional trims v and normalizes it with norm; blank values become nil.
+func optional(v *string, norm func(string) string) *string {
+	if v == nil {
+		return nil
+	}
+	s := norm(strings.TrimSpace(*v))
+	if s == "" {
+		return nil
+	}
+	return &s
+}
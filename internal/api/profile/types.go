@@ -0,0 +1,13 @@
+package profile
+
+// UpdateRequest is the body of PUT /me/profile. It replaces the whole
+// profile: fields left out are cleared, except CEFRLevel, which is kept when
+// left out or null and cleared when blank, so saving the other fields does
+// not erase the placement test's result. Languages are ISO 639-1 codes.
+type UpdateRequest struct {
+	CEFRLevel           *string  `json:"cefr_level"`
+	NativeLanguage      *string  `json:"native_language"`
+	ExplanationLanguage *string  `json:"explanation_language"`
+	Goals               []string `json:"goals"`
+	Interests           []string `json:"interests"`
+}
@@ -0,0 +1,209 @@
+package quiz
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/llm"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const generatePrompt = `You are an English teacher writing a reading comprehension quiz about numbered passages from a learner's document.
+Write exactly %d questions. Use only these question types: %s.
+- "multiple_choice": "options" holds exactly 4 different answers and "correct_option" is the 0-based index of the right one.
+- "true_false": "prompt" is a statement and "correct_bool" tells whether the passage supports it.
+- "short_answer": "reference_answer" is a model answer of one or two sentences and "rubric" says what a full-mark answer must contain.
+Answer fields that do not belong to the question's type are null.
+Each question must be answerable from a single passage: "passage" is its number and "evidence" is the sentence or sentences of that passage holding the answer, copied exactly. "explanation" says in one or two simple sentences why the answer is right.
+Test understanding of the content rather than memory of exact wording, and spread the questions over the passages.%s`
+
+// generatedSchema is the structured output asked of the model.
+var generatedSchema = &llm.Schema{
+	Name: "quiz",
+	Schema: map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"questions": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"type":             map[string]any{"type": "string", "enum": Types},
+						"passage":          map[string]any{"type": "integer"},
+						"prompt":           map[string]any{"type": "string"},
+						"options":          map[string]any{"type": []string{"array", "null"}, "items": map[string]any{"type": "string"}},
+						"correct_option":   map[string]any{"type": []string{"integer", "null"}},
+						"correct_bool":     map[string]any{"type": []string{"boolean", "null"}},
+						"reference_answer": map[string]any{"type": []string{"string", "null"}},
+						"rubric":           map[string]any{"type": []string{"string", "null"}},
+						"explanation":      map[string]any{"type": "string"},
+						"evidence":         map[string]any{"type": "string"},
+					},
+					"required": []string{
+						"type", "passage", "prompt", "options", "correct_option", "correct_bool",
+						"reference_answer", "rubric", "explanation", "evidence",
+					},
+					"additionalProperties": false,
+				},
+			},
+		},
+		"required":             []string{"questions"},
+		"additionalProperties": false,
+	},
+}
+
+// generated is the model's reply to generatePrompt.
+type generated struct {
+	Questions []struct {
+		Type            string   `json:"type"`
+		Passage         int      `json:"passage"`
+		Prompt          string   `json:"prompt"`
+		Options         []string `json:"options"`
+		CorrectOption   *int     `json:"correct_option"`
+		CorrectBool     *bool    `json:"correct_bool"`
+		ReferenceAnswer *string  `json:"reference_answer"`
+		Rubric          *string  `json:"rubric"`
+		Explanation     string   `json:"explanation"`
+		Evidence        string   `json:"evidence"`
+	} `json:"questions"`
+}
+
+// generateMessages asks for count questions of types about chunks, pitched
+// at level when it is known.
+func generateMessages(chunks []*model.Chunk, count int, types []string, level *string) []llm.Message {
+	pitch := ""
+	if level != nil {
+		pitch = fmt.Sprintf("\nThe learner's level is CEFR %s: word the questions and options so that they can understand them.", *level)
+	}
+
+	var b strings.Builder
+	for i, c := range chunks {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		fmt.Fprintf(&b, "[%d]", i+1)
+		if c.PageIndex != nil {
+			fmt.Fprintf(&b, " (page %d)", *c.PageIndex)
+		}
+		b.WriteString("\n")
+		b.WriteString(c.Content)
+	}
+	return []llm.Message{
+		{Role: llm.RoleSystem, Content: fmt.Sprintf(generatePrompt, count, strings.Join(types, ", "), pitch)},
+		{Role: llm.RoleUser, Content: b.String()},
+	}
+}
+
+// parseGenerated decodes and validates a reply asking for count questions
+// of types about chunks. Every question must cite a passage and quote it.
+func parseGenerated(reply string, chunks []*model.Chunk, count int, types []string) ([]*model.QuizQuestion, error) {
+	var g generated
+	if err := json.Unmarshal([]byte(llm.StripFence(reply)), &g); err != nil {
+		return nil, fmt.Errorf("not a JSON object of the requested shape (%v)", err)
+	}
+	if len(g.Questions) != count {
+		return nil, fmt.Errorf("there are %d questions instead of %d", len(g.Questions), count)
+	}
+
+	out := make([]*model.QuizQuestion, len(g.Questions))
+	for i, q := range g.Questions {
+		n := i + 1
+		prompt := strings.TrimSpace(q.Prompt)
+		explanation := strings.TrimSpace(q.Explanation)
+		evidence := strings.TrimSpace(q.Evidence)
+		switch {
+		case !slices.Contains(types, q.Type):
+			return nil, fmt.Errorf("question %d has type %q, which was not asked for", n, q.Type)
+		case q.Passage < 1 || q.Passage > len(chunks):
+			return nil, fmt.Errorf("question %d cites passage %d, but passages are numbered 1 to %d", n, q.Passage, len(chunks))
+		case prompt == "":
+			return nil, fmt.Errorf("question %d has an empty prompt", n)
+		case explanation == "":
+			return nil, fmt.Errorf("question %d has no explanation", n)
+		case evidence == "":
+			return nil, fmt.Errorf("question %d has no evidence", n)
+		}
+		chunk := chunks[q.Passage-1]
+		if !strings.Contains(fold(chunk.Content), fold(evidence)) {
+			return nil, fmt.Errorf("the evidence of question %d is not copied exactly from passage %d", n, q.Passage)
+		}
+
+		question := &model.QuizQuestion{
+			Position:    int32(n),
+			Type:        q.Type,
+			ChunkID:     &chunk.ID,
+			PageIndex:   chunk.PageIndex,
+			Prompt:      prompt,
+			Explanation: explanation,
+			Evidence:    evidence,
+		}
+		switch q.Type {
+		case TypeMultipleChoice:
+			options, err := parseOptions(q.Options)
+			if err != nil {
+				return nil, fmt.Errorf("question %d: %w", n, err)
+			}
+			if q.CorrectOption == nil || *q.CorrectOption < 0 || *q.CorrectOption >= len(options) {
+				return nil, fmt.Errorf("question %d needs \"correct_option\" between 0 and %d", n, len(options)-1)
+			}
+			raw, _ := json.Marshal(options)
+			encoded := string(raw)
+			correct := int32(*q.CorrectOption)
+			question.Options = &encoded
+			question.CorrectOption = &correct
+		case TypeTrueFalse:
+			if q.CorrectBool == nil {
+				return nil, fmt.Errorf("question %d needs \"correct_bool\"", n)
+			}
+			question.CorrectBool = q.CorrectBool
+		case TypeShortAnswer:
+			reference := trimmed(q.ReferenceAnswer)
+			rubric := trimmed(q.Rubric)
+			if reference == nil || rubric == nil {
+				return nil, fmt.Errorf("question %d needs \"reference_answer\" and \"rubric\"", n)
+			}
+			question.ReferenceAnswer = reference
+			question.Rubric = rubric
+		}
+		out[i] = question
+	}
+	return out, nil
+}
+
+func parseOptions(options []string) ([]string, error) {
+	if len(options) != 4 {
+		return nil, errors.New("\"options\" must hold exactly 4 answers")
+	}
+	out := make([]string, len(options))
+	for i, o := range options {
+		o = strings.TrimSpace(o)
+		if o == "" {
+			return nil, errors.New("\"options\" has an empty answer")
+		}
+		for _, prev := range out[:i] {
+			if strings.EqualFold(prev, o) {
+				return nil, fmt.Errorf("\"options\" repeats %q", o)
+			}
+		}
+		out[i] = o
+	}
+	return out, nil
+}
+
+func trimmed(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	t := strings.TrimSpace(*s)
+	if t == "" {
+		return nil
+	}
+	return &t
+}
+
+// fold makes quotes comparable despite differences in spacing and case.
+func fold(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
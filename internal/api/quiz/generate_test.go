@@ -0,0 +1,131 @@
+package quiz
+
+import (
+	"ai-learn-english/internal/database/model"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func testChunks() []*model.Chunk {
+	page := int32(3)
+	return []*model.Chunk{
+		{ID: 11, Content: "The museum opens at nine.\nIt is free for students on weekdays."},
+		{ID: 12, PageIndex: &page, Content: "Tickets bought online are cheaper. Children under six always enter for free."},
+	}
+}
+
+// question returns a valid generated question of typ about passage 2, with
+// fields overridden by set.
+func question(typ string, set map[string]any) map[string]any {
+	q := map[string]any{
+		"type":             typ,
+		"passage":          2,
+		"prompt":           "Who can always enter for free?",
+		"options":          nil,
+		"correct_option":   nil,
+		"correct_bool":     nil,
+		"reference_answer": nil,
+		"rubric":           nil,
+		"explanation":      "The passage says children under six enter for free.",
+		"evidence":         "Children under six always enter for free.",
+	}
+	switch typ {
+	case TypeMultipleChoice:
+		q["options"] = []string{"Students", "Children under six", "Everyone", "Nobody"}
+		q["correct_option"] = 1
+	case TypeTrueFalse:
+		q["prompt"] = "Children under six pay half price."
+		q["correct_bool"] = false
+	case TypeShortAnswer:
+		q["reference_answer"] = "Children under six."
+		q["rubric"] = "Mentions children under six."
+	}
+	for k, v := range set {
+		q[k] = v
+	}
+	return q
+}
+
+func reply(t *testing.T, questions ...map[string]any) string {
+	t.Helper()
+	raw, err := json.Marshal(map[string]any{"questions": questions})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(raw)
+}
+
+func TestParseGenerated(t *testing.T) {
+	chunks := testChunks()
+	questions, err := parseGenerated("```json\n"+reply(t,
+		question(TypeMultipleChoice, map[string]any{"options": []string{" Students ", "Children under six", "Everyone", "Nobody"}}),
+		question(TypeTrueFalse, map[string]any{"passage": 1, "evidence": "it is free  for students\non weekdays."}),
+		question(TypeShortAnswer, nil),
+	)+"\n```", chunks, 3, Types)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mc, tf, sa := questions[0], questions[1], questions[2]
+	if mc.Position != 1 || tf.Position != 2 || sa.Position != 3 {
+		t.Errorf("positions %d %d %d", mc.Position, tf.Position, sa.Position)
+	}
+	if mc.Options == nil || *mc.Options != `["Students","Children under six","Everyone","Nobody"]` || *mc.CorrectOption != 1 {
+		t.Errorf("multiple choice = %+v", mc)
+	}
+	if *mc.ChunkID != 12 || mc.PageIndex == nil || *mc.PageIndex != 3 {
+		t.Errorf("multiple choice cites chunk %v page %v", *mc.ChunkID, mc.PageIndex)
+	}
+	if *tf.ChunkID != 11 || tf.PageIndex != nil || tf.CorrectBool == nil || *tf.CorrectBool {
+		t.Errorf("true/false = %+v", tf)
+	}
+	if sa.ReferenceAnswer == nil || *sa.ReferenceAnswer != "Children under six." || sa.Rubric == nil || sa.Options != nil {
+		t.Errorf("short answer = %+v", sa)
+	}
+}
+
+func TestParseGeneratedRejects(t *testing.T) {
+	chunks := testChunks()
+	mc := func(set map[string]any) map[string]any { return question(TypeMultipleChoice, set) }
+	tests := []struct {
+		name  string
+		reply string
+		count int
+		types []string
+		want  string
+	}{
+		{"not JSON", "Here is your quiz!", 1, Types, "not a JSON object"},
+		{"too few questions", reply(t, mc(nil)), 2, Types, "1 questions instead of 2"},
+		{"too many questions", reply(t, mc(nil), mc(nil)), 1, Types, "2 questions instead of 1"},
+		{"type not asked for", reply(t, question(TypeShortAnswer, nil)), 1, []string{TypeMultipleChoice, TypeTrueFalse}, `type "short_answer"`},
+		{"unknown type", reply(t, mc(map[string]any{"type": "essay"})), 1, Types, `type "essay"`},
+		{"passage 0", reply(t, mc(map[string]any{"passage": 0})), 1, Types, "cites passage 0"},
+		{"passage past the end", reply(t, mc(map[string]any{"passage": 3})), 1, Types, "cites passage 3"},
+		{"empty prompt", reply(t, mc(map[string]any{"prompt": "  "})), 1, Types, "empty prompt"},
+		{"no explanation", reply(t, mc(map[string]any{"explanation": ""})), 1, Types, "no explanation"},
+		{"no evidence", reply(t, mc(map[string]any{"evidence": " "})), 1, Types, "no evidence"},
+		{"evidence paraphrased", reply(t, mc(map[string]any{"evidence": "Kids under six get in free."})), 1, Types, "not copied exactly from passage 2"},
+		{"evidence from another passage", reply(t, mc(map[string]any{"evidence": "The museum opens at nine."})), 1, Types, "not copied exactly from passage 2"},
+		{"three options", reply(t, mc(map[string]any{"options": []string{"a", "b", "c"}})), 1, Types, "exactly 4"},
+		{"five options", reply(t, mc(map[string]any{"options": []string{"a", "b", "c", "d", "e"}})), 1, Types, "exactly 4"},
+		{"no options", reply(t, mc(map[string]any{"options": nil})), 1, Types, "exactly 4"},
+		{"empty option", reply(t, mc(map[string]any{"options": []string{"a", " ", "c", "d"}})), 1, Types, "empty answer"},
+		{"duplicate options", reply(t, mc(map[string]any{"options": []string{"Yes", "No", " yes", "Maybe"}})), 1, Types, `repeats "yes"`},
+		{"no correct option", reply(t, mc(map[string]any{"correct_option": nil})), 1, Types, `"correct_option" between 0 and 3`},
+		{"correct option out of range", reply(t, mc(map[string]any{"correct_option": 4})), 1, Types, `"correct_option" between 0 and 3`},
+		{"negative correct option", reply(t, mc(map[string]any{"correct_option": -1})), 1, Types, `"correct_option" between 0 and 3`},
+		{"no correct bool", reply(t, question(TypeTrueFalse, map[string]any{"correct_bool": nil})), 1, Types, `"correct_bool"`},
+		{"no reference answer", reply(t, question(TypeShortAnswer, map[string]any{"reference_answer": " "})), 1, Types, `"reference_answer" and "rubric"`},
+		{"no rubric", reply(t, question(TypeShortAnswer, map[string]any{"rubric": nil})), 1, Types, `"reference_answer" and "rubric"`},
+		{"second question bad", reply(t, mc(nil), mc(map[string]any{"passage": 9})), 2, Types, "question 2 cites passage 9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseGenerated(tt.reply, chunks, tt.count, tt.types)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
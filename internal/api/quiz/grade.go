@@ -0,0 +1,153 @@
+package quiz
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/llm"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const gradePrompt = `You are an English teacher marking a learner's short answers to reading comprehension questions.
+For each numbered answer you get the question, the evidence from the passage, a model answer and a rubric. Give "score" 1 when the answer meets the rubric, 0.5 when it is partly right and 0 when it is wrong or off topic. Judge the content, not the learner's grammar or spelling.
+"feedback" is one or two encouraging sentences in simple English saying what was right and what was missing.
+Reply with one grade for every answer, with its number.`
+
+// gradesSchema is the structured output asked of the model.
+var gradesSchema = &llm.Schema{
+	Name: "grades",
+	Schema: map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"grades": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"number":   map[string]any{"type": "integer"},
+						"score":    map[string]any{"type": "number"},
+						"feedback": map[string]any{"type": "string"},
+					},
+					"required":             []string{"number", "score", "feedback"},
+					"additionalProperties": false,
+				},
+			},
+		},
+		"required":             []string{"grades"},
+		"additionalProperties": false,
+	},
+}
+
+// grades is the model's reply to gradePrompt.
+type grades struct {
+	Grades []struct {
+		Number   int     `json:"number"`
+		Score    float64 `json:"score"`
+		Feedback string  `json:"feedback"`
+	} `json:"grades"`
+}
+
+// Feedback of automatically graded answers.
+const (
+	feedbackCorrect     = "Correct."
+	feedbackNotAnswered = "Not answered."
+)
+
+// gradeObjective grades a multiple choice or true/false answer, or an
+// unanswered question of any type.
+func gradeObjective(q *model.QuizQuestion, a *model.QuizAnswer) {
+	switch {
+	case a.Response == nil:
+		a.Feedback = feedbackNotAnswered
+		return
+	case q.Type == TypeMultipleChoice:
+		a.Correct = *a.Response == correctAnswer(q)
+	case q.Type == TypeTrueFalse:
+		a.Correct = *a.Response == strconv.FormatBool(*q.CorrectBool)
+	}
+	if a.Correct {
+		a.Score = 1
+		a.Feedback = feedbackCorrect
+	} else {
+		a.Feedback = fmt.Sprintf("Not quite: the answer is %q.", correctAnswer(q))
+	}
+}
+
+// gradeMessages asks for grades of the short answers to questions.
+func gradeMessages(questions []*model.QuizQuestion, answers []*model.QuizAnswer) []llm.Message {
+	var b strings.Builder
+	for i, q := range questions {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		fmt.Fprintf(&b, "Answer %d\nQuestion: %s\nEvidence: %s\nModel answer: %s\nRubric: %s\nLearner's answer: %s",
+			i+1, q.Prompt, q.Evidence, *q.ReferenceAnswer, *q.Rubric, *answers[i].Response)
+	}
+	return []llm.Message{
+		{Role: llm.RoleSystem, Content: gradePrompt},
+		{Role: llm.RoleUser, Content: b.String()},
+	}
+}
+
+// parseGrades decodes a reply to gradeMessages and applies the grades to
+// answers. Every answer must be graded exactly once.
+func parseGrades(reply string, answers []*model.QuizAnswer) error {
+	var g grades
+	if err := json.Unmarshal([]byte(llm.StripFence(reply)), &g); err != nil {
+		return fmt.Errorf("not a JSON object of the requested shape (%v)", err)
+	}
+	graded := make([]bool, len(answers))
+	for _, grade := range g.Grades {
+		i := grade.Number - 1
+		feedback := strings.TrimSpace(grade.Feedback)
+		switch {
+		case i < 0 || i >= len(answers):
+			return fmt.Errorf("there is no answer %d", grade.Number)
+		case graded[i]:
+			return fmt.Errorf("answer %d is graded twice", grade.Number)
+		case grade.Score != 0 && grade.Score != 0.5 && grade.Score != 1:
+			return fmt.Errorf("answer %d has score %v; use 0, 0.5 or 1", grade.Number, grade.Score)
+		case feedback == "":
+			return fmt.Errorf("answer %d has no feedback", grade.Number)
+		}
+		graded[i] = true
+		answers[i].Score = grade.Score
+		answers[i].Correct = grade.Score == 1
+		answers[i].Feedback = feedback
+	}
+	for i, ok := range graded {
+		if !ok {
+			return fmt.Errorf("answer %d is not graded", i+1)
+		}
+	}
+	return nil
+}
+
+// correctAnswer returns the answer to q as shown to the learner.
+func correctAnswer(q *model.QuizQuestion) string {
+	switch q.Type {
+	case TypeMultipleChoice:
+		options := decodeOptions(q)
+		if q.CorrectOption != nil && int(*q.CorrectOption) < len(options) {
+			return options[*q.CorrectOption]
+		}
+	case TypeTrueFalse:
+		if q.CorrectBool != nil {
+			return strconv.FormatBool(*q.CorrectBool)
+		}
+	case TypeShortAnswer:
+		if q.ReferenceAnswer != nil {
+			return *q.ReferenceAnswer
+		}
+	}
+	return ""
+}
+
+func decodeOptions(q *model.QuizQuestion) []string {
+	var options []string
+	if q.Options != nil {
+		_ = json.Unmarshal([]byte(*q.Options), &options)
+	}
+	return options
+}
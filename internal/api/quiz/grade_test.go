@@ -0,0 +1,104 @@
+package quiz
+
+import (
+	"ai-learn-english/internal/database/model"
+	"strings"
+	"testing"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestGradeObjective(t *testing.T) {
+	options := `["Students","Children under six","Everyone","Nobody"]`
+	mc := &model.QuizQuestion{Type: TypeMultipleChoice, Options: &options, CorrectOption: ptr(int32(1))}
+	tf := &model.QuizQuestion{Type: TypeTrueFalse, CorrectBool: ptr(false)}
+	sa := &model.QuizQuestion{Type: TypeShortAnswer, ReferenceAnswer: ptr("Children under six.")}
+	tests := []struct {
+		name     string
+		question *model.QuizQuestion
+		response *string
+		correct  bool
+		feedback string
+	}{
+		{"multiple choice right", mc, ptr("Children under six"), true, feedbackCorrect},
+		{"multiple choice wrong", mc, ptr("Students"), false, `Not quite: the answer is "Children under six".`},
+		{"true/false right", tf, ptr("false"), true, feedbackCorrect},
+		{"true/false wrong", tf, ptr("true"), false, `Not quite: the answer is "false".`},
+		{"multiple choice unanswered", mc, nil, false, feedbackNotAnswered},
+		{"short answer unanswered", sa, nil, false, feedbackNotAnswered},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &model.QuizAnswer{Response: tt.response}
+			gradeObjective(tt.question, a)
+			wantScore := 0.0
+			if tt.correct {
+				wantScore = 1
+			}
+			if a.Correct != tt.correct || a.Score != wantScore || a.Feedback != tt.feedback {
+				t.Errorf("graded %+v, want correct %v feedback %q", a, tt.correct, tt.feedback)
+			}
+		})
+	}
+}
+
+func answers(n int) []*model.QuizAnswer {
+	out := make([]*model.QuizAnswer, n)
+	for i := range out {
+		out[i] = &model.QuizAnswer{Response: ptr("an answer")}
+	}
+	return out
+}
+
+func TestParseGrades(t *testing.T) {
+	a := answers(3)
+	err := parseGrades("```json\n"+`{"grades": [
+		{"number": 2, "score": 0.5, "feedback": " Partly right. "},
+		{"number": 1, "score": 1, "feedback": "Well done."},
+		{"number": 3, "score": 0, "feedback": "Read the passage again."}
+	]}`+"\n```", a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		score    float64
+		correct  bool
+		feedback string
+	}{
+		{1, true, "Well done."},
+		{0.5, false, "Partly right."},
+		{0, false, "Read the passage again."},
+	}
+	for i, w := range want {
+		if a[i].Score != w.score || a[i].Correct != w.correct || a[i].Feedback != w.feedback {
+			t.Errorf("answer %d = %+v, want %+v", i+1, a[i], w)
+		}
+	}
+}
+
+func TestParseGradesRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply string
+		want  string
+	}{
+		{"not JSON", "Great answers!", "not a JSON object"},
+		{"missing", `{"grades": [{"number": 1, "score": 1, "feedback": "Good."}]}`, "answer 2 is not graded"},
+		{"none", `{"grades": []}`, "answer 1 is not graded"},
+		{"duplicated", `{"grades": [{"number": 1, "score": 1, "feedback": "Good."}, {"number": 1, "score": 0, "feedback": "No."}, {"number": 2, "score": 1, "feedback": "Good."}]}`, "answer 1 is graded twice"},
+		{"number 0", `{"grades": [{"number": 0, "score": 1, "feedback": "Good."}]}`, "no answer 0"},
+		{"number past the end", `{"grades": [{"number": 3, "score": 1, "feedback": "Good."}]}`, "no answer 3"},
+		{"score 0.75", `{"grades": [{"number": 1, "score": 0.75, "feedback": "Good."}]}`, "score 0.75"},
+		{"score 2", `{"grades": [{"number": 1, "score": 2, "feedback": "Good."}]}`, "score 2"},
+		{"negative score", `{"grades": [{"number": 1, "score": -1, "feedback": "Good."}]}`, "score -1"},
+		{"no feedback", `{"grades": [{"number": 1, "score": 1, "feedback": "  "}]}`, "answer 1 has no feedback"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := parseGrades(tt.reply, answers(2))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package quiz
+
+import (
+	"ai-learn-english/internal/middleware"
+	"ai-learn-english/pkg/apperror"
+	"strconv"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+var ErrInvalidBody = apperror.New("invalid_body", "request body is not valid JSON")
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Generate handles POST /documents/:id/quizzes.
+func (h *Handler) Generate(c fiber.Ctx) error {
+	documentID, err := paramID(c, "id")
+	if err != nil {
+		return err
+	}
+	var req GenerateRequest
+	if len(c.Body()) > 0 {
+		if err := c.Bind().JSON(&req); err != nil {
+			return ErrInvalidBody
+		}
+	}
+
+	res, err := h.svc.Generate(c.Context(), middleware.UserID(c), documentID, req)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(res)
+}
+
+// List handles GET /documents/:id/quizzes.
+func (h *Handler) List(c fiber.Ctx) error {
+	documentID, err := paramID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	res, err := h.svc.List(c.Context(), middleware.UserID(c), documentID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// Get handles GET /quizzes/:id.
+func (h *Handler) Get(c fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	res, err := h.svc.Get(c.Context(), middleware.UserID(c), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// Submit handles POST /quizzes/:id/attempts.
+func (h *Handler) Submit(c fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return err
+	}
+	var req SubmitRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return ErrInvalidBody
+	}
+
+	res, err := h.svc.Submit(c.Context(), middleware.UserID(c), id, req)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(res)
+}
+
+// Attempt handles GET /quizzes/:id/attempts/:attempt_id.
+func (h *Handler) Attempt(c fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return err
+	}
+	attemptID, err := paramID(c, "attempt_id")
+	if err != nil {
+		return err
+	}
+
+	res, err := h.svc.Attempt(c.Context(), middleware.UserID(c), id, attemptID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+func paramID(c fiber.Ctx, name string) (int64, error) {
+	id, err := strconv.ParseInt(c.Params(name), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidID
+	}
+	return id, nil
+}
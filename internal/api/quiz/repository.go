@@ -0,0 +1,110 @@
+package quiz
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/internal/learner"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// Repository persists quizzes and attempts through the generated query
+// package.
+type Repository struct {
+	q *query.Query
+}
+
+func NewRepository(q *query.Query) *Repository {
+	return &Repository{q: q}
+}
+
+// DocumentOwned reports whether documentID exists and belongs to userID.
+func (r *Repository) DocumentOwned(ctx context.Context, userID, documentID int64) (bool, error) {
+	d := r.q.Document
+	n, err := d.WithContext(ctx).Where(d.ID.Eq(documentID), d.UserID.Eq(userID)).Count()
+	return n > 0, err
+}
+
+// ChunkLevels returns the ids and readability levels of the document's
+// chunks in reading order.
+func (r *Repository) ChunkLevels(ctx context.Context, documentID int64) ([]*model.Chunk, error) {
+	c := r.q.Chunk
+	return c.WithContext(ctx).Select(c.ID, c.CefrLevel).Where(c.DocumentID.Eq(documentID)).Order(c.ChunkIndex).Find()
+}
+
+// Chunks returns the chunks of the document with ids in reading order.
+// Ids of other documents are ignored.
+func (r *Repository) Chunks(ctx context.Context, documentID int64, ids []int64) ([]*model.Chunk, error) {
+	c := r.q.Chunk
+	return c.WithContext(ctx).Where(c.DocumentID.Eq(documentID), c.ID.In(ids...)).Order(c.ChunkIndex).Find()
+}
+
+// CreateQuiz stores quiz with its questions.
+func (r *Repository) CreateQuiz(ctx context.Context, quiz *model.Quiz, questions []*model.QuizQuestion) error {
+	return r.q.Transaction(func(tx *query.Query) error {
+		if err := tx.Quiz.WithContext(ctx).Create(quiz); err != nil {
+			return err
+		}
+		for _, q := range questions {
+			q.QuizID = quiz.ID
+		}
+		return tx.QuizQuestion.WithContext(ctx).Create(questions...)
+	})
+}
+
+// Quiz returns the user's quiz with id, or nil when it does not exist.
+func (r *Repository) Quiz(ctx context.Context, userID, id int64) (*model.Quiz, error) {
+	z := r.q.Quiz
+	quiz, err := z.WithContext(ctx).Where(z.ID.Eq(id), z.UserID.Eq(userID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return quiz, err
+}
+
+// List returns the user's quizzes about a document, newest first.
+func (r *Repository) List(ctx context.Context, userID, documentID int64) ([]*model.Quiz, error) {
+	z := r.q.Quiz
+	return z.WithContext(ctx).Where(z.UserID.Eq(userID), z.DocumentID.Eq(documentID)).Order(z.ID.Desc()).Find()
+}
+
+func (r *Repository) Questions(ctx context.Context, quizID int64) ([]*model.QuizQuestion, error) {
+	qq := r.q.QuizQuestion
+	return qq.WithContext(ctx).Where(qq.QuizID.Eq(quizID)).Order(qq.Position).Find()
+}
+
+// CreateAttempt stores attempt with its graded answers.
+func (r *Repository) CreateAttempt(ctx context.Context, attempt *model.QuizAttempt, answers []*model.QuizAnswer) error {
+	return r.q.Transaction(func(tx *query.Query) error {
+		if err := tx.QuizAttempt.WithContext(ctx).Create(attempt); err != nil {
+			return err
+		}
+		for _, a := range answers {
+			a.AttemptID = attempt.ID
+		}
+		return tx.QuizAnswer.WithContext(ctx).Create(answers...)
+	})
+}
+
+// Attempt returns the user's attempt with id at quizID, or nil when it
+// does not exist.
+func (r *Repository) Attempt(ctx context.Context, userID, quizID, id int64) (*model.QuizAttempt, error) {
+	a := r.q.QuizAttempt
+	attempt, err := a.WithContext(ctx).Where(a.ID.Eq(id), a.QuizID.Eq(quizID), a.UserID.Eq(userID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return attempt, err
+}
+
+func (r *Repository) Answers(ctx context.Context, attemptID int64) ([]*model.QuizAnswer, error) {
+	a := r.q.QuizAnswer
+	return a.WithContext(ctx).Where(a.AttemptID.Eq(attemptID)).Find()
+}
+
+// Profile returns the user's learner profile.
+func (r *Repository) Profile(ctx context.Context, userID int64) (*learner.Profile, error) {
+	return learner.Load(ctx, r.q, userID)
+}
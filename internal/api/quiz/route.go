@@ -0,0 +1,19 @@
+package quiz
+
+import (
+	"ai-learn-english/internal/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// RegisterRoutes registers quiz routes on the provided router.
+func RegisterRoutes(r fiber.Router, h *Handler) {
+	docs := r.Group("/documents", middleware.RequireUser())
+	docs.Post("/:id/quizzes", h.Generate)
+	docs.Get("/:id/quizzes", h.List)
+
+	grp := r.Group("/quizzes", middleware.RequireUser())
+	grp.Get("/:id", h.Get)
+	grp.Post("/:id/attempts", h.Submit)
+	grp.Get("/:id/attempts/:attempt_id", h.Attempt)
+}
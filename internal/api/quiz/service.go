@@ -0,0 +1,371 @@
+package quiz
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/learner"
+	"ai-learn-english/internal/llm"
+	"ai-learn-english/pkg/apperror"
+	"ai-learn-english/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	defaultCount = 5
+	maxCount     = 10
+	// maxPassages bounds the chunks a quiz is grounded in, and so the
+	// length of the prompt.
+	maxPassages = 6
+	// maxAnswerRunes bounds short answers sent for grading.
+	maxAnswerRunes = 1000
+)
+
+var (
+	ErrInvalidID        = apperror.New("invalid_id", "id must be a positive integer")
+	ErrInvalidCount     = apperror.New("invalid_count", fmt.Sprintf("count must be between 1 and %d", maxCount))
+	ErrInvalidType      = apperror.New("invalid_type", "types must be among "+strings.Join(Types, ", "))
+	ErrTooManyChunks    = apperror.New("too_many_chunks", fmt.Sprintf("a quiz can be grounded in at most %d chunks", maxPassages))
+	ErrUnknownQuestion  = apperror.New("unknown_question", "an answer refers to a question that is not in this quiz")
+	ErrDuplicateAnswer  = apperror.New("duplicate_answer", "a question is answered more than once")
+	ErrInvalidAnswer    = apperror.New("invalid_answer", "multiple choice answers need a valid choice, true/false answers a value and short answers a text")
+	ErrAnswerTooLong    = apperror.New("answer_too_long", fmt.Sprintf("short answers must be at most %d characters", maxAnswerRunes))
+	ErrDocumentNotFound = apperror.New("document_not_found", "document not found").WithStatus(http.StatusNotFound)
+	ErrChunkNotFound    = apperror.New("chunk_not_found", "chunk not found in this document").WithStatus(http.StatusNotFound)
+	ErrQuizNotFound     = apperror.New("quiz_not_found", "quiz not found").WithStatus(http.StatusNotFound)
+	ErrAttemptNotFound  = apperror.New("attempt_not_found", "quiz attempt not found").WithStatus(http.StatusNotFound)
+	ErrNoPassages       = apperror.New("document_not_chunked", "the document has no text to write questions about yet").WithStatus(http.StatusConflict)
+	ErrModelUnavailable = apperror.New("model_unavailable", "the teacher is unavailable, please try again").WithStatus(http.StatusBadGateway)
+	ErrGenerationFailed = apperror.New("generation_failed", "the teacher could not write this quiz, please try again").WithStatus(http.StatusBadGateway)
+	ErrGradingFailed    = apperror.New("grading_failed", "the teacher could not mark the short answers, please try again").WithStatus(http.StatusBadGateway)
+)
+
+// Service writes comprehension quizzes about documents and marks attempts.
+type Service struct {
+	repo  *Repository
+	model llm.ChatModel
+}
+
+func NewService(repo *Repository, model llm.ChatModel) *Service {
+	return &Service{repo: repo, model: model}
+}
+
+// Generate writes a quiz about one of the user's documents.
+func (s *Service) Generate(ctx context.Context, userID, documentID int64, req GenerateRequest) (*Quiz, error) {
+	count := req.Count
+	if count == 0 {
+		count = defaultCount
+	}
+	if count < 1 || count > maxCount {
+		return nil, ErrInvalidCount
+	}
+	types := Types
+	if len(req.Types) > 0 {
+		types = nil
+		for _, t := range req.Types {
+			if !slices.Contains(Types, t) {
+				return nil, ErrInvalidType
+			}
+			if !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+	}
+	if len(req.ChunkIDs) > maxPassages {
+		return nil, ErrTooManyChunks
+	}
+
+	owned, err := s.repo.DocumentOwned(ctx, userID, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("check document: %w", err)
+	}
+	if !owned {
+		return nil, ErrDocumentNotFound
+	}
+	profile, err := s.repo.Profile(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("load learner profile: %w", err)
+	}
+	chunks, err := s.passages(ctx, documentID, req.ChunkIDs, count, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	llmReq := llm.Request{
+		Messages:    generateMessages(chunks, count, types, profile.CEFRLevel),
+		Temperature: 0.4,
+		Schema:      generatedSchema,
+	}
+	var questions []*model.QuizQuestion
+	err = s.ask(ctx, "quiz generate", llmReq, ErrGenerationFailed, func(reply string) error {
+		var err error
+		questions, err = parseGenerated(reply, chunks, count, types)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	name := s.model.Name()
+	quiz := &model.Quiz{UserID: userID, DocumentID: documentID, QuestionCount: int32(len(questions)), Model: &name}
+	if err := s.repo.CreateQuiz(ctx, quiz, questions); err != nil {
+		return nil, fmt.Errorf("save quiz: %w", err)
+	}
+	return newQuiz(quiz, questions), nil
+}
+
+// passages returns the chunks a quiz of count questions is grounded in:
+// the chunks with ids, or chunks spread evenly over the document. When the
+// document has enough chunks in the learner's reading band, only those are
+// spread over.
+func (s *Service) passages(ctx context.Context, documentID int64, ids []int64, count int, profile *learner.Profile) ([]*model.Chunk, error) {
+	if len(ids) == 0 {
+		all, err := s.repo.ChunkLevels(ctx, documentID)
+		if err != nil {
+			return nil, fmt.Errorf("list chunks: %w", err)
+		}
+		n := min(count, maxPassages, len(all))
+		suited := slices.DeleteFunc(slices.Clone(all), func(c *model.Chunk) bool {
+			return profile.Distance(c.CefrLevel) > 0
+		})
+		if len(suited) >= n {
+			all = suited
+		}
+		for i := range n {
+			ids = append(ids, all[i*len(all)/n].ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, ErrNoPassages
+	}
+
+	chunks, err := s.repo.Chunks(ctx, documentID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("load chunks: %w", err)
+	}
+	for _, id := range ids {
+		if !slices.ContainsFunc(chunks, func(c *model.Chunk) bool { return c.ID == id }) {
+			return nil, ErrChunkNotFound
+		}
+	}
+	return chunks, nil
+}
+
+// ask sends req until parse accepts the reply. It returns failed when the
+// model never produced a valid reply.
+func (s *Service) ask(ctx context.Context, task string, req llm.Request, failed error, parse func(reply string) error) error {
+	err := llm.ChatValid(ctx, s.model, req, llm.ValidAttempts, parse)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, llm.ErrInvalidReply):
+		logger.Warn("%s with %s: %v", task, s.model.Name(), err)
+		return failed
+	default:
+		logger.Error(err, "%s with %s", task, s.model.Name())
+		return ErrModelUnavailable
+	}
+}
+
+// List returns the user's quizzes about a document.
+func (s *Service) List(ctx context.Context, userID, documentID int64) (*ListResponse, error) {
+	owned, err := s.repo.DocumentOwned(ctx, userID, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("check document: %w", err)
+	}
+	if !owned {
+		return nil, ErrDocumentNotFound
+	}
+	quizzes, err := s.repo.List(ctx, userID, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("list quizzes: %w", err)
+	}
+	return &ListResponse{Items: quizzes}, nil
+}
+
+// Get returns one of the user's quizzes without its answers.
+func (s *Service) Get(ctx context.Context, userID, id int64) (*Quiz, error) {
+	quiz, questions, err := s.load(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return newQuiz(quiz, questions), nil
+}
+
+// Submit marks an attempt at a quiz. Multiple choice and true/false
+// answers are marked right away; short answers are marked by the model
+// against each question's rubric.
+func (s *Service) Submit(ctx context.Context, userID, id int64, req SubmitRequest) (*AttemptResponse, error) {
+	quiz, questions, err := s.load(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	given := make(map[int64]Answer, len(req.Answers))
+	for _, a := range req.Answers {
+		if !slices.ContainsFunc(questions, func(q *model.QuizQuestion) bool { return q.ID == a.QuestionID }) {
+			return nil, ErrUnknownQuestion
+		}
+		if _, ok := given[a.QuestionID]; ok {
+			return nil, ErrDuplicateAnswer
+		}
+		given[a.QuestionID] = a
+	}
+
+	answers := make([]*model.QuizAnswer, len(questions))
+	var (
+		short        []*model.QuizQuestion
+		shortAnswers []*model.QuizAnswer
+	)
+	for i, q := range questions {
+		a := &model.QuizAnswer{QuestionID: q.ID}
+		if in, ok := given[q.ID]; ok {
+			if a.Response, err = response(q, in); err != nil {
+				return nil, err
+			}
+		}
+		answers[i] = a
+		if q.Type == TypeShortAnswer && a.Response != nil {
+			short = append(short, q)
+			shortAnswers = append(shortAnswers, a)
+			continue
+		}
+		gradeObjective(q, a)
+	}
+
+	if len(short) > 0 {
+		llmReq := llm.Request{Messages: gradeMessages(short, shortAnswers), Temperature: 0, Schema: gradesSchema}
+		err := s.ask(ctx, "quiz grade", llmReq, ErrGradingFailed, func(reply string) error {
+			return parseGrades(reply, shortAnswers)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	attempt := &model.QuizAttempt{QuizID: quiz.ID, UserID: userID, MaxScore: float64(len(questions)), SubmittedAt: time.Now()}
+	for _, a := range answers {
+		attempt.Score += a.Score
+	}
+	if err := s.repo.CreateAttempt(ctx, attempt, answers); err != nil {
+		return nil, fmt.Errorf("save quiz attempt: %w", err)
+	}
+	return newAttempt(attempt, questions, answers), nil
+}
+
+// Attempt returns a marked attempt at one of the user's quizzes.
+func (s *Service) Attempt(ctx context.Context, userID, quizID, id int64) (*AttemptResponse, error) {
+	_, questions, err := s.load(ctx, userID, quizID)
+	if err != nil {
+		return nil, err
+	}
+	attempt, err := s.repo.Attempt(ctx, userID, quizID, id)
+	if err != nil {
+		return nil, fmt.Errorf("get quiz attempt %d: %w", id, err)
+	}
+	if attempt == nil {
+		return nil, ErrAttemptNotFound
+	}
+	answers, err := s.repo.Answers(ctx, attempt.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get answers of quiz attempt %d: %w", id, err)
+	}
+	return newAttempt(attempt, questions, answers), nil
+}
+
+func (s *Service) load(ctx context.Context, userID, id int64) (*model.Quiz, []*model.QuizQuestion, error) {
+	quiz, err := s.repo.Quiz(ctx, userID, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get quiz %d: %w", id, err)
+	}
+	if quiz == nil {
+		return nil, nil, ErrQuizNotFound
+	}
+	questions, err := s.repo.Questions(ctx, quiz.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get questions of quiz %d: %w", id, err)
+	}
+	return quiz, questions, nil
+}
+
+// response validates the answer to q and returns it as text: the chosen
+// option, "true" or "false", or the short answer. A blank short answer
+// counts as unanswered.
+func response(q *model.QuizQuestion, a Answer) (*string, error) {
+	var r string
+	switch q.Type {
+	case TypeMultipleChoice:
+		options := decodeOptions(q)
+		if a.Choice == nil || *a.Choice < 0 || *a.Choice >= len(options) {
+			return nil, ErrInvalidAnswer
+		}
+		r = options[*a.Choice]
+	case TypeTrueFalse:
+		if a.Value == nil {
+			return nil, ErrInvalidAnswer
+		}
+		r = strconv.FormatBool(*a.Value)
+	case TypeShortAnswer:
+		if a.Text == nil {
+			return nil, ErrInvalidAnswer
+		}
+		r = strings.TrimSpace(*a.Text)
+		if r == "" {
+			return nil, nil
+		}
+		if utf8.RuneCountInString(r) > maxAnswerRunes {
+			return nil, ErrAnswerTooLong
+		}
+	}
+	return &r, nil
+}
+
+func newQuiz(quiz *model.Quiz, questions []*model.QuizQuestion) *Quiz {
+	out := &Quiz{Quiz: quiz, Questions: make([]Question, len(questions))}
+	for i, q := range questions {
+		out.Questions[i] = Question{
+			ID:        q.ID,
+			Position:  q.Position,
+			Type:      q.Type,
+			Prompt:    q.Prompt,
+			Options:   decodeOptions(q),
+			ChunkID:   q.ChunkID,
+			PageIndex: q.PageIndex,
+		}
+	}
+	return out
+}
+
+func newAttempt(attempt *model.QuizAttempt, questions []*model.QuizQuestion, answers []*model.QuizAnswer) *AttemptResponse {
+	byQuestion := make(map[int64]*model.QuizAnswer, len(answers))
+	for _, a := range answers {
+		byQuestion[a.QuestionID] = a
+	}
+	out := &AttemptResponse{Attempt: attempt, Results: make([]Result, 0, len(questions))}
+	for _, q := range questions {
+		a, ok := byQuestion[q.ID]
+		if !ok {
+			continue
+		}
+		out.Results = append(out.Results, Result{
+			QuestionID:    q.ID,
+			Position:      q.Position,
+			Type:          q.Type,
+			Prompt:        q.Prompt,
+			Response:      a.Response,
+			Score:         a.Score,
+			Correct:       a.Correct,
+			Feedback:      a.Feedback,
+			CorrectAnswer: correctAnswer(q),
+			Explanation:   q.Explanation,
+			Source:        Source{ChunkID: q.ChunkID, PageIndex: q.PageIndex, Quote: q.Evidence},
+		})
+	}
+	return out
+}
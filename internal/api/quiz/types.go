@@ -0,0 +1,89 @@
+package quiz
+
+import "ai-learn-english/internal/database/model"
+
+// Question types.
+const (
+	TypeMultipleChoice = "multiple_choice"
+	TypeTrueFalse      = "true_false"
+	TypeShortAnswer    = "short_answer"
+)
+
+// Types lists the question types a quiz can contain.
+var Types = []string{TypeMultipleChoice, TypeTrueFalse, TypeShortAnswer}
+
+// GenerateRequest is the body of POST /documents/:id/quizzes. Types limits
+// the question types, all of them by default. ChunkIDs grounds the quiz in
+// those chunks of the document; by default chunks are spread over the whole
+// document.
+type GenerateRequest struct {
+	Count    int      `json:"count"`
+	Types    []string `json:"types"`
+	ChunkIDs []int64  `json:"chunk_ids"`
+}
+
+// Question is a quiz question as shown before an attempt, without its
+// answer. ChunkID and PageIndex locate the passage it is about.
+type Question struct {
+	ID        int64    `json:"id"`
+	Position  int32    `json:"position"`
+	Type      string   `json:"type"`
+	Prompt    string   `json:"prompt"`
+	Options   []string `json:"options,omitempty"`
+	ChunkID   *int64   `json:"chunk_id"`
+	PageIndex *int32   `json:"page_index"`
+}
+
+type Quiz struct {
+	*model.Quiz
+	Questions []Question `json:"questions"`
+}
+
+type ListResponse struct {
+	Items []*model.Quiz `json:"items"`
+}
+
+// Answer is the learner's answer to one question: Choice is the 0-based
+// option of a multiple choice question, Value the answer to a true/false
+// one and Text the answer to a short answer one.
+type Answer struct {
+	QuestionID int64   `json:"question_id"`
+	Choice     *int    `json:"choice"`
+	Value      *bool   `json:"value"`
+	Text       *string `json:"text"`
+}
+
+// SubmitRequest is the body of POST /quizzes/:id/attempts. Questions left
+// out score zero.
+type SubmitRequest struct {
+	Answers []Answer `json:"answers"`
+}
+
+// Source is the passage a question is grounded in. Quote is the part of it
+// that holds the answer.
+type Source struct {
+	ChunkID   *int64 `json:"chunk_id"`
+	PageIndex *int32 `json:"page_index"`
+	Quote     string `json:"quote"`
+}
+
+// Result is the grading of one question. Score goes from 0 to 1; short
+// answers can earn 0.5 for a partly correct answer.
+type Result struct {
+	QuestionID    int64   `json:"question_id"`
+	Position      int32   `json:"position"`
+	Type          string  `json:"type"`
+	Prompt        string  `json:"prompt"`
+	Response      *string `json:"response"`
+	Score         float64 `json:"score"`
+	Correct       bool    `json:"correct"`
+	Feedback      string  `json:"feedback"`
+	CorrectAnswer string  `json:"correct_answer"`
+	Explanation   string  `json:"explanation"`
+	Source        Source  `json:"source"`
+}
+
+type AttemptResponse struct {
+	Attempt *model.QuizAttempt `json:"attempt"`
+	Results []Result           `json:"results"`
+}
@@ -0,0 +1,68 @@
+package review
+
+import (
+	"ai-learn-english/internal/middleware"
+	"ai-learn-english/pkg/apperror"
+	"strconv"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+var (
+	ErrInvalidBody  = apperror.New("invalid_body", "request body is not valid JSON")
+	ErrInvalidQuery = apperror.New("invalid_query", "query parameters are not valid")
+)
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Due handles GET /reviews/due?limit=.
+func (h *Handler) Due(c fiber.Ctx) error {
+	var req DueRequest
+	if err := c.Bind().Query(&req); err != nil {
+		return ErrInvalidQuery
+	}
+
+	res, err := h.svc.Due(c.Context(), middleware.UserID(c), req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// Grade handles POST /reviews/:card_id/grade.
+func (h *Handler) Grade(c fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("card_id"), 10, 64)
+	if err != nil || id <= 0 {
+		return ErrInvalidID
+	}
+	var req GradeRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return ErrInvalidBody
+	}
+
+	res, err := h.svc.Grade(c.Context(), middleware.UserID(c), id, req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// CreateCard handles POST /reviews/cards.
+func (h *Handler) CreateCard(c fiber.Ctx) error {
+	var req CreateCardRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return ErrInvalidBody
+	}
+
+	res, err := h.svc.CreateCard(c.Context(), middleware.UserID(c), req)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(res)
+}
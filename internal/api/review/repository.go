@@ -0,0 +1,111 @@
+package review
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository persists flashcards and reviews through the generated query
+// package.
+type Repository struct {
+	q *query.Query
+}
+
+func NewRepository(q *query.Query) *Repository {
+	return &Repository{q: q}
+}
+
+// Due returns up to limit of the user's cards due at now, most overdue
+// first, and how many are due in total. Cards of words marked known are
+// skipped.
+func (r *Repository) Due(ctx context.Context, userID int64, now time.Time, limit int) ([]*model.Flashcard, int64, error) {
+	f := r.q.Flashcard
+	w := r.q.WordBank
+	do := f.WithContext(ctx).LeftJoin(w, w.ID.EqCol(f.WordBankID)).
+		Where(f.UserID.Eq(userID), f.DueAt.Lte(now), field.Or(w.ID.IsNull(), w.Known.Is(false)))
+	total, err := do.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+	cards, err := do.Select(f.ALL).Order(f.DueAt, f.ID).Limit(limit).Find()
+	return cards, total, err
+}
+
+// Words returns the word bank entries with ids by id.
+func (r *Repository) Words(ctx context.Context, ids []int64) (map[int64]*model.WordBank, error) {
+	out := make(map[int64]*model.WordBank)
+	if len(ids) == 0 {
+		return out, nil
+	}
+	w := r.q.WordBank
+	entries, err := w.WithContext(ctx).Where(w.ID.In(ids...)).Find()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		out[e.ID] = e
+	}
+	return out, nil
+}
+
+// Review locks the user's card with id, lets grade compute its new state
+// and stores the state together with the review. It returns nil when the
+// card does not exist.
+func (r *Repository) Review(ctx context.Context, userID, id int64, grade func(*model.Flashcard) *model.Review) (*model.Flashcard, *model.Review, error) {
+	var (
+		card   *model.Flashcard
+		review *model.Review
+	)
+	err := r.q.Transaction(func(tx *query.Query) error {
+		f := tx.Flashcard
+		var err error
+		card, err = f.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(f.ID.Eq(id), f.UserID.Eq(userID)).First()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			card = nil
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		review = grade(card)
+		_, err = f.WithContext(ctx).Where(f.ID.Eq(card.ID)).UpdateSimple(
+			f.Algorithm.Value(*card.Algorithm),
+			f.Reps.Value(card.Reps),
+			f.Lapses.Value(card.Lapses),
+			f.Ease.Value(card.Ease),
+			f.IntervalDays.Value(card.IntervalDays),
+			f.Stability.Value(card.Stability),
+			f.Difficulty.Value(card.Difficulty),
+			f.DueAt.Value(card.DueAt),
+			f.LastReviewedAt.Value(*card.LastReviewedAt),
+		)
+		if err != nil {
+			return err
+		}
+		return tx.Review.WithContext(ctx).Create(review)
+	})
+	return card, review, err
+}
+
+// ChunkOwned reports whether chunkID belongs to one of the user's
+// documents.
+func (r *Repository) ChunkOwned(ctx context.Context, userID, chunkID int64) (bool, error) {
+	c := r.q.Chunk
+	d := r.q.Document
+	n, err := c.WithContext(ctx).Join(d, d.ID.EqCol(c.DocumentID)).
+		Where(c.ID.Eq(chunkID), d.UserID.Eq(userID)).Count()
+	return n > 0, err
+}
+
+func (r *Repository) Create(ctx context.Context, card *model.Flashcard) error {
+	return r.q.Flashcard.WithContext(ctx).Create(card)
+}
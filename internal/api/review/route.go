@@ -0,0 +1,16 @@
+package review
+
+import (
+	"ai-learn-english/internal/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// RegisterRoutes registers flashcard review routes on the provided router.
+func RegisterRoutes(r fiber.Router, h *Handler) {
+	grp := r.Group("/reviews", middleware.RequireUser())
+
+	grp.Get("/due", h.Due)
+	grp.Post("/cards", h.CreateCard)
+	grp.Post("/:card_id/grade", h.Grade)
+}
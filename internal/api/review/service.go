@@ -0,0 +1,172 @@
+package review
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/srs"
+	"ai-learn-english/pkg/apperror"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	defaultDueLimit = 20
+	maxDueLimit     = 100
+)
+
+var (
+	ErrInvalidID     = apperror.New("invalid_id", "card id must be a positive integer")
+	ErrInvalidGrade  = apperror.New("invalid_grade", "grade must be 1 (again), 2 (hard), 3 (good) or 4 (easy)")
+	ErrEmptyCard     = apperror.New("empty_card", "front and back must not be empty")
+	ErrCardNotFound  = apperror.New("card_not_found", "card not found").WithStatus(http.StatusNotFound)
+	ErrChunkNotFound = apperror.New("chunk_not_found", "chunk not found").WithStatus(http.StatusNotFound)
+)
+
+// Service schedules flashcard reviews.
+type Service struct {
+	repo       *Repository
+	algorithms map[string]srs.Algorithm
+	// algorithm schedules cards that have never been reviewed.
+	algorithm string
+	now       srs.Clock
+}
+
+// NewService returns a service scheduling new cards with cfg.Algorithm.
+// Cards keep the algorithm of their first review, so every algorithm stays
+// available when the setting changes.
+func NewService(repo *Repository, cfg config.SRSConfig, clock srs.Clock) (*Service, error) {
+	s := &Service{repo: repo, algorithms: make(map[string]srs.Algorithm), algorithm: cfg.Algorithm, now: clock}
+	for _, name := range []string{srs.SM2, srs.FSRS} {
+		a, err := srs.New(name, cfg)
+		if err != nil {
+			return nil, err
+		}
+		s.algorithms[name] = a
+	}
+	if _, ok := s.algorithms[cfg.Algorithm]; !ok {
+		return nil, fmt.Errorf("%w %q", srs.ErrUnknownAlgorithm, cfg.Algorithm)
+	}
+	return s, nil
+}
+
+// Due returns the user's cards that are due for review.
+func (s *Service) Due(ctx context.Context, userID int64, req DueRequest) (*DueResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultDueLimit
+	}
+	limit = min(limit, maxDueLimit)
+
+	cards, total, err := s.repo.Due(ctx, userID, s.now(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("list due cards: %w", err)
+	}
+	var wordIDs []int64
+	for _, c := range cards {
+		if c.WordBankID != nil {
+			wordIDs = append(wordIDs, *c.WordBankID)
+		}
+	}
+	words, err := s.repo.Words(ctx, wordIDs)
+	if err != nil {
+		return nil, fmt.Errorf("load words of due cards: %w", err)
+	}
+
+	res := &DueResponse{Total: total, Cards: make([]Card, len(cards))}
+	for i, c := range cards {
+		res.Cards[i] = Card{Flashcard: c}
+		if c.WordBankID != nil {
+			res.Cards[i].Word = words[*c.WordBankID]
+		}
+	}
+	return res, nil
+}
+
+// Grade records a review of one of the user's cards and schedules its next
+// one. Cards can be reviewed before they are due.
+func (s *Service) Grade(ctx context.Context, userID, cardID int64, req GradeRequest) (*GradeResponse, error) {
+	grade := srs.Grade(req.Grade)
+	if !grade.Valid() {
+		return nil, ErrInvalidGrade
+	}
+	now := s.now()
+
+	card, review, err := s.repo.Review(ctx, userID, cardID, func(card *model.Flashcard) *model.Review {
+		name := s.algorithm
+		if card.Algorithm != nil {
+			if _, ok := s.algorithms[*card.Algorithm]; ok {
+				name = *card.Algorithm
+			}
+		}
+		state := s.algorithms[name].Next(stateOf(card), grade, now)
+		elapsed := 0.0
+		if card.LastReviewedAt != nil {
+			elapsed = max(0, now.Sub(*card.LastReviewedAt).Hours()/24)
+		}
+
+		card.Algorithm = &name
+		card.Reps = int32(state.Reps)
+		card.Lapses = int32(state.Lapses)
+		card.Ease = state.Ease
+		card.IntervalDays = int32(state.IntervalDays)
+		card.Stability = state.Stability
+		card.Difficulty = state.Difficulty
+		card.DueAt = state.Due
+		card.LastReviewedAt = state.LastReview
+		return &model.Review{
+			FlashcardID:  card.ID,
+			UserID:       userID,
+			Grade:        int32(grade),
+			Algorithm:    name,
+			ElapsedDays:  elapsed,
+			IntervalDays: card.IntervalDays,
+			ReviewedAt:   now,
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("grade card %d: %w", cardID, err)
+	}
+	if card == nil {
+		return nil, ErrCardNotFound
+	}
+	return &GradeResponse{Card: card, Review: review}, nil
+}
+
+// CreateCard adds a hand-made card, due right away.
+func (s *Service) CreateCard(ctx context.Context, userID int64, req CreateCardRequest) (*model.Flashcard, error) {
+	front := strings.TrimSpace(req.Front)
+	back := strings.TrimSpace(req.Back)
+	if front == "" || back == "" {
+		return nil, ErrEmptyCard
+	}
+	if req.ChunkID != nil {
+		owned, err := s.repo.ChunkOwned(ctx, userID, *req.ChunkID)
+		if err != nil {
+			return nil, fmt.Errorf("check chunk: %w", err)
+		}
+		if !owned {
+			return nil, ErrChunkNotFound
+		}
+	}
+
+	card := &model.Flashcard{UserID: userID, ChunkID: req.ChunkID, Front: &front, Back: &back, DueAt: s.now()}
+	if err := s.repo.Create(ctx, card); err != nil {
+		return nil, fmt.Errorf("create card: %w", err)
+	}
+	return card, nil
+}
+
+func stateOf(card *model.Flashcard) srs.State {
+	return srs.State{
+		Reps:         int(card.Reps),
+		Lapses:       int(card.Lapses),
+		Ease:         card.Ease,
+		IntervalDays: int(card.IntervalDays),
+		Stability:    card.Stability,
+		Difficulty:   card.Difficulty,
+		Due:          card.DueAt,
+		LastReview:   card.LastReviewedAt,
+	}
+}
@@ -0,0 +1,42 @@
+package review
+
+import "ai-learn-english/internal/database/model"
+
+// DueRequest holds the query string of GET /reviews/due.
+type DueRequest struct {
+	Limit int `query:"limit"`
+}
+
+// Card is a flashcard to review. Word is the word bank entry the card was
+// made from, null for cards created by hand.
+type Card struct {
+	*model.Flashcard
+	Word *model.WordBank `json:"word"`
+}
+
+// DueResponse lists the cards due now, most overdue first. Total counts
+// every due card, including those beyond the limit.
+type DueResponse struct {
+	Total int64  `json:"total"`
+	Cards []Card `json:"cards"`
+}
+
+// GradeRequest is the body of POST /reviews/:card_id/grade: 1 again,
+// 2 hard, 3 good, 4 easy.
+type GradeRequest struct {
+	Grade int `json:"grade"`
+}
+
+// GradeResponse is the card rescheduled by a review.
+type GradeResponse struct {
+	Card   *model.Flashcard `json:"card"`
+	Review *model.Review    `json:"review"`
+}
+
+// CreateCardRequest is the body of POST /reviews/cards. ChunkID links the
+// card to the passage it was made from.
+type CreateCardRequest struct {
+	Front   string `json:"front"`
+	Back    string `json:"back"`
+	ChunkID *int64 `json:"chunk_id"`
+}
@@ -0,0 +1,32 @@
+package search
+
+import (
+	"ai-learn-english/internal/middleware"
+	"ai-learn-english/pkg/apperror"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+var ErrInvalidQuery = apperror.New("invalid_query", "query parameters are not valid")
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Search handles GET /search?q=&document_id=&limit=.
+func (h *Handler) Search(c fiber.Ctx) error {
+	var req SearchRequest
+	if err := c.Bind().Query(&req); err != nil {
+		return ErrInvalidQuery
+	}
+
+	res, err := h.svc.Search(c.Context(), middleware.UserID(c), req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
@@ -0,0 +1,22 @@
+package search
+
+import (
+	"ai-learn-english/internal/database/query"
+	"context"
+)
+
+// Repository reads documents through the generated query package.
+type Repository struct {
+	q *query.Query
+}
+
+func NewRepository(q *query.Query) *Repository {
+	return &Repository{q: q}
+}
+
+// DocumentOwned reports whether documentID exists and belongs to userID.
+func (r *Repository) DocumentOwned(ctx context.Context, userID, documentID int64) (bool, error) {
+	d := r.q.Document
+	n, err := d.WithContext(ctx).Where(d.ID.Eq(documentID), d.UserID.Eq(userID)).Count()
+	return n > 0, err
+}
@@ -0,0 +1,12 @@
+package search
+
+import (
+	"ai-learn-english/internal/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// RegisterRoutes registers search routes on the provided router.
+func RegisterRoutes(r fiber.Router, h *Handler) {
+	r.Get("/search", middleware.RequireUser(), h.Search)
+}
@@ -0,0 +1,74 @@
+package search
+
+import (
+	"ai-learn-english/internal/retrieval"
+	"ai-learn-english/pkg/apperror"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	defaultLimit = 10
+	maxLimit     = 50
+)
+
+var (
+	ErrEmptyQuery       = apperror.New("empty_query", "query parameter \"q\" must not be empty")
+	ErrDocumentNotFound = apperror.New("document_not_found", "document not found").WithStatus(http.StatusNotFound)
+)
+
+// Service searches the chunks of a user's documents with the hybrid
+// retriever.
+type Service struct {
+	repo      *Repository
+	retriever *retrieval.Retriever
+}
+
+func NewService(repo *Repository, retriever *retrieval.Retriever) *Service {
+	return &Service{repo: repo, retriever: retriever}
+}
+
+// Search returns the user's chunks that best match req.Q.
+func (s *Service) Search(ctx context.Context, userID int64, req SearchRequest) (*SearchResponse, error) {
+	q := strings.TrimSpace(req.Q)
+	if q == "" {
+		return nil, ErrEmptyQuery
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	limit = min(limit, maxLimit)
+
+	var docIDs []int64
+	if req.DocumentID != nil {
+		owned, err := s.repo.DocumentOwned(ctx, userID, *req.DocumentID)
+		if err != nil {
+			return nil, fmt.Errorf("check document: %w", err)
+		}
+		if !owned {
+			return nil, ErrDocumentNotFound
+		}
+		docIDs = append(docIDs, *req.DocumentID)
+	}
+
+	passages, err := s.retriever.Retrieve(ctx, userID, q, limit, docIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+	res := &SearchResponse{Query: q, Results: make([]SearchResult, len(passages))}
+	for i, p := range passages {
+		res.Results[i] = SearchResult{
+			ChunkID:     p.Chunk.ID,
+			DocumentID:  p.Chunk.DocumentID,
+			PageIndex:   p.Chunk.PageIndex,
+			Preview:     p.Chunk.ContentPreview,
+			Score:       p.Score,
+			VectorRank:  p.VectorRank,
+			KeywordRank: p.KeywordRank,
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,26 @@
+package search
+
+// SearchRequest holds the query string of GET /search. DocumentID restricts
+// the search to one of the user's documents.
+type SearchRequest struct {
+	Q          string `query:"q"`
+	DocumentID *int64 `query:"document_id"`
+	Limit      int    `query:"limit"`
+}
+
+// SearchResult is one matching chunk. VectorRank and KeywordRank are the
+// positions in the vector and BM25 result lists, 0 when absent from one.
+type SearchResult struct {
+	ChunkID     int64   `json:"chunk_id"`
+	DocumentID  int64   `json:"document_id"`
+	PageIndex   *int32  `json:"page_index"`
+	Preview     *string `json:"preview"`
+	Score       float32 `json:"score"`
+	VectorRank  int     `json:"vector_rank"`
+	KeywordRank int     `json:"keyword_rank"`
+}
+
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+}
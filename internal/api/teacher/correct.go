@@ -0,0 +1,211 @@
+package teacher
+
+import (
+	"ai-learn-english/internal/llm"
+	"ai-learn-english/pkg/apperror"
+	"ai-learn-english/pkg/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const maxCorrectRunes = 2000
+
+var (
+	ErrEmptyText        = apperror.New("empty_text", "text must not be empty")
+	ErrTextTooLong      = apperror.New("text_too_long", fmt.Sprintf("text must be at most %d characters", maxCorrectRunes))
+	ErrCorrectionFailed = apperror.New("correction_failed", "the teacher could not check this text, please try again").WithStatus(http.StatusBadGateway)
+)
+
+// Error categories an edit can have.
+var categories = []string{
+	"tense", "article", "preposition", "word_order", "agreement", "verb_form",
+	"plural", "word_choice", "spelling", "punctuation", "capitalization", "other",
+}
+
+const correctPrompt = `You are an English teacher checking a learner's writing.
+Find the grammar, spelling, punctuation and word choice mistakes in the text and correct them. Change as little as possible: keep the learner's meaning, style and any text that is already correct.
+Reply with a JSON object:
+- "corrected": the whole text with every edit applied and nothing else changed.
+- "edits": the edits in the order they appear in the text. "original" is the exact text being replaced, copied character for character; it must not be empty, so for a missing word include the neighbouring word (e.g. "have cat" -> "have a cat"). "category" is one of: %s. "explanation" is one short sentence in simple English.
+%s
+If the text is correct, "corrected" is the text unchanged and "edits" is empty.`
+
+// correctionSchema is the structured output asked of the model.
+var correctionSchema = &llm.Schema{
+	Name: "correction",
+	Schema: map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"corrected": map[string]any{"type": "string"},
+			"edits": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"original":       map[string]any{"type": "string"},
+						"replacement":    map[string]any{"type": "string"},
+						"category":       map[string]any{"type": "string", "enum": categories},
+						"explanation":    map[string]any{"type": "string"},
+						"explanation_vi": map[string]any{"type": []string{"string", "null"}},
+					},
+					"required":             []string{"original", "replacement", "category", "explanation", "explanation_vi"},
+					"additionalProperties": false,
+				},
+			},
+		},
+		"required":             []string{"corrected", "edits"},
+		"additionalProperties": false,
+	},
+}
+
+// correction is the model's reply to correctPrompt.
+type correction struct {
+	Corrected string `json:"corrected"`
+	Edits     []struct {
+		Original      string  `json:"original"`
+		Replacement   string  `json:"replacement"`
+		Category      string  `json:"category"`
+		Explanation   string  `json:"explanation"`
+		ExplanationVI *string `json:"explanation_vi"`
+	} `json:"edits"`
+}
+
+// Correct checks req.Text and returns its corrections. Replies that are
+// not valid JSON, or whose edits do not match the text, are sent back to
+// the model with the problem until it gets them right or runs out of
+// attempts.
+func (s *Service) Correct(ctx context.Context, req CorrectRequest) (*CorrectResponse, error) {
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return nil, ErrEmptyText
+	}
+	if utf8.RuneCountInString(text) > maxCorrectRunes {
+		return nil, ErrTextTooLong
+	}
+
+	vietnamese := `"explanation_vi" is null.`
+	if req.Vietnamese {
+		vietnamese = `"explanation_vi" is the same explanation in Vietnamese.`
+	}
+	llmReq := llm.Request{
+		Messages: []llm.Message{
+			{Role: llm.RoleSystem, Content: fmt.Sprintf(correctPrompt, strings.Join(categories, ", "), vietnamese)},
+			{Role: llm.RoleUser, Content: text},
+		},
+		Temperature: 0,
+		Schema:      correctionSchema,
+	}
+
+	var out *CorrectResponse
+	err := llm.ChatValid(ctx, s.model, llmReq, llm.ValidAttempts, func(reply string) error {
+		var err error
+		out, err = parseCorrection(text, reply, req.Vietnamese)
+		return err
+	})
+	switch {
+	case err == nil:
+		return out, nil
+	case errors.Is(err, llm.ErrInvalidReply):
+		logger.Warn("teacher correct with %s: %v", s.model.Name(), err)
+		return nil, ErrCorrectionFailed
+	default:
+		logger.Error(err, "teacher correct with %s", s.model.Name())
+		return nil, ErrModelUnavailable
+	}
+}
+
+// parseCorrection decodes and validates a reply for text. The edits are
+// located in text in order and must turn it into the corrected text.
+func parseCorrection(text, reply string, vietnamese bool) (*CorrectResponse, error) {
+	var c correction
+	if err := json.Unmarshal([]byte(llm.StripFence(reply)), &c); err != nil {
+		return nil, fmt.Errorf("not a JSON object of the requested shape (%v)", err)
+	}
+
+	out := &CorrectResponse{Text: text, Edits: make([]Edit, 0, len(c.Edits))}
+	var corrected strings.Builder
+	pos := 0 // byte offset in text after the previous edit
+	for i, e := range c.Edits {
+		if e.Original == e.Replacement {
+			continue
+		}
+		switch {
+		case e.Original == "":
+			return nil, fmt.Errorf("edit %d has an empty \"original\"", i+1)
+		case !slices.Contains(categories, e.Category):
+			return nil, fmt.Errorf("edit %d has unknown category %q", i+1, e.Category)
+		case strings.TrimSpace(e.Explanation) == "":
+			return nil, fmt.Errorf("edit %d has no explanation", i+1)
+		case vietnamese && (e.ExplanationVI == nil || strings.TrimSpace(*e.ExplanationVI) == ""):
+			return nil, fmt.Errorf("edit %d has no Vietnamese explanation", i+1)
+		}
+		at := indexWord(text[pos:], e.Original)
+		if at < 0 {
+			return nil, fmt.Errorf("edit %d: %q does not appear in the text after the previous edit", i+1, e.Original)
+		}
+		start := pos + at
+		end := start + len(e.Original)
+		corrected.WriteString(text[pos:start])
+		corrected.WriteString(e.Replacement)
+		pos = end
+
+		edit := Edit{
+			Start:       utf8.RuneCountInString(text[:start]),
+			Original:    e.Original,
+			Replacement: e.Replacement,
+			Category:    e.Category,
+			Explanation: strings.TrimSpace(e.Explanation),
+		}
+		edit.End = edit.Start + utf8.RuneCountInString(e.Original)
+		if vietnamese {
+			vi := strings.TrimSpace(*e.ExplanationVI)
+			edit.ExplanationVI = &vi
+		}
+		out.Edits = append(out.Edits, edit)
+	}
+	corrected.WriteString(text[pos:])
+
+	out.Corrected = corrected.String()
+	if normalizeSpace(out.Corrected) != normalizeSpace(c.Corrected) {
+		return nil, errors.New("applying the edits to the text does not give \"corrected\"; list every change as an edit")
+	}
+	out.Correct = len(out.Edits) == 0
+	return out, nil
+}
+
+// indexWord is strings.Index preferring a match that does not start or end
+// inside a word, so a short original like "go" is not found in "good".
+func indexWord(s, sub string) int {
+	first := strings.Index(s, sub)
+	for from := first; from >= 0; {
+		at := from
+		before, _ := utf8.DecodeLastRuneInString(s[:at])
+		after, _ := utf8.DecodeRuneInString(s[at+len(sub):])
+		start, _ := utf8.DecodeRuneInString(sub)
+		end, _ := utf8.DecodeLastRuneInString(sub)
+		if !(isWordRune(before) && isWordRune(start)) && !(isWordRune(after) && isWordRune(end)) {
+			return at
+		}
+		next := strings.Index(s[at+1:], sub)
+		if next < 0 {
+			break
+		}
+		from = at + 1 + next
+	}
+	return first
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,181 @@
+package teacher
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type testEdit struct {
+	Original      string  `json:"original"`
+	Replacement   string  `json:"replacement"`
+	Category      string  `json:"category"`
+	Explanation   string  `json:"explanation"`
+	ExplanationVI *string `json:"explanation_vi"`
+}
+
+func edit(original, replacement string) testEdit {
+	return testEdit{Original: original, Replacement: replacement, Category: "other", Explanation: "Fixed."}
+}
+
+func correctionReply(t *testing.T, corrected string, edits ...testEdit) string {
+	t.Helper()
+	if edits == nil {
+		edits = []testEdit{}
+	}
+	raw, err := json.Marshal(map[string]any{"corrected": corrected, "edits": edits})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(raw)
+}
+
+func TestIndexWord(t *testing.T) {
+	tests := []struct {
+		s, sub string
+		want   int
+	}{
+		{"I go to school", "go", 2},
+		{"It is good to go", "go", 14},
+		{"a good goal", "go", 2}, // no whole-word match: fall back to the first
+		{"have cat", "have cat", 0},
+		{"the cats, the cat.", "cat", 14},
+		{"cat", "cat", 0},
+		{"Tôi đi học, go home", "go", 16}, // a byte offset
+		{"the end", "nope", -1},
+		{"an apple an", "an", 0},
+		{"ant and an", "an", 8},
+		{"he's", "'s", 2}, // punctuation at the edge may touch a word
+	}
+	for _, tt := range tests {
+		if got := indexWord(tt.s, tt.sub); got != tt.want {
+			t.Errorf("indexWord(%q, %q) = %d, want %d", tt.s, tt.sub, got, tt.want)
+		}
+	}
+}
+
+func TestParseCorrection(t *testing.T) {
+	text := "I has a good dog. Yesterday I go to park."
+	res, err := parseCorrection(text, "```json\n"+correctionReply(t, "I have a good dog. Yesterday I went to the park.",
+		edit("has", "have"),
+		edit("go", "went"),
+		edit("to park", "to the park"),
+		edit("dog", "dog"), // unchanged edits are dropped
+	)+"\n```", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Correct || res.Text != text || res.Corrected != "I have a good dog. Yesterday I went to the park." {
+		t.Errorf("response = %+v", res)
+	}
+	want := []struct {
+		start, end int
+		original   string
+	}{{2, 5, "has"}, {30, 32, "go"}, {33, 40, "to park"}}
+	if len(res.Edits) != len(want) {
+		t.Fatalf("%d edits, want %d", len(res.Edits), len(want))
+	}
+	for i, w := range want {
+		e := res.Edits[i]
+		if e.Start != w.start || e.End != w.end || e.Original != w.original || e.ExplanationVI != nil {
+			t.Errorf("edit %d = %+v, want %q at %d-%d", i+1, e, w.original, w.start, w.end)
+		}
+	}
+}
+
+func TestParseCorrectionRuneOffsets(t *testing.T) {
+	// Offsets count runes, so clients can slice the text in any language.
+	text := "Tôi nghĩ “he go” café — he go home."
+	res, err := parseCorrection(text, correctionReply(t, "Tôi nghĩ “he goes” café — he goes home.",
+		edit("go", "goes"),
+		edit("go", "goes"),
+	), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runes := []rune(text)
+	for i, e := range res.Edits {
+		if got := string(runes[e.Start:e.End]); got != "go" {
+			t.Errorf("edit %d spans %d-%d = %q, want \"go\"", i+1, e.Start, e.End, got)
+		}
+	}
+	if res.Edits[0].Start != 13 || res.Edits[1].Start != 27 {
+		t.Errorf("edits start at %d and %d, want 13 and 27", res.Edits[0].Start, res.Edits[1].Start)
+	}
+}
+
+func TestParseCorrectionShortOriginal(t *testing.T) {
+	// "go" must be matched as a word, not inside "good".
+	text := "It is good, I go now."
+	res, err := parseCorrection(text, correctionReply(t, "It is good, I am going now.", edit("go", "am going")), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := res.Edits[0]; e.Start != 14 || e.End != 16 {
+		t.Errorf("edit at %d-%d, want 14-16", e.Start, e.End)
+	}
+}
+
+func TestParseCorrectionCorrectText(t *testing.T) {
+	res, err := parseCorrection("All good here.", correctionReply(t, "All  good here. "), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Correct || len(res.Edits) != 0 || res.Corrected != "All good here." {
+		t.Errorf("response = %+v", res)
+	}
+}
+
+func TestParseCorrectionVietnamese(t *testing.T) {
+	vi := func(s string) testEdit {
+		e := edit("has", "have")
+		e.ExplanationVI = &s
+		return e
+	}
+	res, err := parseCorrection("I has a dog.", correctionReply(t, "I have a dog.", vi(" Dùng “have” với “I”. ")), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := res.Edits[0].ExplanationVI; got == nil || *got != "Dùng “have” với “I”." {
+		t.Errorf("explanation_vi = %v", got)
+	}
+
+	for name, e := range map[string]testEdit{"null": edit("has", "have"), "blank": vi("  ")} {
+		_, err := parseCorrection("I has a dog.", correctionReply(t, "I have a dog.", e), true)
+		if err == nil || !strings.Contains(err.Error(), "no Vietnamese explanation") {
+			t.Errorf("%s explanation_vi: err = %v", name, err)
+		}
+	}
+}
+
+func TestParseCorrectionRejects(t *testing.T) {
+	text := "I has a dog and she like cats."
+	withCategory := edit("has", "have")
+	withCategory.Category = "style"
+	noExplanation := edit("has", "have")
+	noExplanation.Explanation = " "
+	tests := []struct {
+		name  string
+		reply string
+		want  string
+	}{
+		{"not JSON", "Your text is great!", "not a JSON object"},
+		{"empty original", correctionReply(t, "I has a big dog and she like cats.", edit("", "big ")), `edit 1 has an empty "original"`},
+		{"unknown category", correctionReply(t, "I have a dog and she like cats.", withCategory), `unknown category "style"`},
+		{"no explanation", correctionReply(t, "I have a dog and she like cats.", noExplanation), "edit 1 has no explanation"},
+		{"not in the text", correctionReply(t, "I have a dog and she likes cats.", edit("has", "have"), edit("liked", "likes")), `edit 2: "liked" does not appear`},
+		{"out of order", correctionReply(t, "I have a dog and she likes cats.", edit("like", "likes"), edit("has", "have")), `edit 2: "has" does not appear in the text after the previous edit`},
+		{"overlapping", correctionReply(t, "I have a dog and she like cats.", edit("I has", "I have"), edit("has a", "have a")), `edit 2: "has a" does not appear`},
+		{"missing edit", correctionReply(t, "I have a dog and she likes cats.", edit("has", "have")), `does not give "corrected"`},
+		{"corrected disagrees", correctionReply(t, "I have a cat and she like cats.", edit("has", "have")), `does not give "corrected"`},
+		{"case differs", correctionReply(t, "i have a dog and she like cats.", edit("has", "have")), `does not give "corrected"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCorrection(text, tt.reply, false)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
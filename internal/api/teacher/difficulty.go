@@ -0,0 +1,38 @@
+package teacher
+
+import (
+	"ai-learn-english/internal/learner"
+	"ai-learn-english/internal/retrieval"
+	"slices"
+)
+
+// candidateFactor is how many more passages than needed are retrieved
+// when the learner's level is known, so that passages at the right
+// difficulty can replace slightly more relevant ones that are too easy or
+// too hard.
+const candidateFactor = 2
+
+// pickPassages returns the topK passages best suited to profile. Each
+// passage's relevance is divided by one plus its distance from the
+// learner's reading band, so relevance still decides among passages of a
+// suitable level.
+func pickPassages(passages []retrieval.Passage, profile *learner.Profile, topK int) []retrieval.Passage {
+	weight := func(p retrieval.Passage) float32 {
+		return p.Score / float32(1+profile.Distance(p.Chunk.CefrLevel))
+	}
+	slices.SortStableFunc(passages, func(a, b retrieval.Passage) int {
+		wa, wb := weight(a), weight(b)
+		switch {
+		case wa > wb:
+			return -1
+		case wa < wb:
+			return 1
+		default:
+			return 0
+		}
+	})
+	if len(passages) > topK {
+		passages = passages[:topK]
+	}
+	return passages
+}
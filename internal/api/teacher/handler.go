@@ -1,8 +1,73 @@
 package teacher
 
-import "github.com/gofiber/fiber/v3"
+import (
+	"ai-learn-english/internal/middleware"
+	"ai-learn-english/pkg/apperror"
+	"ai-learn-english/pkg/sse"
+	"bufio"
+	"context"
 
-// GetTeacher returns an empty string as a minimal placeholder.
-func GetTeacher(c fiber.Ctx) error {
-	return c.SendString("")
+	"github.com/gofiber/fiber/v3"
+)
+
+var ErrInvalidBody = apperror.New("invalid_body", "request body is not valid JSON")
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Chat handles POST /teacher/chat. With "stream": true, or when the client
+// accepts text/event-stream, the answer is streamed as Server-Sent Events.
+func (h *Handler) Chat(c fiber.Ctx) error {
+	var req ChatRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return ErrInvalidBody
+	}
+	if req.Stream || sse.Accepts(c.Get(fiber.HeaderAccept)) {
+		return h.stream(c, req)
+	}
+
+	res, err := h.svc.Chat(c.Context(), middleware.UserID(c), req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// Correct handles POST /teacher/correct.
+func (h *Handler) Correct(c fiber.Ctx) error {
+	var req CorrectRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return ErrInvalidBody
+	}
+
+	res, err := h.svc.Correct(c.Context(), req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// stream prepares the turn while errors can still be returned as JSON, then
+// switches the response to an event stream.
+func (h *Handler) stream(c fiber.Ctx, req ChatRequest) error {
+	turn, err := h.svc.Prepare(c.Context(), middleware.UserID(c), req)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// The writer runs after the handler has returned, so it must not use c.
+	return c.SendStreamWriter(func(w *bufio.Writer) {
+		events := sse.NewWriter(w)
+		h.svc.Stream(context.Background(), turn, events.Event)
+	})
 }
@@ -0,0 +1,348 @@
+package teacher
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/learner"
+	"ai-learn-english/internal/llm"
+	"ai-learn-english/internal/middleware"
+	"ai-learn-english/internal/retrieval"
+	"ai-learn-english/internal/token"
+	"ai-learn-english/pkg/httpjson"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+const testUserID = 42
+
+// fakeStore keeps conversations in memory. Titles are set from a
+// background goroutine, hence the lock.
+type fakeStore struct {
+	mu      sync.Mutex
+	profile *learner.Profile
+	convs   map[int64]*model.Conversation
+	turns   [][2]*model.Message
+	titles  map[int64]string
+}
+
+func (s *fakeStore) DocumentOwned(ctx context.Context, userID, documentID int64) (bool, error) {
+	return userID == testUserID && documentID == 7, nil
+}
+
+func (s *fakeStore) Profile(ctx context.Context, userID int64) (*learner.Profile, error) {
+	if s.profile == nil {
+		return learner.FromModel(nil), nil
+	}
+	return s.profile, nil
+}
+
+func (s *fakeStore) FindConversation(ctx context.Context, userID, id int64) (*model.Conversation, error) {
+	if conv, ok := s.convs[id]; ok && conv.UserID == userID {
+		return conv, nil
+	}
+	return nil, nil
+}
+
+func (s *fakeStore) SaveTurn(ctx context.Context, conv *model.Conversation, question, answer *model.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if conv.ID == 0 {
+		conv.ID = 1
+	}
+	question.ID = int64(2*len(s.turns) + 1)
+	answer.ID = question.ID + 1
+	s.turns = append(s.turns, [2]*model.Message{question, answer})
+	return nil
+}
+
+func (s *fakeStore) SetTitle(ctx context.Context, conversationID int64, title string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.titles == nil {
+		s.titles = make(map[int64]string)
+	}
+	s.titles[conversationID] = title
+	return nil
+}
+
+func (s *fakeStore) saved() [][2]*model.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][2]*model.Message(nil), s.turns...)
+}
+
+type fakeRetriever struct {
+	passages []retrieval.Passage
+}
+
+func (r fakeRetriever) Retrieve(ctx context.Context, userID int64, question string, topK int, documentIDs ...int64) ([]retrieval.Passage, error) {
+	return r.passages[:min(topK, len(r.passages))], nil
+}
+
+// fakeMemory has no history: the prompt is the system prompt and the
+// question.
+type fakeMemory struct{}
+
+func (fakeMemory) Prompt(ctx context.Context, conv *model.Conversation, system, question string) ([]llm.Message, error) {
+	return []llm.Message{{Role: llm.RoleSystem, Content: system}, {Role: llm.RoleUser, Content: question}}, nil
+}
+
+func (fakeMemory) Summarize(ctx context.Context, conversationID int64) error { return nil }
+
+func passage(id int64, page int32, content string) retrieval.Passage {
+	return retrieval.Passage{Chunk: &model.Chunk{ID: id, DocumentID: 7, PageIndex: &page, Content: content}, Score: 1}
+}
+
+// newTestApp serves the teacher routes of a service using store and model,
+// and returns a bearer token for testUserID.
+func newTestApp(t *testing.T, store *fakeStore, chatModel llm.ChatModel) (*fiber.App, string) {
+	t.Helper()
+	config.Cfg.Auth = config.AuthConfig{JWTSecret: "test-secret", AccessTTLMinutes: 5}
+	issued, err := token.NewManager(config.Cfg.Auth).IssueAccess(testUserID)
+	if err != nil {
+		t.Fatalf("issue token: %v", err)
+	}
+
+	retriever := fakeRetriever{passages: []retrieval.Passage{
+		passage(11, 3, "The present perfect links the past to the present."),
+		passage(12, 4, "Use the past simple for finished time."),
+	}}
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	RegisterRoutes(app, NewHandler(NewService(store, retriever, chatModel, fakeMemory{}, 5)))
+	return app, issued.Token
+}
+
+func postChat(t *testing.T, app *fiber.App, bearer string, body any) (*http.Response, []byte) {
+	t.Helper()
+	raw, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/teacher/chat", strings.NewReader(string(raw)))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if bearer != "" {
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+bearer)
+	}
+	res, err := app.Test(req, fiber.TestConfig{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer res.Body.Close()
+	out, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return res, out
+}
+
+func errorCode(t *testing.T, body []byte) string {
+	t.Helper()
+	var e struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(body, &e); err != nil {
+		t.Fatalf("decode error %s: %v", body, err)
+	}
+	return e.Code
+}
+
+func statusErr(code int) error {
+	return &httpjson.StatusError{Service: "fake", StatusCode: code, Body: http.StatusText(code)}
+}
+
+func TestChatSendsGroundedPrompt(t *testing.T) {
+	level := "B1"
+	store := &fakeStore{profile: &learner.Profile{CEFRLevel: &level}}
+	fake := llm.NewFake("teacher",
+		llm.FakeReply{Content: "It links the past to now [1]."},
+		llm.FakeReply{Content: "Present perfect"},
+	)
+	app, bearer := newTestApp(t, store, fake)
+
+	res, body := postChat(t, app, bearer, ChatRequest{Question: "When do I use the present perfect?"})
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status %d: %s", res.StatusCode, body)
+	}
+	var out ChatResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Answer != "It links the past to now [1]." {
+		t.Errorf("Answer = %q", out.Answer)
+	}
+	if len(out.Citations) != 1 || out.Citations[0].ChunkID != 11 || *out.Citations[0].PageIndex != 3 {
+		t.Errorf("Citations = %+v, want chunk 11 on page 3", out.Citations)
+	}
+
+	reqs := fake.Requests()
+	if len(reqs) == 0 {
+		t.Fatal("the model was not called")
+	}
+	msgs := reqs[0].Messages
+	if len(msgs) != 2 || msgs[0].Role != llm.RoleSystem || msgs[1].Role != llm.RoleUser {
+		t.Fatalf("prompt roles = %+v, want system then user", msgs)
+	}
+	system := msgs[0].Content
+	for _, want := range []string{
+		"[1] (page 3)\nThe present perfect links the past to the present.",
+		"[2] (page 4)\nUse the past simple for finished time.",
+		"About the learner:",
+		"B1",
+	} {
+		if !strings.Contains(system, want) {
+			t.Errorf("system prompt lacks %q:\n%s", want, system)
+		}
+	}
+	if msgs[1].Content != "When do I use the present perfect?" {
+		t.Errorf("question = %q", msgs[1].Content)
+	}
+
+	saved := store.saved()
+	if len(saved) != 1 || saved[0][0].Content != "When do I use the present perfect?" || saved[0][1].Content != out.Answer {
+		t.Errorf("saved turns = %+v", saved)
+	}
+}
+
+func TestChatErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		bearer  bool
+		body    ChatRequest
+		replies []llm.FakeReply
+		status  int
+		code    string
+	}{
+		{"unauthenticated", false, ChatRequest{Question: "Hi?"}, nil, http.StatusUnauthorized, "unauthenticated"},
+		{"empty question", true, ChatRequest{Question: "   "}, nil, http.StatusBadRequest, "empty_question"},
+		{"foreign document", true, ChatRequest{Question: "Hi?", DocumentID: ptr(int64(8))}, nil, http.StatusNotFound, "document_not_found"},
+		{"unknown conversation", true, ChatRequest{Question: "Hi?", ConversationID: ptr(int64(9))}, nil, http.StatusNotFound, "conversation_not_found"},
+		{"other document than the conversation", true, ChatRequest{Question: "Hi?", ConversationID: ptr(int64(5)), DocumentID: ptr(int64(8))}, nil, http.StatusConflict, "document_mismatch"},
+		{"document for an unscoped conversation", true, ChatRequest{Question: "Hi?", ConversationID: ptr(int64(6)), DocumentID: ptr(int64(7))}, nil, http.StatusConflict, "document_mismatch"},
+		{"model fails", true, ChatRequest{Question: "Hi?"}, []llm.FakeReply{{Err: statusErr(http.StatusInternalServerError)}}, http.StatusBadGateway, "model_unavailable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeStore{convs: map[int64]*model.Conversation{
+				5: {ID: 5, UserID: testUserID, DocumentID: ptr(int64(7))},
+				6: {ID: 6, UserID: testUserID},
+			}}
+			fake := llm.NewFake("teacher", tt.replies...)
+			app, bearer := newTestApp(t, store, fake)
+			if !tt.bearer {
+				bearer = ""
+			}
+
+			res, body := postChat(t, app, bearer, tt.body)
+			if res.StatusCode != tt.status {
+				t.Fatalf("status %d, want %d: %s", res.StatusCode, tt.status, body)
+			}
+			if code := errorCode(t, body); code != tt.code {
+				t.Errorf("code = %q, want %q", code, tt.code)
+			}
+			if saved := store.saved(); len(saved) != 0 {
+				t.Errorf("a failed request saved %d turns", len(saved))
+			}
+		})
+	}
+}
+
+func TestChatFallback(t *testing.T) {
+	tests := []struct {
+		name         string
+		primaryErr   error
+		status       int
+		secondaryHit bool
+	}{
+		{"server error fails over", statusErr(http.StatusServiceUnavailable), http.StatusOK, true},
+		{"rate limit fails over", statusErr(http.StatusTooManyRequests), http.StatusOK, true},
+		{"timeout fails over", context.DeadlineExceeded, http.StatusOK, true},
+		{"bad request does not", statusErr(http.StatusBadRequest), http.StatusBadGateway, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := llm.NewFake("primary", llm.FakeReply{Err: tt.primaryErr})
+			secondary := llm.NewFake("secondary", llm.FakeReply{Content: "From the secondary."}, llm.FakeReply{Content: "Title"})
+			app, bearer := newTestApp(t, &fakeStore{}, llm.Fallback(primary, secondary))
+
+			res, body := postChat(t, app, bearer, ChatRequest{Question: "Hi?"})
+			if res.StatusCode != tt.status {
+				t.Fatalf("status %d, want %d: %s", res.StatusCode, tt.status, body)
+			}
+			got := secondary.Requests()
+			if hit := len(got) > 0; hit != tt.secondaryHit {
+				t.Fatalf("secondary called = %v, want %v", hit, tt.secondaryHit)
+			}
+			if !tt.secondaryHit {
+				return
+			}
+			if first := primary.Requests()[0]; got[0].Messages[0].Content != first.Messages[0].Content {
+				t.Error("the secondary model got a different prompt")
+			}
+			var out ChatResponse
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Fatal(err)
+			}
+			if out.Answer != "From the secondary." {
+				t.Errorf("Answer = %q", out.Answer)
+			}
+		})
+	}
+}
+
+func TestChatStream(t *testing.T) {
+	tests := []struct {
+		name   string
+		reply  llm.FakeReply
+		events []string
+		saved  string
+	}{
+		{"complete", llm.FakeReply{Deltas: []string{"Hel", "lo."}}, []string{"delta", "delta", "done"}, "Hello."},
+		{"fails mid-answer", llm.FakeReply{Deltas: []string{"Hel"}, Err: statusErr(http.StatusBadGateway)}, []string{"delta", "error"}, "Hel"},
+		{"fails before any text", llm.FakeReply{Err: statusErr(http.StatusBadGateway)}, []string{"error"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeStore{}
+			fake := llm.NewFake("teacher", tt.reply, llm.FakeReply{Content: "Title"})
+			app, bearer := newTestApp(t, store, fake)
+
+			res, body := postChat(t, app, bearer, ChatRequest{Question: "Hi?", Stream: true})
+			if res.StatusCode != http.StatusOK {
+				t.Fatalf("status %d: %s", res.StatusCode, body)
+			}
+			if ct := res.Header.Get(fiber.HeaderContentType); ct != "text/event-stream" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+			var events []string
+			for _, line := range strings.Split(string(body), "\n") {
+				if name, ok := strings.CutPrefix(line, "event: "); ok {
+					events = append(events, name)
+				}
+			}
+			if strings.Join(events, ",") != strings.Join(tt.events, ",") {
+				t.Errorf("events = %v, want %v", events, tt.events)
+			}
+
+			saved := store.saved()
+			switch {
+			case tt.saved == "" && len(saved) != 0:
+				t.Errorf("saved %d turns without any delivered text", len(saved))
+			case tt.saved != "" && (len(saved) != 1 || saved[0][1].Content != tt.saved):
+				t.Errorf("saved turns = %+v, want the answer %q", saved, tt.saved)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,72 @@
+package teacher
+
+import (
+	"ai-learn-english/internal/learner"
+	"ai-learn-english/internal/retrieval"
+	"fmt"
+	"strings"
+)
+
+const systemPrompt = `You are a patient English teacher helping a learner study their own documents.
+Answer the learner's question using the numbered passages below when they are relevant.
+Cite the passages you rely on with their number in square brackets, e.g. [2], and mention the page when it helps.
+If the passages do not contain the answer, say so and answer from your general knowledge of English.
+Explain clearly with short examples and keep the answer focused.`
+
+// buildSystem returns the system prompt pitched at profile and grounding
+// the answer in passages, numbered from 1 in retrieval order.
+func buildSystem(passages []retrieval.Passage, profile *learner.Profile) string {
+	var b strings.Builder
+	b.WriteString(systemPrompt)
+	if instructions := profile.Instructions(); instructions != "" {
+		b.WriteString("\n\nAbout the learner:\n")
+		b.WriteString(instructions)
+	}
+	if len(passages) == 0 {
+		b.WriteString("\n\nNo passages from the learner's documents matched this question.")
+	} else {
+		b.WriteString("\n\nPassages:")
+		for i, p := range passages {
+			fmt.Fprintf(&b, "\n\n[%d]", i+1)
+			if p.Chunk.PageIndex != nil {
+				fmt.Fprintf(&b, " (page %d)", *p.Chunk.PageIndex)
+			}
+			if p.Chunk.CefrLevel != nil {
+				fmt.Fprintf(&b, " (level %s)", *p.Chunk.CefrLevel)
+			}
+			b.WriteString("\n")
+			b.WriteString(p.Chunk.Content)
+		}
+	}
+
+	return b.String()
+}
+
+// citations returns the passages referenced in answer as [n]. When the
+// answer cites nothing every passage is returned, since all of them were
+// given to the model as context.
+func citations(answer string, passages []retrieval.Passage) []Citation {
+	out := make([]Citation, 0, len(passages))
+	for i, p := range passages {
+		if strings.Contains(answer, fmt.Sprintf("[%d]", i+1)) {
+			out = append(out, newCitation(i+1, p))
+		}
+	}
+	if len(out) > 0 {
+		return out
+	}
+	for i, p := range passages {
+		out = append(out, newCitation(i+1, p))
+	}
+	return out
+}
+
+func newCitation(number int, p retrieval.Passage) Citation {
+	return Citation{
+		Number:     number,
+		ChunkID:    p.Chunk.ID,
+		DocumentID: p.Chunk.DocumentID,
+		PageIndex:  p.Chunk.PageIndex,
+		Preview:    p.Chunk.ContentPreview,
+	}
+}
@@ -1 +1,71 @@
-package teacher
\ No newline at end of file
+package teacher
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/internal/learner"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Repository persists chat turns through the generated query package.
+type Repository struct {
+	q *query.Query
+}
+
+func NewRepository(q *query.Query) *Repository {
+	return &Repository{q: q}
+}
+
+// DocumentOwned reports whether documentID exists and belongs to userID.
+func (r *Repository) DocumentOwned(ctx context.Context, userID, documentID int64) (bool, error) {
+	d := r.q.Document
+	n, err := d.WithContext(ctx).Where(d.ID.Eq(documentID), d.UserID.Eq(userID)).Count()
+	return n > 0, err
+}
+
+// Profile returns the user's learner profile.
+func (r *Repository) Profile(ctx context.Context, userID int64) (*learner.Profile, error) {
+	return learner.Load(ctx, r.q, userID)
+}
+
+// FindConversation returns the conversation with id if it belongs to
+// userID, or nil when there is none.
+func (r *Repository) FindConversation(ctx context.Context, userID, id int64) (*model.Conversation, error) {
+	c := r.q.Conversation
+	conv, err := c.WithContext(ctx).Where(c.ID.Eq(id), c.UserID.Eq(userID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return conv, err
+}
+
+// SaveTurn stores the user question and the assistant answer together in
+// conv, creating the conversation first when it is new, and marks the
+// conversation as recently active.
+func (r *Repository) SaveTurn(ctx context.Context, conv *model.Conversation, question, answer *model.Message) error {
+	return r.q.Transaction(func(tx *query.Query) error {
+		c := tx.Conversation
+		if conv.ID == 0 {
+			if err := c.WithContext(ctx).Create(conv); err != nil {
+				return err
+			}
+		} else if _, err := c.WithContext(ctx).Where(c.ID.Eq(conv.ID)).UpdateSimple(c.UpdatedAt.Value(time.Now())); err != nil {
+			return err
+		}
+		question.ConversationID = conv.ID
+		answer.ConversationID = conv.ID
+		return tx.Message.WithContext(ctx).Create(question, answer)
+	})
+}
+
+// SetTitle titles the conversation unless it already has a title, so a
+// rename by the learner is never overwritten.
+func (r *Repository) SetTitle(ctx context.Context, conversationID int64, title string) error {
+	c := r.q.Conversation
+	_, err := c.WithContext(ctx).Where(c.ID.Eq(conversationID), c.Title.IsNull()).UpdateSimple(c.Title.Value(title))
+	return err
+}
@@ -1,12 +1,15 @@
 package teacher
 
 import (
+	"ai-learn-english/internal/middleware"
+
 	"github.com/gofiber/fiber/v3"
 )
 
 // RegisterRoutes registers teacher-related routes on the provided router.
-func RegisterRoutes(r fiber.Router) {
-	grp := r.Group("/teacher")
+func RegisterRoutes(r fiber.Router, h *Handler) {
+	grp := r.Group("/teacher", middleware.RequireUser())
 
-	grp.Get("/", GetTeacher)
+	grp.Post("/chat", h.Chat)
+	grp.Post("/correct", h.Correct)
 }
@@ -1 +1,246 @@
-package teacher
\ No newline at end of file
+package teacher
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/learner"
+	"ai-learn-english/internal/llm"
+	"ai-learn-english/internal/retrieval"
+	"ai-learn-english/pkg/apperror"
+	"ai-learn-english/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	roleUser      = "user"
+	roleAssistant = "assistant"
+)
+
+var (
+	ErrEmptyQuestion        = apperror.New("empty_question", "question must not be empty")
+	ErrDocumentNotFound     = apperror.New("document_not_found", "document not found").WithStatus(http.StatusNotFound)
+	ErrConversationNotFound = apperror.New("conversation_not_found", "conversation not found").WithStatus(http.StatusNotFound)
+	ErrConversationArchived = apperror.New("conversation_archived", "conversation is archived, restore it to continue").WithStatus(http.StatusConflict)
+	ErrDocumentMismatch     = apperror.New("document_mismatch", "document_id must match the document the conversation is scoped to").WithStatus(http.StatusConflict)
+	ErrModelUnavailable     = apperror.New("model_unavailable", "the teacher is unavailable, please try again").WithStatus(http.StatusBadGateway)
+)
+
+// errClientGone is returned by stream senders when the client disconnected.
+var errClientGone = errors.New("client disconnected")
+
+// Store keeps the conversations the teacher answers in. Repository is the
+// database implementation.
+type Store interface {
+	DocumentOwned(ctx context.Context, userID, documentID int64) (bool, error)
+	Profile(ctx context.Context, userID int64) (*learner.Profile, error)
+	FindConversation(ctx context.Context, userID, id int64) (*model.Conversation, error)
+	SaveTurn(ctx context.Context, conv *model.Conversation, question, answer *model.Message) error
+	SetTitle(ctx context.Context, conversationID int64, title string) error
+}
+
+// Retriever finds the passages of the learner's documents that answers are
+// grounded in, as retrieval.Retriever does.
+type Retriever interface {
+	Retrieve(ctx context.Context, userID int64, question string, topK int, documentIDs ...int64) ([]retrieval.Passage, error)
+}
+
+// Memory assembles prompts from conversation history and keeps the running
+// summary up to date, as memory.Manager does.
+type Memory interface {
+	Prompt(ctx context.Context, conv *model.Conversation, system, question string) ([]llm.Message, error)
+	Summarize(ctx context.Context, conversationID int64) error
+}
+
+// Service answers learner questions grounded in their documents.
+type Service struct {
+	repo      Store
+	retriever Retriever
+	model     llm.ChatModel
+	memory    Memory
+	topK      int
+}
+
+func NewService(repo Store, retriever Retriever, model llm.ChatModel, memory Memory, topK int) *Service {
+	return &Service{repo: repo, retriever: retriever, model: model, memory: memory, topK: topK}
+}
+
+// Turn is a prepared question: validated, scoped and grounded in the
+// retrieved passages, ready to be sent to the model.
+type Turn struct {
+	userID       int64
+	conversation *model.Conversation
+	question     string
+	documentID   *int64
+	passages     []retrieval.Passage
+	request      llm.Request
+}
+
+// Prepare validates req, retrieves the passages relevant to the question and
+// assembles the prompt from them and the conversation so far.
+func (s *Service) Prepare(ctx context.Context, userID int64, req ChatRequest) (*Turn, error) {
+	question := strings.TrimSpace(req.Question)
+	if question == "" {
+		return nil, ErrEmptyQuestion
+	}
+
+	conv := &model.Conversation{UserID: userID, DocumentID: req.DocumentID}
+	if req.ConversationID != nil {
+		found, err := s.repo.FindConversation(ctx, userID, *req.ConversationID)
+		if err != nil {
+			return nil, fmt.Errorf("find conversation: %w", err)
+		}
+		if found == nil {
+			return nil, ErrConversationNotFound
+		}
+		if found.ArchivedAt != nil {
+			return nil, ErrConversationArchived
+		}
+		// A turn is retrieved from and saved under the conversation's
+		// document, so it cannot move the thread to another one.
+		if req.DocumentID != nil && (found.DocumentID == nil || *found.DocumentID != *req.DocumentID) {
+			return nil, ErrDocumentMismatch
+		}
+		conv = found
+	}
+
+	documentID := conv.DocumentID
+	if req.DocumentID != nil {
+		owned, err := s.repo.DocumentOwned(ctx, userID, *req.DocumentID)
+		if err != nil {
+			return nil, fmt.Errorf("check document: %w", err)
+		}
+		if !owned {
+			return nil, ErrDocumentNotFound
+		}
+		documentID = req.DocumentID
+	}
+	var docIDs []int64
+	if documentID != nil {
+		docIDs = append(docIDs, *documentID)
+	}
+
+	profile, err := s.repo.Profile(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("load learner profile: %w", err)
+	}
+	candidates := s.topK
+	if profile.CEFRLevel != nil {
+		candidates *= candidateFactor
+	}
+	passages, err := s.retriever.Retrieve(ctx, userID, question, candidates, docIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve passages: %w", err)
+	}
+	passages = pickPassages(passages, profile, s.topK)
+
+	messages, err := s.memory.Prompt(ctx, conv, buildSystem(passages, profile), question)
+	if err != nil {
+		return nil, fmt.Errorf("build prompt: %w", err)
+	}
+
+	return &Turn{
+		userID:       userID,
+		conversation: conv,
+		question:     question,
+		documentID:   documentID,
+		passages:     passages,
+		request:      llm.Request{Messages: messages, Temperature: 0.3},
+	}, nil
+}
+
+// Chat answers req and stores both turns in the messages table.
+func (s *Service) Chat(ctx context.Context, userID int64, req ChatRequest) (*ChatResponse, error) {
+	turn, err := s.Prepare(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.model.Chat(ctx, turn.request)
+	if err != nil {
+		logger.Error(err, "teacher chat with %s", s.model.Name())
+		return nil, ErrModelUnavailable
+	}
+	return s.save(ctx, turn, res.Content)
+}
+
+// Stream answers a prepared turn, sending "delta" events with the text as
+// the model produces it and a final "done" event with the ChatResponse.
+// An error from send means the client has disconnected: the model request
+// is cancelled and only the text that was delivered is stored.
+func (s *Service) Stream(ctx context.Context, turn *Turn, send func(event string, data any) error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var delivered strings.Builder
+	_, err := s.model.Stream(ctx, turn.request, func(delta string) error {
+		if err := send("delta", DeltaEvent{Text: delta}); err != nil {
+			return fmt.Errorf("%w: %v", errClientGone, err)
+		}
+		delivered.WriteString(delta)
+		return nil
+	})
+	gone := errors.Is(err, errClientGone)
+	if err != nil && !gone {
+		logger.Error(err, "teacher stream with %s", s.model.Name())
+	}
+
+	if delivered.Len() == 0 {
+		if err != nil && !gone {
+			send("error", ErrModelUnavailable)
+		}
+		return
+	}
+
+	// Store what the learner actually saw, even when the client left.
+	res, saveErr := s.save(context.WithoutCancel(ctx), turn, delivered.String())
+	if saveErr != nil {
+		logger.Error(saveErr, "save streamed teacher answer")
+	}
+	switch {
+	case gone:
+	case err != nil:
+		send("error", ErrModelUnavailable)
+	case saveErr != nil:
+		send("error", apperror.New("internal_error", "An unexpected error occurred"))
+	default:
+		send("done", res)
+	}
+}
+
+// save stores the question and answer of turn as one exchange of its
+// conversation. In the background, an untitled conversation is titled from
+// this exchange and older exchanges are folded into the summary.
+func (s *Service) save(ctx context.Context, turn *Turn, answer string) (*ChatResponse, error) {
+	userMsg := &model.Message{UserID: turn.userID, Role: roleUser, Content: turn.question, DocumentID: turn.documentID}
+	assistantMsg := &model.Message{UserID: turn.userID, Role: roleAssistant, Content: answer, DocumentID: turn.documentID}
+	if err := s.repo.SaveTurn(ctx, turn.conversation, userMsg, assistantMsg); err != nil {
+		return nil, fmt.Errorf("save messages: %w", err)
+	}
+	if turn.conversation.Title == nil {
+		go s.title(context.WithoutCancel(ctx), turn.conversation.ID, turn.question, answer)
+	}
+	go s.summarize(context.WithoutCancel(ctx), turn.conversation.ID)
+
+	return &ChatResponse{
+		ConversationID: turn.conversation.ID,
+		MessageID:      assistantMsg.ID,
+		Answer:         answer,
+		Citations:      citations(answer, turn.passages),
+	}, nil
+}
+
+// summaryTimeout bounds a background summary update, which may take a few
+// model calls after a long gap.
+const summaryTimeout = 2 * time.Minute
+
+func (s *Service) summarize(ctx context.Context, conversationID int64) {
+	ctx, cancel := context.WithTimeout(ctx, summaryTimeout)
+	defer cancel()
+	if err := s.memory.Summarize(ctx, conversationID); err != nil {
+		logger.Error(err, "update conversation memory")
+	}
+}
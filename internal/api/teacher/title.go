@@ -0,0 +1,61 @@
+package teacher
+
+import (
+	"ai-learn-english/internal/llm"
+	"ai-learn-english/pkg/logger"
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const titlePrompt = `Write a short title, at most six words, for a conversation between an English learner and their teacher that starts with the exchange below.
+Reply with the title only, without quotes or a trailing full stop.`
+
+const (
+	titleTimeout = 20 * time.Second
+	// maxTitleRunes keeps fallback titles short; the column allows 255.
+	maxTitleRunes = 60
+)
+
+// title names a conversation after its first exchange. When the model
+// cannot help, the start of the question is used instead.
+func (s *Service) title(ctx context.Context, conversationID int64, question, answer string) {
+	ctx, cancel := context.WithTimeout(ctx, titleTimeout)
+	defer cancel()
+
+	title := ""
+	res, err := s.model.Chat(ctx, llm.Request{
+		Messages: []llm.Message{
+			{Role: llm.RoleSystem, Content: titlePrompt},
+			{Role: llm.RoleUser, Content: "Learner: " + question + "\n\nTeacher: " + answer},
+		},
+		Temperature: 0.2,
+		MaxTokens:   24,
+	})
+	if err != nil {
+		logger.Warn("title conversation %d with %s: %v", conversationID, s.model.Name(), err)
+	} else {
+		title = strings.Trim(strings.TrimSpace(res.Content), "\"'.")
+	}
+	if title == "" {
+		title = question
+	}
+
+	if err := s.repo.SetTitle(ctx, conversationID, truncate(title, maxTitleRunes)); err != nil {
+		logger.Error(err, "save title of conversation %d", conversationID)
+	}
+}
+
+// truncate shortens s to at most n runes on a word boundary where possible.
+func truncate(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	cut := string([]rune(s)[:n-1])
+	if i := strings.LastIndex(cut, " "); i > n/2 {
+		cut = cut[:i]
+	}
+	return cut + "…"
+}
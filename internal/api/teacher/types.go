@@ -1 +1,64 @@
-package teacher
\ No newline at end of file
+package teacher
+
+// ChatRequest is the body of POST /teacher/chat. The exchange is added to
+// ConversationID, or to a new conversation when it is unset. DocumentID
+// restricts retrieval to one of the user's documents and scopes a new
+// conversation to it; an existing conversation keeps its document, and a
+// different DocumentID is rejected. When Stream is set the answer is
+// sent as Server-Sent Events instead of a single JSON response.
+type ChatRequest struct {
+	Question       string `json:"question"`
+	ConversationID *int64 `json:"conversation_id"`
+	DocumentID     *int64 `json:"document_id"`
+	Stream         bool   `json:"stream"`
+}
+
+// Citation points at a chunk the answer is grounded in. Number matches the
+// [n] markers used in the answer text.
+type Citation struct {
+	Number     int     `json:"number"`
+	ChunkID    int64   `json:"chunk_id"`
+	DocumentID int64   `json:"document_id"`
+	PageIndex  *int32  `json:"page_index"`
+	Preview    *string `json:"preview"`
+}
+
+type ChatResponse struct {
+	ConversationID int64      `json:"conversation_id"`
+	MessageID      int64      `json:"message_id"`
+	Answer         string     `json:"answer"`
+	Citations      []Citation `json:"citations"`
+}
+
+// DeltaEvent is the payload of a streamed "delta" event.
+type DeltaEvent struct {
+	Text string `json:"text"`
+}
+
+// CorrectRequest is the body of POST /teacher/correct. With Vietnamese set
+// every edit is also explained in Vietnamese.
+type CorrectRequest struct {
+	Text       string `json:"text"`
+	Vietnamese bool   `json:"vietnamese"`
+}
+
+// Edit is one correction. Start and End are offsets in Unicode code points
+// into the submitted text, End exclusive; Original is the text between them.
+type Edit struct {
+	Start         int     `json:"start"`
+	End           int     `json:"end"`
+	Original      string  `json:"original"`
+	Replacement   string  `json:"replacement"`
+	Category      string  `json:"category"`
+	Explanation   string  `json:"explanation"`
+	ExplanationVI *string `json:"explanation_vi"`
+}
+
+// CorrectResponse is the corrected text with the edits that produce it from
+// Text, in order. Correct is set when there is nothing to fix.
+type CorrectResponse struct {
+	Text      string `json:"text"`
+	Corrected string `json:"corrected"`
+	Correct   bool   `json:"correct"`
+	Edits     []Edit `json:"edits"`
+}
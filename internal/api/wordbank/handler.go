@@ -0,0 +1,93 @@
+package wordbank
+
+import (
+	"ai-learn-english/internal/middleware"
+	"ai-learn-english/pkg/apperror"
+	"strconv"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+var (
+	ErrInvalidBody  = apperror.New("invalid_body", "request body is not valid JSON")
+	ErrInvalidQuery = apperror.New("invalid_query", "query parameters are not valid")
+)
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// List handles GET /word-bank?q=&known=&level=&limit=&offset=.
+func (h *Handler) List(c fiber.Ctx) error {
+	var req ListRequest
+	if err := c.Bind().Query(&req); err != nil {
+		return ErrInvalidQuery
+	}
+
+	res, err := h.svc.List(c.Context(), middleware.UserID(c), req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// Add handles POST /word-bank. New entries are answered with 201.
+func (h *Handler) Add(c fiber.Ctx) error {
+	var req AddRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return ErrInvalidBody
+	}
+
+	res, err := h.svc.Add(c.Context(), middleware.UserID(c), req)
+	if err != nil {
+		return err
+	}
+	status := fiber.StatusOK
+	if res.Created {
+		status = fiber.StatusCreated
+	}
+	return c.Status(status).JSON(res)
+}
+
+// Update handles PATCH /word-bank/:id.
+func (h *Handler) Update(c fiber.Ctx) error {
+	id, err := entryID(c)
+	if err != nil {
+		return err
+	}
+	var req UpdateRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return ErrInvalidBody
+	}
+
+	res, err := h.svc.Update(c.Context(), middleware.UserID(c), id, req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(res)
+}
+
+// Delete handles DELETE /word-bank/:id.
+func (h *Handler) Delete(c fiber.Ctx) error {
+	id, err := entryID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.svc.Delete(c.Context(), middleware.UserID(c), id); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func entryID(c fiber.Ctx) (int64, error) {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidID
+	}
+	return id, nil
+}
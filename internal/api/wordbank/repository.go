@@ -0,0 +1,134 @@
+package wordbank
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+)
+
+// Repository persists word bank entries through the generated query package.
+type Repository struct {
+	q *query.Query
+}
+
+func NewRepository(q *query.Query) *Repository {
+	return &Repository{q: q}
+}
+
+// List returns a page of the user's entries matching the filters, newest
+// first, and the number of matching entries.
+func (r *Repository) List(ctx context.Context, userID int64, req ListRequest, offset, limit int) ([]*model.WordBank, int64, error) {
+	w := r.q.WordBank
+	do := w.WithContext(ctx).Where(w.UserID.Eq(userID))
+	if q := strings.ToLower(strings.TrimSpace(req.Q)); q != "" {
+		like := escapeLike(q)
+		do = do.Where(w.WithContext(ctx).Where(w.Lemma.Like(like + "%")).Or(w.Note.Like("%" + like + "%")))
+	}
+	if req.Known != nil {
+		do = do.Where(w.Known.Is(*req.Known))
+	}
+	if req.Level != "" {
+		do = do.Where(w.CefrLevel.Eq(req.Level))
+	}
+	return do.Order(w.CreatedAt.Desc(), w.ID.Desc()).FindByPage(offset, limit)
+}
+
+// FindOwned returns the entry with id if it belongs to userID, or nil.
+func (r *Repository) FindOwned(ctx context.Context, userID, id int64) (*model.WordBank, error) {
+	w := r.q.WordBank
+	entry, err := w.WithContext(ctx).Where(w.ID.Eq(id), w.UserID.Eq(userID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return entry, err
+}
+
+// FindByLemma returns the user's entry for lemma, or nil.
+func (r *Repository) FindByLemma(ctx context.Context, userID int64, lemma string) (*model.WordBank, error) {
+	w := r.q.WordBank
+	entry, err := w.WithContext(ctx).Where(w.UserID.Eq(userID), w.Lemma.Eq(lemma)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return entry, err
+}
+
+// DocumentOwned reports whether documentID exists and belongs to userID.
+func (r *Repository) DocumentOwned(ctx context.Context, userID, documentID int64) (bool, error) {
+	d := r.q.Document
+	n, err := d.WithContext(ctx).Where(d.ID.Eq(documentID), d.UserID.Eq(userID)).Count()
+	return n > 0, err
+}
+
+// DocumentWord returns the vocabulary row of lemma in documentID, or nil.
+func (r *Repository) DocumentWord(ctx context.Context, documentID int64, lemma string) (*model.DocumentVocabulary, error) {
+	v := r.q.DocumentVocabulary
+	word, err := v.WithContext(ctx).Where(v.DocumentID.Eq(documentID), v.Lemma.Eq(lemma)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return word, err
+}
+
+// Create adds entry together with the flashcard that reviews it, first due
+// at due.
+func (r *Repository) Create(ctx context.Context, entry *model.WordBank, due time.Time) error {
+	return r.q.Transaction(func(tx *query.Query) error {
+		if err := tx.WordBank.WithContext(ctx).Create(entry); err != nil {
+			return err
+		}
+		card := &model.Flashcard{UserID: entry.UserID, WordBankID: &entry.ID, DueAt: due}
+		return tx.Flashcard.WithContext(ctx).Create(card)
+	})
+}
+
+// SetNote replaces the note of entry id; a nil note clears it.
+func (r *Repository) SetNote(ctx context.Context, id int64, note *string) error {
+	w := r.q.WordBank
+	column := w.Note.Null()
+	if note != nil {
+		column = w.Note.Value(*note)
+	}
+	_, err := w.WithContext(ctx).Where(w.ID.Eq(id)).UpdateSimple(column)
+	return err
+}
+
+// SetKnown marks entry id known as of at, or unknown.
+func (r *Repository) SetKnown(ctx context.Context, id int64, known bool, at time.Time) error {
+	w := r.q.WordBank
+	knownAt := w.KnownAt.Null()
+	if known {
+		knownAt = w.KnownAt.Value(at)
+	}
+	_, err := w.WithContext(ctx).Where(w.ID.Eq(id)).UpdateSimple(w.Known.Value(known), knownAt)
+	return err
+}
+
+// FillExample sets the example of entry id to the sentence word was found
+// in, unless the entry already has one.
+func (r *Repository) FillExample(ctx context.Context, id int64, word *model.DocumentVocabulary) error {
+	w := r.q.WordBank
+	columns := []field.AssignExpr{w.Example.Value(word.Example), w.DocumentID.Value(word.DocumentID)}
+	if word.PageIndex != nil {
+		columns = append(columns, w.PageIndex.Value(*word.PageIndex))
+	}
+	_, err := w.WithContext(ctx).Where(w.ID.Eq(id), w.Example.IsNull()).UpdateSimple(columns...)
+	return err
+}
+
+func (r *Repository) Delete(ctx context.Context, id int64) error {
+	w := r.q.WordBank
+	_, err := w.WithContext(ctx).Where(w.ID.Eq(id)).Delete()
+	return err
+}
+
+// escapeLike escapes the LIKE wildcards in s.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,17 @@
+package wordbank
+
+import (
+	"ai-learn-english/internal/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// RegisterRoutes registers word bank routes on the provided router.
+func RegisterRoutes(r fiber.Router, h *Handler) {
+	grp := r.Group("/word-bank", middleware.RequireUser())
+
+	grp.Get("/", h.List)
+	grp.Post("/", h.Add)
+	grp.Patch("/:id", h.Update)
+	grp.Delete("/:id", h.Delete)
+}
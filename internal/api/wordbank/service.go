@@ -0,0 +1,226 @@
+package wordbank
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/srs"
+	"ai-learn-english/pkg/apperror"
+	"ai-learn-english/pkg/vocab"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+
+	// maxWordLength matches word_bank.lemma.
+	maxWordLength = 64
+)
+
+var (
+	ErrInvalidID        = apperror.New("invalid_id", "word bank entry id must be a positive integer")
+	ErrInvalidWord      = apperror.New("invalid_word", fmt.Sprintf("word must be an English word or phrase of at most %d characters", maxWordLength))
+	ErrInvalidLevel     = apperror.New("invalid_level", "level must be one of "+strings.Join(vocab.Levels, ", "))
+	ErrEntryNotFound    = apperror.New("entry_not_found", "word bank entry not found").WithStatus(http.StatusNotFound)
+	ErrDocumentNotFound = apperror.New("document_not_found", "document not found").WithStatus(http.StatusNotFound)
+)
+
+// Service manages the words a user saves to study.
+type Service struct {
+	repo *Repository
+	now  srs.Clock
+}
+
+func NewService(repo *Repository, clock srs.Clock) *Service {
+	return &Service{repo: repo, now: clock}
+}
+
+// List searches the user's word bank.
+func (s *Service) List(ctx context.Context, userID int64, req ListRequest) (*ListResponse, error) {
+	if req.Level != "" && !slices.Contains(vocab.Levels, req.Level) {
+		return nil, ErrInvalidLevel
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	limit = min(limit, maxLimit)
+
+	entries, total, err := s.repo.List(ctx, userID, req, max(req.Offset, 0), limit)
+	if err != nil {
+		return nil, fmt.Errorf("list word bank: %w", err)
+	}
+	return &ListResponse{Total: total, Items: entries}, nil
+}
+
+// Add saves a word to the user's word bank. Adding a word that is already
+// there returns the existing entry, with the note replaced and the example
+// filled in when they are given.
+func (s *Service) Add(ctx context.Context, userID int64, req AddRequest) (*AddResponse, error) {
+	lemma, err := normalize(req.Word)
+	if err != nil {
+		return nil, err
+	}
+	note := trimNote(req.Note)
+
+	var word *model.DocumentVocabulary
+	if req.DocumentID != nil {
+		owned, err := s.repo.DocumentOwned(ctx, userID, *req.DocumentID)
+		if err != nil {
+			return nil, fmt.Errorf("check document: %w", err)
+		}
+		if !owned {
+			return nil, ErrDocumentNotFound
+		}
+		if word, err = s.repo.DocumentWord(ctx, *req.DocumentID, lemma); err != nil {
+			return nil, fmt.Errorf("find word in document %d: %w", *req.DocumentID, err)
+		}
+	}
+
+	entry, err := s.repo.FindByLemma(ctx, userID, lemma)
+	if err != nil {
+		return nil, fmt.Errorf("find word bank entry: %w", err)
+	}
+	if entry != nil {
+		return s.merge(ctx, entry, req.Note != nil, note, word)
+	}
+
+	entry = &model.WordBank{UserID: userID, Lemma: lemma, Note: note}
+	if !strings.ContainsRune(lemma, ' ') {
+		band := int32(vocab.Band(lemma))
+		level := vocab.Level(lemma)
+		entry.FrequencyBand, entry.CefrLevel = &band, &level
+	}
+	if word != nil {
+		entry.Example = &word.Example
+		entry.DocumentID = &word.DocumentID
+		entry.PageIndex = word.PageIndex
+	}
+	if err := s.repo.Create(ctx, entry, s.now()); err != nil {
+		// A concurrent add of the same word won the race; update its row.
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			existing, findErr := s.repo.FindByLemma(ctx, userID, lemma)
+			if findErr == nil && existing != nil {
+				return s.merge(ctx, existing, req.Note != nil, note, word)
+			}
+		}
+		return nil, fmt.Errorf("add word bank entry: %w", err)
+	}
+	entry, err = s.get(ctx, userID, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &AddResponse{Entry: entry, Created: true}, nil
+}
+
+// merge applies an add of a word that is already in the word bank to its
+// entry: the note is replaced when setNote is true and the example filled
+// in from word when the entry has none.
+func (s *Service) merge(ctx context.Context, entry *model.WordBank, setNote bool, note *string, word *model.DocumentVocabulary) (*AddResponse, error) {
+	changed := false
+	if setNote {
+		if err := s.repo.SetNote(ctx, entry.ID, note); err != nil {
+			return nil, fmt.Errorf("update note of word bank entry %d: %w", entry.ID, err)
+		}
+		changed = true
+	}
+	if entry.Example == nil && word != nil {
+		if err := s.repo.FillExample(ctx, entry.ID, word); err != nil {
+			return nil, fmt.Errorf("fill example of word bank entry %d: %w", entry.ID, err)
+		}
+		changed = true
+	}
+	if changed {
+		var err error
+		if entry, err = s.get(ctx, entry.UserID, entry.ID); err != nil {
+			return nil, err
+		}
+	}
+	return &AddResponse{Entry: entry, Created: false}, nil
+}
+
+// Update changes the note of an entry or marks it known or unknown.
+func (s *Service) Update(ctx context.Context, userID, id int64, req UpdateRequest) (*model.WordBank, error) {
+	entry, err := s.get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := false
+	if req.Note != nil {
+		if err := s.repo.SetNote(ctx, id, trimNote(req.Note)); err != nil {
+			return nil, fmt.Errorf("update note of word bank entry %d: %w", id, err)
+		}
+		changed = true
+	}
+	if req.Known != nil && *req.Known != entry.Known {
+		if err := s.repo.SetKnown(ctx, id, *req.Known, s.now()); err != nil {
+			return nil, fmt.Errorf("mark word bank entry %d known: %w", id, err)
+		}
+		changed = true
+	}
+	if !changed {
+		return entry, nil
+	}
+	return s.get(ctx, userID, id)
+}
+
+// Delete removes an entry from the user's word bank.
+func (s *Service) Delete(ctx context.Context, userID, id int64) error {
+	if _, err := s.get(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete word bank entry %d: %w", id, err)
+	}
+	return nil
+}
+
+func (s *Service) get(ctx context.Context, userID, id int64) (*model.WordBank, error) {
+	entry, err := s.repo.FindOwned(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("find word bank entry %d: %w", id, err)
+	}
+	if entry == nil {
+		return nil, ErrEntryNotFound
+	}
+	return entry, nil
+}
+
+// normalize lowercases word and reduces a single word to its lemma.
+// Phrases such as "look forward to" are kept as they are.
+func normalize(word string) (string, error) {
+	word = strings.ToLower(strings.Join(strings.Fields(word), " "))
+	if word == "" || utf8.RuneCountInString(word) > maxWordLength {
+		return "", ErrInvalidWord
+	}
+	for _, r := range word {
+		if !unicode.IsLetter(r) && r != ' ' && r != '-' && r != '\'' {
+			return "", ErrInvalidWord
+		}
+	}
+	if strings.ContainsAny(word, " -'") {
+		return word, nil
+	}
+	return vocab.Lemma(word), nil
+}
+
+// trimNote returns nil for a missing or blank note.
+func trimNote(note *string) *string {
+	if note == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*note)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,41 @@
+package wordbank
+
+import "ai-learn-english/internal/database/model"
+
+// AddRequest is the body of POST /word-bank. Word is reduced to its lemma.
+// With DocumentID the example sentence and page are taken from that
+// document's vocabulary.
+type AddRequest struct {
+	Word       string  `json:"word"`
+	Note       *string `json:"note"`
+	DocumentID *int64  `json:"document_id"`
+}
+
+// UpdateRequest is the body of PATCH /word-bank/:id. Fields left out are not
+// changed; an empty note removes it.
+type UpdateRequest struct {
+	Note  *string `json:"note"`
+	Known *bool   `json:"known"`
+}
+
+// ListRequest holds the query string of GET /word-bank. Q matches the start
+// of the word or any part of the note.
+type ListRequest struct {
+	Q      string `query:"q"`
+	Known  *bool  `query:"known"`
+	Level  string `query:"level"`
+	Limit  int    `query:"limit"`
+	Offset int    `query:"offset"`
+}
+
+type ListResponse struct {
+	Total int64             `json:"total"`
+	Items []*model.WordBank `json:"items"`
+}
+
+// AddResponse is returned by POST /word-bank. Created is false when the word
+// was already in the bank.
+type AddResponse struct {
+	Entry   *model.WordBank `json:"entry"`
+	Created bool            `json:"created"`
+}
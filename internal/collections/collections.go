@@ -0,0 +1,276 @@
+// Package collections keeps track of which vector collection serves
+// retrieval. Each embedding model and dimension gets its own collection,
+// recorded in the embedding_collections table. Exactly one collection is
+// active; a new one is backfilled while the active one keeps serving and
+// then takes over in a single cut-over.
+package collections
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/internal/embedding"
+	"ai-learn-english/internal/vectorstore"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Collection states.
+const (
+	StateBuilding = "building"
+	StateActive   = "active"
+	StateRetired  = "retired"
+	StateDropped  = "dropped"
+)
+
+// activeTTL is how long a process keeps using the active collection it
+// looked up, so a cut-over reaches every API and worker process within it.
+const activeTTL = 30 * time.Second
+
+var (
+	ErrNotFound      = errors.New("embedding collection not found")
+	ErrNotBuilding   = errors.New("embedding collection is not being built")
+	ErrAlreadyActive = errors.New("configured embedding model is already the active collection")
+)
+
+// Collection is a registered collection with the embedder that fills it.
+type Collection struct {
+	*model.EmbeddingCollection
+	Embedder embedding.Embedder
+}
+
+// Manager resolves the active collection and runs backfills and cut-overs.
+type Manager struct {
+	q      *query.Query
+	store  vectorstore.VectorStore
+	cfg    config.EmbeddingConfig
+	openai config.OpenAIConfig
+	gemini config.GeminiConfig
+
+	mu        sync.Mutex
+	embedders map[string]embedding.Embedder
+	active    *Collection
+	loadedAt  time.Time
+}
+
+// NewManager builds a manager whose configured embedder, from cfg, is the
+// one new collections are built for.
+func NewManager(q *query.Query, store vectorstore.VectorStore, cfg config.EmbeddingConfig, openai config.OpenAIConfig, gemini config.GeminiConfig) *Manager {
+	return &Manager{
+		q:         q,
+		store:     store,
+		cfg:       cfg,
+		openai:    openai,
+		gemini:    gemini,
+		embedders: make(map[string]embedding.Embedder),
+	}
+}
+
+// Active returns the collection that serves retrieval and receives newly
+// ingested chunks. The first call on an empty registry registers the
+// configured embedder as active, which is how existing deployments adopt
+// the registry.
+func (m *Manager) Active(ctx context.Context) (*Collection, error) {
+	m.mu.Lock()
+	if m.active != nil && time.Since(m.loadedAt) < activeTTL {
+		c := m.active
+		m.mu.Unlock()
+		return c, nil
+	}
+	m.mu.Unlock()
+
+	row, err := m.activeRow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c, err := m.collection(row)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.active, m.loadedAt = c, time.Now()
+	m.mu.Unlock()
+	return c, nil
+}
+
+// forget drops the cached active collection.
+func (m *Manager) forget() {
+	m.mu.Lock()
+	m.active = nil
+	m.mu.Unlock()
+}
+
+func (m *Manager) activeRow(ctx context.Context) (*model.EmbeddingCollection, error) {
+	var row *model.EmbeddingCollection
+	err := m.q.Transaction(func(tx *query.Query) error {
+		ec := tx.EmbeddingCollection
+		active, err := ec.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where(ec.State.Eq(StateActive)).First()
+		if err == nil {
+			row = active
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		want, err := m.configured()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		existing, err := ec.WithContext(ctx).Where(ec.Name.Eq(want.Name)).First()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			want.State, want.ActivatedAt = StateActive, &now
+			row = want
+			return ec.WithContext(ctx).Create(want)
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := ec.WithContext(ctx).Where(ec.ID.Eq(existing.ID)).
+			UpdateSimple(ec.State.Value(StateActive), ec.ActivatedAt.Value(now)); err != nil {
+			return err
+		}
+		existing.State, existing.ActivatedAt = StateActive, &now
+		row = existing
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load active embedding collection: %w", err)
+	}
+	return row, nil
+}
+
+// configured returns an unsaved row describing the configured embedder.
+func (m *Manager) configured() (*model.EmbeddingCollection, error) {
+	provider := m.cfg.Provider
+	if provider == "" {
+		provider = "local"
+	}
+	row := &model.EmbeddingCollection{Provider: provider, Model: m.cfg.Model, Dimension: int32(m.cfg.Dimension)}
+	e, err := m.embedder(row)
+	if err != nil {
+		return nil, err
+	}
+	row.Name = embedding.CollectionName(e)
+	return row, nil
+}
+
+// collection pairs row with its embedder.
+func (m *Manager) collection(row *model.EmbeddingCollection) (*Collection, error) {
+	e, err := m.embedder(row)
+	if err != nil {
+		return nil, err
+	}
+	return &Collection{EmbeddingCollection: row, Embedder: e}, nil
+}
+
+// embedder returns the embedder for the provider, model and dimension
+// recorded in row, reusing the API keys of the provider configs.
+func (m *Manager) embedder(row *model.EmbeddingCollection) (embedding.Embedder, error) {
+	key := fmt.Sprintf("%s/%s/%d", row.Provider, row.Model, row.Dimension)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.embedders[key]; ok {
+		return e, nil
+	}
+	cfg := config.EmbeddingConfig{
+		Provider:  row.Provider,
+		Model:     row.Model,
+		Dimension: int(row.Dimension),
+		BatchSize: m.cfg.BatchSize,
+	}
+	e, err := embedding.New(cfg, m.openai, m.gemini)
+	if err != nil {
+		return nil, err
+	}
+	m.embedders[key] = e
+	return e, nil
+}
+
+// List returns every registered collection, newest first.
+func (m *Manager) List(ctx context.Context) ([]*model.EmbeddingCollection, error) {
+	ec := m.q.EmbeddingCollection
+	rows, err := ec.WithContext(ctx).Order(ec.ID.Desc()).Find()
+	if err != nil {
+		return nil, fmt.Errorf("list embedding collections: %w", err)
+	}
+	return rows, nil
+}
+
+// Get returns the collection registered under name.
+func (m *Manager) Get(ctx context.Context, name string) (*Collection, error) {
+	ec := m.q.EmbeddingCollection
+	row, err := ec.WithContext(ctx).Where(ec.Name.Eq(name)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load embedding collection %s: %w", name, err)
+	}
+	return m.collection(row)
+}
+
+// Prepare registers a building collection for the configured embedder and
+// creates it in the vector store. A retired or dropped collection for the
+// same model is built again.
+func (m *Manager) Prepare(ctx context.Context) (*Collection, error) {
+	if _, err := m.Active(ctx); err != nil {
+		return nil, err
+	}
+	want, err := m.configured()
+	if err != nil {
+		return nil, err
+	}
+
+	ec := m.q.EmbeddingCollection
+	existing, err := ec.WithContext(ctx).Where(ec.Name.Eq(want.Name)).First()
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		want.State = StateBuilding
+		if err := ec.WithContext(ctx).Create(want); err != nil {
+			return nil, fmt.Errorf("register embedding collection %s: %w", want.Name, err)
+		}
+	case err != nil:
+		return nil, fmt.Errorf("load embedding collection %s: %w", want.Name, err)
+	case existing.State == StateActive:
+		return nil, ErrAlreadyActive
+	default:
+		_, err := ec.WithContext(ctx).Where(ec.ID.Eq(existing.ID)).UpdateSimple(
+			ec.State.Value(StateBuilding),
+			ec.BackfillTotal.Zero(),
+			ec.BackfillDone.Zero(),
+			ec.RetiredAt.Null(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("rebuild embedding collection %s: %w", want.Name, err)
+		}
+		existing.State, existing.BackfillTotal, existing.BackfillDone, existing.RetiredAt = StateBuilding, 0, 0, nil
+		want = existing
+	}
+
+	c, err := m.collection(want)
+	if err != nil {
+		return nil, err
+	}
+	if err := embedding.EnsureCollection(ctx, m.store, c.Name, c.Embedder); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ActiveCollection returns the name and embedder of the active collection.
+func (m *Manager) ActiveCollection(ctx context.Context) (string, embedding.Embedder, error) {
+	c, err := m.Active(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	return c.Name, c.Embedder, nil
+}
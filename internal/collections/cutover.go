@@ -0,0 +1,131 @@
+package collections
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// Cutover makes the building collection name the active one. It catches up
+// on chunks ingested since the backfill, then in one transaction retires
+// the active collection, activates name and points every chunk row of the
+// old collection at the new one. A final sync picks up chunks written to
+// the old collection by processes that had not seen the switch yet.
+func (m *Manager) Cutover(ctx context.Context, name string) error {
+	c, err := m.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	if c.State != StateBuilding {
+		return fmt.Errorf("%w: %s is %s", ErrNotBuilding, name, c.State)
+	}
+	if err := m.Sync(ctx, c, false); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = m.q.Transaction(func(tx *query.Query) error {
+		ec := tx.EmbeddingCollection
+		rows, err := ec.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(ec.State.Eq(StateActive)).Or(ec.ID.Eq(c.ID)).Find()
+		if err != nil {
+			return err
+		}
+		var old *model.EmbeddingCollection
+		for _, row := range rows {
+			if row.ID == c.ID && row.State != StateBuilding {
+				return fmt.Errorf("%w: %s is %s", ErrNotBuilding, name, row.State)
+			}
+			if row.State == StateActive {
+				old = row
+			}
+		}
+
+		if old != nil {
+			if _, err := ec.WithContext(ctx).Where(ec.ID.Eq(old.ID)).
+				UpdateSimple(ec.State.Value(StateRetired), ec.RetiredAt.Value(now)); err != nil {
+				return err
+			}
+			ch := tx.Chunk
+			if _, err := ch.WithContext(ctx).Where(ch.MilvusCollection.Eq(old.Name)).
+				UpdateSimple(ch.MilvusCollection.Value(c.Name), ch.MilvusID.SetCol(ch.ID)); err != nil {
+				return err
+			}
+		}
+		_, err = ec.WithContext(ctx).Where(ec.ID.Eq(c.ID)).
+			UpdateSimple(ec.State.Value(StateActive), ec.ActivatedAt.Value(now))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("cut over to %s: %w", name, err)
+	}
+	m.forget()
+
+	c.State, c.ActivatedAt = StateActive, &now
+	return m.Sync(ctx, c, true)
+}
+
+// Cleanup drops retired collections from the vector store once every
+// process has had time to switch to the active one. Chunk rows still
+// pointing at a retired collection are moved to the active one first.
+// It returns the names of the dropped collections.
+func (m *Manager) Cleanup(ctx context.Context) ([]string, error) {
+	m.forget()
+	active, err := m.Active(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ec := m.q.EmbeddingCollection
+	retired, err := ec.WithContext(ctx).Where(ec.State.Eq(StateRetired)).Find()
+	if err != nil {
+		return nil, fmt.Errorf("list retired collections: %w", err)
+	}
+	var ready []*model.EmbeddingCollection
+	for _, row := range retired {
+		if sinceRetired(row) >= 2*activeTTL {
+			ready = append(ready, row)
+		}
+	}
+	if len(ready) == 0 {
+		return nil, nil
+	}
+
+	if err := m.Sync(ctx, active, true); err != nil {
+		return nil, err
+	}
+
+	var dropped []string
+	ch := m.q.Chunk
+	for _, row := range ready {
+		n, err := ch.WithContext(ctx).Where(ch.MilvusCollection.Eq(row.Name)).Count()
+		if err != nil {
+			return dropped, fmt.Errorf("count chunks in %s: %w", row.Name, err)
+		}
+		if n > 0 {
+			// Chunks of documents that are still being processed; their
+			// ingestion will move them to the active collection.
+			continue
+		}
+		if err := m.store.DropCollection(ctx, row.Name); err != nil {
+			return dropped, err
+		}
+		if _, err := ec.WithContext(ctx).Where(ec.ID.Eq(row.ID)).UpdateSimple(ec.State.Value(StateDropped)); err != nil {
+			return dropped, fmt.Errorf("mark %s dropped: %w", row.Name, err)
+		}
+		dropped = append(dropped, row.Name)
+	}
+	return dropped, nil
+}
+
+// sinceRetired is how long ago row was retired, or zero.
+func sinceRetired(row *model.EmbeddingCollection) time.Duration {
+	if row.RetiredAt == nil {
+		return 0
+	}
+	return time.Since(*row.RetiredAt)
+}
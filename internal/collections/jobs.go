@@ -0,0 +1,51 @@
+package collections
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/jobqueue"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// KindBackfill is the job that fills a building collection.
+const KindBackfill = "backfill_collection"
+
+type backfillPayload struct {
+	Name string `json:"name"`
+}
+
+// Backfill registers a building collection for the configured embedder and
+// queues the job that fills it.
+func (m *Manager) Backfill(ctx context.Context, queue *jobqueue.Queue) (*Collection, error) {
+	c, err := m.Prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := queue.Enqueue(ctx, KindBackfill, backfillPayload{Name: c.Name}); err != nil {
+		return nil, fmt.Errorf("queue backfill of %s: %w", c.Name, err)
+	}
+	return c, nil
+}
+
+// RegisterHandlers wires the backfill job into w. Backfills of collections
+// that were cut over or rebuilt in the meantime have nothing left to do.
+func RegisterHandlers(w *jobqueue.Worker, m *Manager) {
+	w.Handle(KindBackfill, func(ctx context.Context, job *model.Job) error {
+		var payload backfillPayload
+		if err := jobqueue.Decode(job, &payload); err != nil {
+			return jobqueue.Permanent(err)
+		}
+		c, err := m.Get(ctx, payload.Name)
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if c.State != StateBuilding {
+			return nil
+		}
+		return m.Sync(ctx, c, false)
+	})
+}
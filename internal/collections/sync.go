@@ -0,0 +1,165 @@
+package collections
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/embedding"
+	"ai-learn-english/internal/ingest"
+	"ai-learn-english/internal/vectorstore"
+	"context"
+	"fmt"
+
+	"gorm.io/gen"
+)
+
+// syncBatch is the number of chunks embedded per call and per progress
+// update.
+const syncBatch = 64
+
+// Sync embeds into c every chunk of a ready document that has no vector
+// there yet and records progress on the collection row. It resumes from
+// whatever the collection already holds, so an interrupted backfill can be
+// run again. While c is building, vectors of chunks that no longer exist
+// are removed. With repoint set, chunk rows are also switched to c, which
+// is only correct for the active collection.
+func (m *Manager) Sync(ctx context.Context, c *Collection, repoint bool) error {
+	if err := embedding.EnsureCollection(ctx, m.store, c.Name, c.Embedder); err != nil {
+		return err
+	}
+
+	present := make(map[int64]bool)
+	err := m.store.Scan(ctx, c.Name, func(batch []vectorstore.Record) error {
+		for _, rec := range batch {
+			present[rec.ID] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ch, d := m.q.Chunk, m.q.Document
+	wanted := make(map[int64]bool)
+	var todo, elsewhere []int64
+	var rows []*model.Chunk
+	err = ch.WithContext(ctx).
+		Select(ch.ID, ch.MilvusCollection).
+		Join(d, d.ID.EqCol(ch.DocumentID)).
+		Where(d.Status.Eq(ingest.StatusReady)).
+		FindInBatches(&rows, 1000, func(tx gen.Dao, batch int) error {
+			for _, row := range rows {
+				wanted[row.ID] = true
+				switch {
+				case !present[row.ID]:
+					todo = append(todo, row.ID)
+				case row.MilvusCollection != c.Name:
+					elsewhere = append(elsewhere, row.ID)
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return fmt.Errorf("load chunks for %s: %w", c.Name, err)
+	}
+
+	if c.State == StateBuilding {
+		var orphans []int64
+		for id := range present {
+			if !wanted[id] {
+				orphans = append(orphans, id)
+			}
+		}
+		if err := m.store.Delete(ctx, c.Name, orphans); err != nil {
+			return err
+		}
+	}
+
+	total, done := int32(len(wanted)), int32(len(wanted)-len(todo))
+	if err := m.progress(ctx, c, total, done); err != nil {
+		return err
+	}
+	for start := 0; start < len(todo); start += syncBatch {
+		ids := todo[start:min(start+syncBatch, len(todo))]
+		if err := m.embedChunks(ctx, c, ids, repoint); err != nil {
+			return err
+		}
+		done += int32(len(ids))
+		if err := m.progress(ctx, c, total, done); err != nil {
+			return err
+		}
+	}
+
+	if repoint && len(elsewhere) > 0 {
+		for start := 0; start < len(elsewhere); start += 1000 {
+			if err := m.repoint(ctx, c.Name, elsewhere[start:min(start+1000, len(elsewhere))]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// embedChunks embeds the chunks with ids into c. Chunks deleted since they
+// were listed are skipped.
+func (m *Manager) embedChunks(ctx context.Context, c *Collection, ids []int64, repoint bool) error {
+	ch, d := m.q.Chunk, m.q.Document
+	chunks, err := ch.WithContext(ctx).Where(ch.ID.In(ids...)).Find()
+	if err != nil {
+		return fmt.Errorf("load chunks: %w", err)
+	}
+	if len(chunks) == 0 {
+		return nil
+	}
+
+	docIDs := make([]int64, len(chunks))
+	texts := make([]string, len(chunks))
+	for i, row := range chunks {
+		docIDs[i] = row.DocumentID
+		texts[i] = row.Content
+	}
+	docs, err := d.WithContext(ctx).Select(d.ID, d.UserID).Where(d.ID.In(docIDs...)).Find()
+	if err != nil {
+		return fmt.Errorf("load documents: %w", err)
+	}
+	owners := make(map[int64]int64, len(docs))
+	for _, doc := range docs {
+		owners[doc.ID] = doc.UserID
+	}
+
+	vectors, err := c.Embedder.Embed(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("embed chunks for %s: %w", c.Name, err)
+	}
+	records := make([]vectorstore.Record, len(chunks))
+	found := make([]int64, len(chunks))
+	for i, row := range chunks {
+		records[i] = vectorstore.Record{ID: row.ID, UserID: owners[row.DocumentID], DocumentID: row.DocumentID, Vector: vectors[i]}
+		found[i] = row.ID
+	}
+	if err := m.store.Upsert(ctx, c.Name, records); err != nil {
+		return err
+	}
+	if repoint {
+		return m.repoint(ctx, c.Name, found)
+	}
+	return nil
+}
+
+// repoint records collection as the home of the chunks with ids.
+func (m *Manager) repoint(ctx context.Context, collection string, ids []int64) error {
+	ch := m.q.Chunk
+	_, err := ch.WithContext(ctx).Where(ch.ID.In(ids...)).UpdateSimple(ch.MilvusCollection.Value(collection), ch.MilvusID.SetCol(ch.ID))
+	if err != nil {
+		return fmt.Errorf("point chunks at %s: %w", collection, err)
+	}
+	return nil
+}
+
+func (m *Manager) progress(ctx context.Context, c *Collection, total, done int32) error {
+	ec := m.q.EmbeddingCollection
+	_, err := ec.WithContext(ctx).Where(ec.ID.Eq(c.ID)).UpdateSimple(ec.BackfillTotal.Value(total), ec.BackfillDone.Value(done))
+	if err != nil {
+		return fmt.Errorf("save progress of %s: %w", c.Name, err)
+	}
+	c.BackfillTotal, c.BackfillDone = total, done
+	return nil
+}
@@ -0,0 +1,20 @@
+package database
+
+import (
+	"ai-learn-english/internal/database/query"
+	"fmt"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// Init opens the MySQL connection described by dsn and binds the generated
+// query package to it.
+func Init(dsn string) (*gorm.DB, error) {
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{TranslateError: true})
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	query.SetDefault(db)
+	return db, nil
+}
@@ -0,0 +1,279 @@
+// Package migrate applies the versioned SQL migrations embedded in the
+// binary. Applied state is kept in the alembic_version table used by the
+// former Alembic toolchain, so existing databases are picked up as-is: the
+// table holds the revision of the last applied migration.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+const lockName = "ai_learn_english_migrate"
+
+var ErrLocked = errors.New("another migration runner holds the lock")
+
+// Migration is one versioned schema change. Files are named
+// <seq>_<revision>_<name>.up.sql and .down.sql; Revision is the value
+// stored in alembic_version.
+type Migration struct {
+	Seq      int
+	Revision string
+	Name     string
+	Up       string
+	Down     string
+}
+
+// Status describes a migration and whether it has been applied.
+type Status struct {
+	Migration
+	Applied bool
+}
+
+// Migrator runs migrations on a single connection that holds a MySQL
+// named lock, so concurrent runners cannot interleave.
+type Migrator struct {
+	db          *sql.DB
+	migrations  []Migration
+	lockTimeout time.Duration
+}
+
+func New(db *sql.DB) (*Migrator, error) {
+	migrations, err := Load(migrationFS)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations, lockTimeout: 10 * time.Second}, nil
+}
+
+// Load reads and orders the migrations in fsys.
+func Load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	bySeq := map[int]*Migration{}
+	for _, file := range files {
+		base := path.Base(file)
+		var up bool
+		switch {
+		case strings.HasSuffix(base, ".up.sql"):
+			up = true
+			base = strings.TrimSuffix(base, ".up.sql")
+		case strings.HasSuffix(base, ".down.sql"):
+			base = strings.TrimSuffix(base, ".down.sql")
+		default:
+			return nil, fmt.Errorf("migration %s: must end in .up.sql or .down.sql", file)
+		}
+		parts := strings.SplitN(base, "_", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("migration %s: name must be <seq>_<revision>_<name>", file)
+		}
+		seq, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: bad sequence: %w", file, err)
+		}
+		body, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := bySeq[seq]
+		if !ok {
+			m = &Migration{Seq: seq, Revision: parts[1], Name: parts[2]}
+			bySeq[seq] = m
+		} else if m.Revision != parts[1] {
+			return nil, fmt.Errorf("migration %s: sequence %d is used by revision %s", file, seq, m.Revision)
+		}
+		if up {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	out := make([]Migration, 0, len(bySeq))
+	for _, m := range bySeq {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", m.Seq, m.Revision)
+		}
+		out = append(out, *m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Seq < out[j].Seq })
+	for i, m := range out {
+		if m.Seq != i+1 {
+			return nil, fmt.Errorf("migration sequence has a gap before %d_%s", m.Seq, m.Revision)
+		}
+	}
+	return out, nil
+}
+
+// Up applies up to n pending migrations, or all of them when n <= 0.
+func (m *Migrator) Up(ctx context.Context, n int) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		current, err := m.current(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.migrations[current:] {
+			if n > 0 && len(applied) == n {
+				break
+			}
+			if err := m.apply(ctx, conn, mig.Up, mig.Revision); err != nil {
+				return fmt.Errorf("apply %d_%s_%s: %w", mig.Seq, mig.Revision, mig.Name, err)
+			}
+			applied = append(applied, mig)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the last n applied migrations, or one when n <= 0.
+func (m *Migrator) Down(ctx context.Context, n int) ([]Migration, error) {
+	if n <= 0 {
+		n = 1
+	}
+	var reverted []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		current, err := m.current(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := current - 1; i >= 0 && len(reverted) < n; i-- {
+			mig := m.migrations[i]
+			if mig.Down == "" {
+				return fmt.Errorf("migration %d_%s_%s cannot be reverted: no down script", mig.Seq, mig.Revision, mig.Name)
+			}
+			prev := ""
+			if i > 0 {
+				prev = m.migrations[i-1].Revision
+			}
+			if err := m.apply(ctx, conn, mig.Down, prev); err != nil {
+				return fmt.Errorf("revert %d_%s_%s: %w", mig.Seq, mig.Revision, mig.Name, err)
+			}
+			reverted = append(reverted, mig)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Redo reverts and re-applies the last applied migration.
+func (m *Migrator) Redo(ctx context.Context) (*Migration, error) {
+	reverted, err := m.Down(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(reverted) == 0 {
+		return nil, errors.New("no applied migration to redo")
+	}
+	if _, err := m.Up(ctx, 1); err != nil {
+		return nil, err
+	}
+	return &reverted[0], nil
+}
+
+// Status lists every migration and whether it is applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	current, err := m.current(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Status, len(m.migrations))
+	for i, mig := range m.migrations {
+		out[i] = Status{Migration: mig, Applied: i < current}
+	}
+	return out, nil
+}
+
+// current returns how many migrations are applied, based on the revision
+// stored in alembic_version.
+func (m *Migrator) current(ctx context.Context, conn *sql.Conn) (int, error) {
+	_, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS alembic_version (
+    version_num VARCHAR(32) NOT NULL,
+    CONSTRAINT alembic_version_pkc PRIMARY KEY (version_num)
+)`)
+	if err != nil {
+		return 0, fmt.Errorf("create alembic_version: %w", err)
+	}
+
+	var revision string
+	err = conn.QueryRowContext(ctx, "SELECT version_num FROM alembic_version").Scan(&revision)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read alembic_version: %w", err)
+	}
+	return position(m.migrations, revision)
+}
+
+// position returns how many of migrations are applied when revision is the
+// last applied one.
+func position(migrations []Migration, revision string) (int, error) {
+	for i, mig := range migrations {
+		if mig.Revision == revision {
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("database is at unknown revision %s", revision)
+}
+
+// apply runs the statements of script and records revision as the current
+// one. MySQL commits DDL implicitly, so a failing script can leave the
+// schema partially changed; the revision is only updated on success.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, script, revision string) error {
+	for _, stmt := range splitStatements(script) {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("%w\n%s", err, stmt)
+		}
+	}
+	if _, err := conn.ExecContext(ctx, "DELETE FROM alembic_version"); err != nil {
+		return err
+	}
+	if revision == "" {
+		return nil
+	}
+	_, err := conn.ExecContext(ctx, "INSERT INTO alembic_version (version_num) VALUES (?)", revision)
+	return err
+}
+
+// withLock runs fn on a connection holding the migration lock.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var got sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, int(m.lockTimeout.Seconds())).Scan(&got); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	if !got.Valid || got.Int64 != 1 {
+		return ErrLocked
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "SELECT RELEASE_LOCK(?)", lockName)
+
+	return fn(conn)
+}
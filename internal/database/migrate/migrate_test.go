@@ -0,0 +1,122 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func file(body string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(body)}
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/0002_bbb_add_index.up.sql":       file("CREATE INDEX ix ON t (a);"),
+		"migrations/0001_aaa_create_t.down.sql":      file("DROP TABLE t;"),
+		"migrations/0010_jjj_last.up.sql":            file("SELECT 10;"),
+		"migrations/0001_aaa_create_t.up.sql":        file("CREATE TABLE t (a INT);"),
+		"migrations/0002_bbb_add_index.down.sql":     file("DROP INDEX ix ON t;"),
+		"migrations/0003_ccc_no_down.up.sql":         file("SELECT 3;"),
+		"migrations/0004_ddd_x.up.sql":               file("SELECT 4;"),
+		"migrations/0005_eee_x.up.sql":               file("SELECT 5;"),
+		"migrations/0006_fff_x.up.sql":               file("SELECT 6;"),
+		"migrations/0007_ggg_x.up.sql":               file("SELECT 7;"),
+		"migrations/0008_hhh_x.up.sql":               file("SELECT 8;"),
+		"migrations/0009_iii_name_with_parts.up.sql": file("SELECT 9;"),
+		"other/ignored.txt":                          file("not a migration"),
+	}
+	got, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(got) != 10 {
+		t.Fatalf("got %d migrations, want 10", len(got))
+	}
+	for i, m := range got {
+		if m.Seq != i+1 {
+			t.Errorf("migration %d has Seq %d", i, m.Seq)
+		}
+	}
+	first := got[0]
+	if first.Revision != "aaa" || first.Name != "create_t" || first.Up != "CREATE TABLE t (a INT);" || first.Down != "DROP TABLE t;" {
+		t.Errorf("first migration = %+v", first)
+	}
+	if got[1].Down != "DROP INDEX ix ON t;" {
+		t.Errorf("second migration Down = %q", got[1].Down)
+	}
+	if got[2].Down != "" {
+		t.Errorf("migration without down script has Down %q", got[2].Down)
+	}
+	if got[8].Name != "name_with_parts" {
+		t.Errorf("Name = %q, want name_with_parts", got[8].Name)
+	}
+	if got[9].Revision != "jjj" {
+		t.Errorf("numeric order broken: last revision %q", got[9].Revision)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{"bad suffix", fstest.MapFS{"migrations/0001_aaa_x.sql": file("")}, "must end in"},
+		{"bad name", fstest.MapFS{"migrations/0001_aaa.up.sql": file("")}, "<seq>_<revision>_<name>"},
+		{"bad sequence", fstest.MapFS{"migrations/one_aaa_x.up.sql": file("")}, "bad sequence"},
+		{"revision mismatch", fstest.MapFS{
+			"migrations/0001_aaa_x.up.sql":   file("SELECT 1;"),
+			"migrations/0001_bbb_x.down.sql": file("SELECT 1;"),
+		}, "is used by revision"},
+		{"down only", fstest.MapFS{"migrations/0001_aaa_x.down.sql": file("SELECT 1;")}, "no up script"},
+		{"gap", fstest.MapFS{
+			"migrations/0001_aaa_x.up.sql": file("SELECT 1;"),
+			"migrations/0003_ccc_x.up.sql": file("SELECT 3;"),
+		}, "gap before 3_ccc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.fsys)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := Load(migrationFS)
+	if err != nil {
+		t.Fatalf("Load embedded migrations: %v", err)
+	}
+	revisions := map[string]bool{}
+	for _, m := range migrations {
+		if revisions[m.Revision] {
+			t.Errorf("revision %s is used twice", m.Revision)
+		}
+		revisions[m.Revision] = true
+		if len(m.Revision) > 32 {
+			t.Errorf("revision %s does not fit alembic_version", m.Revision)
+		}
+		if m.Down == "" {
+			t.Errorf("migration %d_%s has no down script", m.Seq, m.Revision)
+		}
+		if len(splitStatements(m.Up)) == 0 {
+			t.Errorf("migration %d_%s has no statements", m.Seq, m.Revision)
+		}
+	}
+}
+
+func TestPosition(t *testing.T) {
+	migrations := []Migration{{Seq: 1, Revision: "aaa"}, {Seq: 2, Revision: "bbb"}, {Seq: 3, Revision: "ccc"}}
+	for revision, want := range map[string]int{"aaa": 1, "bbb": 2, "ccc": 3} {
+		got, err := position(migrations, revision)
+		if err != nil || got != want {
+			t.Errorf("position(%q) = %d, %v; want %d", revision, got, err, want)
+		}
+	}
+	if _, err := position(migrations, "zzz"); err == nil {
+		t.Error("position accepted an unknown revision")
+	}
+}
@@ -0,0 +1,4 @@
+DROP TABLE messages;
+DROP TABLE chunks;
+DROP TABLE documents;
+DROP TABLE users;
@@ -0,0 +1,53 @@
+CREATE TABLE users (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    email VARCHAR(255) NOT NULL,
+    username VARCHAR(100) NULL,
+    password_hash VARCHAR(255) NULL,
+    created_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE (email),
+    UNIQUE (username)
+);
+
+CREATE TABLE documents (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    user_id BIGINT NOT NULL,
+    title VARCHAR(255) NULL,
+    original_filename VARCHAR(255) NULL,
+    file_path VARCHAR(500) NULL,
+    language VARCHAR(20) NULL DEFAULT 'en',
+    page_count INTEGER NULL,
+    sha256 VARCHAR(64) NULL,
+    uploaded_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    UNIQUE (sha256)
+);
+
+CREATE TABLE chunks (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    document_id BIGINT NOT NULL,
+    chunk_index INTEGER NOT NULL,
+    page_index INTEGER NULL,
+    content TEXT NOT NULL,
+    content_preview VARCHAR(512) NULL,
+    token_count INTEGER NULL,
+    milvus_collection VARCHAR(128) NOT NULL,
+    milvus_id BIGINT NOT NULL,
+    content_hash VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (document_id) REFERENCES documents (id) ON DELETE CASCADE
+);
+
+CREATE TABLE messages (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    user_id BIGINT NOT NULL,
+    role ENUM('user', 'assistant') NOT NULL,
+    content TEXT NOT NULL,
+    document_id BIGINT NULL,
+    created_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (document_id) REFERENCES documents (id) ON DELETE SET NULL,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+-- The composite key may be the index backing the user_id foreign key.
+ALTER TABLE documents ADD INDEX ix_documents_user_id (user_id);
+ALTER TABLE documents DROP INDEX uq_documents_user_sha256;
+ALTER TABLE documents ADD CONSTRAINT sha256 UNIQUE (sha256);
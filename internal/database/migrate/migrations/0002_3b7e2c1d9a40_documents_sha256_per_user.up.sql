@@ -0,0 +1,2 @@
+ALTER TABLE documents DROP INDEX sha256;
+ALTER TABLE documents ADD CONSTRAINT uq_documents_user_sha256 UNIQUE (user_id, sha256);
@@ -0,0 +1,17 @@
+CREATE TABLE jobs (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    kind VARCHAR(64) NOT NULL,
+    payload JSON NOT NULL,
+    state ENUM('queued', 'running', 'succeeded', 'failed', 'dead') NOT NULL DEFAULT 'queued',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 5,
+    run_at DATETIME NOT NULL,
+    locked_by VARCHAR(128) NULL,
+    lease_expires_at DATETIME NULL,
+    last_error TEXT NULL,
+    created_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX ix_jobs_state_run_at ON jobs (state, run_at);
@@ -0,0 +1,6 @@
+ALTER TABLE documents
+    DROP COLUMN status,
+    DROP COLUMN status_reason,
+    DROP COLUMN chunks_total,
+    DROP COLUMN chunks_embedded,
+    DROP COLUMN status_updated_at;
@@ -0,0 +1,14 @@
+ALTER TABLE documents
+    ADD COLUMN status ENUM('uploaded', 'extracting', 'chunking', 'embedding', 'ready', 'failed') NOT NULL DEFAULT 'uploaded',
+    ADD COLUMN status_reason TEXT NULL,
+    ADD COLUMN chunks_total INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN chunks_embedded INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN status_updated_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- Documents ingested before the status column existed were processed
+-- synchronously on upload, so every one that has pages is ready.
+UPDATE documents
+SET status = 'ready',
+    chunks_total = (SELECT COUNT(*) FROM chunks WHERE chunks.document_id = documents.id),
+    chunks_embedded = (SELECT COUNT(*) FROM chunks WHERE chunks.document_id = documents.id)
+WHERE page_count IS NOT NULL;
@@ -0,0 +1 @@
+DROP TABLE embedding_collections;
@@ -0,0 +1,15 @@
+CREATE TABLE embedding_collections (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    provider VARCHAR(32) NOT NULL,
+    model VARCHAR(255) NOT NULL,
+    dimension INTEGER NOT NULL,
+    state ENUM('building', 'active', 'retired', 'dropped') NOT NULL DEFAULT 'building',
+    backfill_total INTEGER NOT NULL DEFAULT 0,
+    backfill_done INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    activated_at DATETIME NULL,
+    retired_at DATETIME NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_embedding_collections_name (name)
+);
@@ -0,0 +1,7 @@
+ALTER TABLE messages DROP FOREIGN KEY fk_messages_conversation_id;
+
+DROP INDEX ix_messages_conversation_id_id ON messages;
+
+ALTER TABLE messages DROP COLUMN conversation_id;
+
+DROP TABLE conversations;
@@ -0,0 +1,34 @@
+CREATE TABLE conversations (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    user_id BIGINT NOT NULL,
+    title VARCHAR(255) NULL,
+    topic VARCHAR(255) NULL,
+    document_id BIGINT NULL,
+    archived_at DATETIME NULL,
+    created_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (document_id) REFERENCES documents (id) ON DELETE SET NULL,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX ix_conversations_user_id_updated_at ON conversations (user_id, updated_at);
+
+ALTER TABLE messages ADD COLUMN conversation_id BIGINT NULL;
+
+-- Every message written before threads existed goes into one conversation
+-- per user.
+INSERT INTO conversations (user_id, title, created_at, updated_at)
+SELECT user_id, 'Earlier messages', MIN(created_at), MAX(created_at)
+FROM messages
+GROUP BY user_id;
+
+UPDATE messages
+JOIN conversations ON conversations.user_id = messages.user_id
+SET messages.conversation_id = conversations.id;
+
+ALTER TABLE messages
+    MODIFY conversation_id BIGINT NOT NULL,
+    ADD CONSTRAINT fk_messages_conversation_id FOREIGN KEY (conversation_id) REFERENCES conversations (id) ON DELETE CASCADE;
+
+CREATE INDEX ix_messages_conversation_id_id ON messages (conversation_id, id);
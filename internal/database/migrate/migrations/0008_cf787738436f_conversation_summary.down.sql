@@ -0,0 +1,4 @@
+ALTER TABLE conversations
+    DROP COLUMN summary_updated_at,
+    DROP COLUMN summary_message_id,
+    DROP COLUMN summary;
@@ -0,0 +1,4 @@
+ALTER TABLE conversations
+    ADD COLUMN summary TEXT NULL,
+    ADD COLUMN summary_message_id BIGINT NULL,
+    ADD COLUMN summary_updated_at DATETIME NULL;
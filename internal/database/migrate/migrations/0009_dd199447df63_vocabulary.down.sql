@@ -0,0 +1,5 @@
+DROP TABLE word_bank;
+
+DROP TABLE document_vocabulary;
+
+ALTER TABLE documents DROP COLUMN vocabulary_at;
@@ -0,0 +1,41 @@
+ALTER TABLE documents ADD COLUMN vocabulary_at DATETIME NULL;
+
+CREATE TABLE document_vocabulary (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    document_id BIGINT NOT NULL,
+    lemma VARCHAR(64) NOT NULL,
+    form VARCHAR(64) NOT NULL,
+    occurrences INTEGER NOT NULL,
+    frequency_band TINYINT NOT NULL,
+    cefr_level ENUM('A1', 'A2', 'B1', 'B2', 'C1', 'C2') NOT NULL,
+    example TEXT NOT NULL,
+    chunk_id BIGINT NULL,
+    page_index INTEGER NULL,
+    created_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_document_vocabulary_document_id_lemma (document_id, lemma),
+    FOREIGN KEY (document_id) REFERENCES documents (id) ON DELETE CASCADE,
+    FOREIGN KEY (chunk_id) REFERENCES chunks (id) ON DELETE SET NULL
+);
+
+CREATE INDEX ix_document_vocabulary_document_id_cefr_level ON document_vocabulary (document_id, cefr_level);
+
+CREATE TABLE word_bank (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    user_id BIGINT NOT NULL,
+    lemma VARCHAR(64) NOT NULL,
+    note TEXT NULL,
+    known BOOLEAN NOT NULL DEFAULT FALSE,
+    known_at DATETIME NULL,
+    frequency_band TINYINT NULL,
+    cefr_level ENUM('A1', 'A2', 'B1', 'B2', 'C1', 'C2') NULL,
+    example TEXT NULL,
+    document_id BIGINT NULL,
+    page_index INTEGER NULL,
+    created_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_word_bank_user_id_lemma (user_id, lemma),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (document_id) REFERENCES documents (id) ON DELETE SET NULL
+);
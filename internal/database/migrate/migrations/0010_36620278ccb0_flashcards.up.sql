@@ -0,0 +1,47 @@
+CREATE TABLE flashcards (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    user_id BIGINT NOT NULL,
+    word_bank_id BIGINT NULL,
+    chunk_id BIGINT NULL,
+    front TEXT NULL,
+    back TEXT NULL,
+    algorithm VARCHAR(16) NULL,
+    reps INTEGER NOT NULL DEFAULT 0,
+    lapses INTEGER NOT NULL DEFAULT 0,
+    ease DOUBLE NOT NULL DEFAULT 2.5,
+    interval_days INTEGER NOT NULL DEFAULT 0,
+    stability DOUBLE NOT NULL DEFAULT 0,
+    difficulty DOUBLE NOT NULL DEFAULT 0,
+    due_at DATETIME NOT NULL,
+    last_reviewed_at DATETIME NULL,
+    created_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_flashcards_word_bank_id (word_bank_id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (word_bank_id) REFERENCES word_bank (id) ON DELETE CASCADE,
+    FOREIGN KEY (chunk_id) REFERENCES chunks (id) ON DELETE SET NULL
+);
+
+CREATE INDEX ix_flashcards_user_id_due_at ON flashcards (user_id, due_at);
+
+CREATE TABLE reviews (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    flashcard_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    grade TINYINT NOT NULL,
+    algorithm VARCHAR(16) NOT NULL,
+    elapsed_days DOUBLE NOT NULL,
+    interval_days INTEGER NOT NULL,
+    reviewed_at DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (flashcard_id) REFERENCES flashcards (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX ix_reviews_user_id_reviewed_at ON reviews (user_id, reviewed_at);
+
+-- Words saved before flashcards existed are due right away.
+INSERT INTO flashcards (user_id, word_bank_id, due_at)
+SELECT user_id, id, NOW()
+FROM word_bank;
@@ -0,0 +1,7 @@
+DROP TABLE learner_profiles;
+
+DROP TABLE placement_answers;
+
+DROP TABLE placement_sessions;
+
+DROP TABLE placement_items;
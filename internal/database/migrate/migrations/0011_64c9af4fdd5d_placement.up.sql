@@ -0,0 +1,115 @@
+CREATE TABLE placement_items (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    skill VARCHAR(16) NOT NULL,
+    cefr_level VARCHAR(2) NOT NULL,
+    passage TEXT NULL,
+    prompt TEXT NOT NULL,
+    options TEXT NOT NULL,
+    answer TINYINT NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX ix_placement_items_cefr_level ON placement_items (cefr_level);
+
+CREATE TABLE placement_sessions (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    user_id BIGINT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'in_progress',
+    ability DOUBLE NOT NULL,
+    step DOUBLE NOT NULL,
+    last_correct BOOLEAN NULL,
+    answered INTEGER NOT NULL DEFAULT 0,
+    correct INTEGER NOT NULL DEFAULT 0,
+    current_item_id BIGINT NULL,
+    cefr_level VARCHAR(2) NULL,
+    started_at DATETIME NOT NULL,
+    finished_at DATETIME NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (current_item_id) REFERENCES placement_items (id) ON DELETE SET NULL
+);
+
+CREATE INDEX ix_placement_sessions_user_id_status ON placement_sessions (user_id, status);
+
+CREATE TABLE placement_answers (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    session_id BIGINT NOT NULL,
+    item_id BIGINT NOT NULL,
+    choice TINYINT NOT NULL,
+    correct BOOLEAN NOT NULL,
+    ability DOUBLE NOT NULL,
+    answered_at DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_placement_answers_session_id_item_id (session_id, item_id),
+    FOREIGN KEY (session_id) REFERENCES placement_sessions (id) ON DELETE CASCADE,
+    FOREIGN KEY (item_id) REFERENCES placement_items (id) ON DELETE CASCADE
+);
+
+CREATE TABLE learner_profiles (
+    user_id BIGINT NOT NULL,
+    cefr_level VARCHAR(2) NULL,
+    placement_session_id BIGINT NULL,
+    created_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (placement_session_id) REFERENCES placement_sessions (id) ON DELETE SET NULL
+);
+
+-- Starter item bank. answer is the 0-based index into options.
+INSERT INTO placement_items (skill, cefr_level, passage, prompt, options, answer) VALUES
+('grammar', 'A1', NULL, 'She ___ a teacher.', '["is", "are", "am", "be"]', 0),
+('grammar', 'A1', NULL, 'I ___ coffee every morning.', '["drinks", "drink", "drinking", "am drink"]', 1),
+('grammar', 'A1', NULL, '___ you like pizza?', '["Are", "Is", "Do", "Does"]', 2),
+('vocabulary', 'A1', NULL, 'The opposite of "hot" is ___.', '["big", "cold", "old", "fast"]', 1),
+('vocabulary', 'A1', NULL, 'You sleep in a ___.', '["kitchen", "bed", "car", "shop"]', 1),
+('vocabulary', 'A1', NULL, 'Monday, Tuesday, ___, Thursday.', '["Sunday", "Friday", "Wednesday", "Saturday"]', 2),
+('reading', 'A1', 'My name is Lan. I am 20 years old. I live in Hanoi with my parents and my brother.', 'Who does Lan live with?', '["Her friends", "Her family", "Alone", "Her teacher"]', 1),
+('reading', 'A1', 'The shop opens at 8 a.m. and closes at 6 p.m. It is closed on Sundays.', 'When is the shop closed?', '["On Mondays", "At 8 a.m.", "On Sundays", "Every evening at 5 p.m."]', 2),
+
+('grammar', 'A2', NULL, 'Yesterday we ___ to the beach.', '["go", "goes", "went", "have gone"]', 2),
+('grammar', 'A2', NULL, 'This book is ___ than that one.', '["more interesting", "interestinger", "most interesting", "as interesting"]', 0),
+('grammar', 'A2', NULL, 'Look! It ___ outside.', '["rains", "is raining", "rained", "rain"]', 1),
+('vocabulary', 'A2', NULL, 'I need to ___ a table at the restaurant for tonight.', '["book", "take", "write", "pay"]', 0),
+('vocabulary', 'A2', NULL, 'He was late because he ___ the bus.', '["lost", "missed", "forgot", "left"]', 1),
+('vocabulary', 'A2', NULL, 'Can you ___ me your pen? I will give it back.', '["borrow", "lend", "take", "keep"]', 1),
+('reading', 'A2', 'Dear Tom, I am sorry I could not come to your party on Saturday. I had a bad cold and stayed in bed all weekend. Let''s meet next week. Anna', 'Why did Anna miss the party?', '["She was busy at work", "She was ill", "She forgot about it", "She was travelling"]', 1),
+('reading', 'A2', 'Train tickets are cheaper if you buy them online at least three days before you travel.', 'How can you get a cheaper ticket?', '["Buy it at the station", "Buy it online early", "Travel on Saturday", "Buy it on the train"]', 1),
+
+('grammar', 'B1', NULL, 'I ___ here since 2019.', '["live", "am living", "have lived", "lived"]', 2),
+('grammar', 'B1', NULL, 'If it rains tomorrow, we ___ at home.', '["stay", "will stay", "would stay", "stayed"]', 1),
+('grammar', 'B1', NULL, 'The letter ___ by my grandmother many years ago.', '["wrote", "was written", "has written", "is writing"]', 1),
+('vocabulary', 'B1', NULL, 'We had to ___ the meeting because the manager was sick.', '["call off", "call up", "carry on", "look after"]', 0),
+('vocabulary', 'B1', NULL, 'She is very ___; she always tells the truth.', '["honest", "jealous", "curious", "generous"]', 0),
+('vocabulary', 'B1', NULL, 'The company wants to ___ its costs by 10%.', '["reduce", "remove", "refuse", "repeat"]', 0),
+('reading', 'B1', 'Many people believe that working from home saves time. However, a recent survey found that remote workers often work longer hours, because the line between work and free time becomes unclear.', 'According to the survey, remote workers often ___.', '["save a lot of time", "work more hours", "take longer breaks", "prefer the office"]', 1),
+('reading', 'B1', 'The museum is free for students on weekdays. At weekends, everyone pays the full price, but children under six can always enter for free.', 'Who can visit for free on Saturday?', '["Students", "Everyone", "Children under six", "Nobody"]', 2),
+
+('grammar', 'B2', NULL, 'By the time we arrived, the film ___.', '["already started", "has already started", "had already started", "was already starting"]', 2),
+('grammar', 'B2', NULL, 'I wish I ___ more time to travel last year.', '["have had", "had had", "would have", "had"]', 1),
+('grammar', 'B2', NULL, 'He denied ___ the window.', '["to break", "breaking", "break", "to have break"]', 1),
+('vocabulary', 'B2', NULL, 'The new policy had a significant ___ on small businesses.', '["affect", "impact", "result", "cause"]', 1),
+('vocabulary', 'B2', NULL, 'Despite the bad weather, the event was a great ___.', '["success", "achievement", "victory", "progress"]', 0),
+('vocabulary', 'B2', NULL, 'The evidence was not strong enough to ___ his claim.', '["support", "hold", "carry", "raise"]', 0),
+('reading', 'B2', 'Although electric cars produce no exhaust, their overall environmental benefit depends on how the electricity is generated. In regions that rely heavily on coal, the advantage over petrol cars is considerably smaller.', 'What does the text suggest?', '["Electric cars are always cleaner", "The benefit of electric cars varies by region", "Coal is better than petrol", "Petrol cars produce no exhaust"]', 1),
+('reading', 'B2', 'The author admits that the plan is expensive, but argues that the long-term savings on healthcare will more than cover the initial cost.', 'The author thinks the plan is ___.', '["too expensive to be worthwhile", "worth the cost in the long run", "only useful in the short term", "unrelated to healthcare"]', 1),
+
+('grammar', 'C1', NULL, 'Not only ___ late, but he also forgot the documents.', '["he arrived", "did he arrive", "he did arrive", "arrived he"]', 1),
+('grammar', 'C1', NULL, 'Had I known about the problem, I ___ earlier.', '["would act", "will have acted", "would have acted", "had acted"]', 2),
+('grammar', 'C1', NULL, 'It is high time the government ___ action on housing.', '["takes", "took", "will take", "has taken"]', 1),
+('vocabulary', 'C1', NULL, 'The results were ___, so the researchers repeated the experiment.', '["inconclusive", "inconsistent with", "indispensable", "incoherently"]', 0),
+('vocabulary', 'C1', NULL, 'Her speech ___ a heated debate among the delegates.', '["sparked", "lit", "burned", "fired up"]', 0),
+('vocabulary', 'C1', NULL, 'The minister tried to ___ responsibility for the failure onto his staff.', '["shift", "move", "change", "transfer to"]', 0),
+('reading', 'C1', 'Critics of the reform contend that, far from reducing bureaucracy, it merely relocates it: decisions once made by a central office are now subject to approval by several regional committees.', 'What is the critics'' main point?', '["The reform removed bureaucracy", "The reform moved bureaucracy elsewhere", "Regional committees are faster", "The central office was closed for good reasons"]', 1),
+('reading', 'C1', 'While the novel''s plot is admittedly thin, its strength lies in the precision with which it captures the quiet desperation of its characters.', 'The reviewer considers the novel''s main strength to be its ___.', '["complex plot", "portrayal of characters", "fast pace", "humour"]', 1),
+
+('grammar', 'C2', NULL, 'Little ___ that the decision would change the course of his career.', '["he realised", "did he realise", "he did realise", "realised he"]', 1),
+('grammar', 'C2', NULL, 'Were the proposal ___, the company would need to restructure entirely.', '["to accept", "accepting", "to be accepted", "be accepted"]', 2),
+('grammar', 'C2', NULL, 'So ___ was the noise that nobody could sleep.', '["loud", "loudly", "louder", "the loud"]', 0),
+('vocabulary', 'C2', NULL, 'His argument was so ___ that even his opponents found it hard to dismiss.', '["cogent", "cordial", "candid", "callous"]', 0),
+('vocabulary', 'C2', NULL, 'The politician''s ___ remarks offended many voters.', '["disparaging", "dispassionate", "disproportionate", "disinterested"]', 0),
+('vocabulary', 'C2', NULL, 'The data ___ the hypothesis that diet affects sleep quality.', '["bear out", "bear with", "bear down", "bear up"]', 0),
+('reading', 'C2', 'The essay''s ostensible subject is urban gardening, yet its real preoccupation is the erosion of communal life, of which the neglected allotment serves as a quietly eloquent emblem.', 'What is the essay really about?', '["Techniques for urban gardening", "The decline of community life", "The cost of allotments", "City planning regulations"]', 1),
+('reading', 'C2', 'To dismiss the findings as mere coincidence would be disingenuous; equally, to treat them as conclusive would be to overstate what a single, modest study can establish.', 'The writer''s attitude towards the findings is ___.', '["entirely dismissive", "uncritically enthusiastic", "cautiously balanced", "openly hostile"]', 2);
@@ -0,0 +1,5 @@
+ALTER TABLE learner_profiles
+    DROP COLUMN interests,
+    DROP COLUMN goals,
+    DROP COLUMN explanation_language,
+    DROP COLUMN native_language;
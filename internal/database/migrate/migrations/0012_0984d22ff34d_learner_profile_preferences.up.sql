@@ -0,0 +1,5 @@
+ALTER TABLE learner_profiles
+    ADD COLUMN native_language VARCHAR(8) NULL,
+    ADD COLUMN explanation_language VARCHAR(8) NULL,
+    ADD COLUMN goals TEXT NULL,
+    ADD COLUMN interests TEXT NULL;
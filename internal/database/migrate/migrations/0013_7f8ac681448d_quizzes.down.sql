@@ -0,0 +1,7 @@
+DROP TABLE quiz_answers;
+
+DROP TABLE quiz_attempts;
+
+DROP TABLE quiz_questions;
+
+DROP TABLE quizzes;
@@ -0,0 +1,62 @@
+CREATE TABLE quizzes (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    user_id BIGINT NOT NULL,
+    document_id BIGINT NOT NULL,
+    question_count INTEGER NOT NULL,
+    model VARCHAR(128) NULL,
+    created_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (document_id) REFERENCES documents (id) ON DELETE CASCADE
+);
+
+CREATE INDEX ix_quizzes_document_id ON quizzes (document_id);
+
+CREATE TABLE quiz_questions (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    quiz_id BIGINT NOT NULL,
+    position INTEGER NOT NULL,
+    type VARCHAR(16) NOT NULL,
+    chunk_id BIGINT NULL,
+    page_index INTEGER NULL,
+    prompt TEXT NOT NULL,
+    options TEXT NULL,
+    correct_option TINYINT NULL,
+    correct_bool BOOLEAN NULL,
+    reference_answer TEXT NULL,
+    rubric TEXT NULL,
+    explanation TEXT NOT NULL,
+    evidence TEXT NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_quiz_questions_quiz_id_position (quiz_id, position),
+    FOREIGN KEY (quiz_id) REFERENCES quizzes (id) ON DELETE CASCADE,
+    FOREIGN KEY (chunk_id) REFERENCES chunks (id) ON DELETE SET NULL
+);
+
+CREATE TABLE quiz_attempts (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    quiz_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    score DOUBLE NOT NULL,
+    max_score DOUBLE NOT NULL,
+    submitted_at DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (quiz_id) REFERENCES quizzes (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX ix_quiz_attempts_quiz_id ON quiz_attempts (quiz_id);
+
+CREATE TABLE quiz_answers (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    attempt_id BIGINT NOT NULL,
+    question_id BIGINT NOT NULL,
+    response TEXT NULL,
+    score DOUBLE NOT NULL,
+    correct BOOLEAN NOT NULL,
+    feedback TEXT NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_quiz_answers_attempt_id_question_id (attempt_id, question_id),
+    FOREIGN KEY (attempt_id) REFERENCES quiz_attempts (id) ON DELETE CASCADE,
+    FOREIGN KEY (question_id) REFERENCES quiz_questions (id) ON DELETE CASCADE
+);
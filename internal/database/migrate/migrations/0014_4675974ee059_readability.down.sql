@@ -0,0 +1,15 @@
+ALTER TABLE chunks
+    DROP COLUMN cefr_level,
+    DROP COLUMN rare_word_ratio,
+    DROP COLUMN avg_sentence_length,
+    DROP COLUMN dale_chall_score,
+    DROP COLUMN flesch_kincaid_grade;
+
+DROP INDEX ix_documents_user_id_cefr_level ON documents;
+
+ALTER TABLE documents
+    DROP COLUMN cefr_level,
+    DROP COLUMN rare_word_ratio,
+    DROP COLUMN avg_sentence_length,
+    DROP COLUMN dale_chall_score,
+    DROP COLUMN flesch_kincaid_grade;
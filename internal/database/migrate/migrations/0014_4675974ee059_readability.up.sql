@@ -0,0 +1,15 @@
+ALTER TABLE documents
+    ADD COLUMN flesch_kincaid_grade DOUBLE NULL,
+    ADD COLUMN dale_chall_score DOUBLE NULL,
+    ADD COLUMN avg_sentence_length DOUBLE NULL,
+    ADD COLUMN rare_word_ratio DOUBLE NULL,
+    ADD COLUMN cefr_level VARCHAR(2) NULL;
+
+CREATE INDEX ix_documents_user_id_cefr_level ON documents (user_id, cefr_level);
+
+ALTER TABLE chunks
+    ADD COLUMN flesch_kincaid_grade DOUBLE NULL,
+    ADD COLUMN dale_chall_score DOUBLE NULL,
+    ADD COLUMN avg_sentence_length DOUBLE NULL,
+    ADD COLUMN rare_word_ratio DOUBLE NULL,
+    ADD COLUMN cefr_level VARCHAR(2) NULL;
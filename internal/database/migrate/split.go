@@ -0,0 +1,54 @@
+package migrate
+
+import "strings"
+
+// splitStatements splits a SQL script on semicolons that are outside of
+// quotes and comments. Comments are removed and comment-only statements
+// are dropped.
+func splitStatements(script string) []string {
+	var (
+		out   []string
+		cur   strings.Builder
+		quote rune
+	)
+	runes := []rune(script)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			cur.WriteRune(r)
+			if r == '\\' && i+1 < len(runes) {
+				i++
+				cur.WriteRune(runes[i])
+			} else if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+			cur.WriteRune(r)
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-', r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			cur.WriteRune('\n')
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i < len(runes) && (runes[i] != '*' || i+1 >= len(runes) || runes[i+1] != '/') {
+				i++
+			}
+			i++
+			cur.WriteRune(' ')
+		case r == ';':
+			if s := strings.TrimSpace(cur.String()); s != "" {
+				out = append(out, s)
+			}
+			cur.Reset()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if s := strings.TrimSpace(cur.String()); s != "" {
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+package migrate
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{"empty", "", nil},
+		{"single without semicolon", "SELECT 1", []string{"SELECT 1"}},
+		{"two statements", "CREATE TABLE a (id INT);\nDROP TABLE b;\n", []string{"CREATE TABLE a (id INT)", "DROP TABLE b"}},
+		{"blank statements", ";;  ;\nSELECT 1;;", []string{"SELECT 1"}},
+		{"semicolon in single quotes", "INSERT INTO t VALUES ('a;b');SELECT 2", []string{"INSERT INTO t VALUES ('a;b')", "SELECT 2"}},
+		{"semicolon in double quotes", `INSERT INTO t VALUES ("a;b");`, []string{`INSERT INTO t VALUES ("a;b")`}},
+		{"semicolon in backticks", "SELECT `a;b` FROM t;", []string{"SELECT `a;b` FROM t"}},
+		{"escaped quote", `INSERT INTO t VALUES ('it\'s; fine');`, []string{`INSERT INTO t VALUES ('it\'s; fine')`}},
+		{"doubled quote", "INSERT INTO t VALUES ('it''s; fine');", []string{"INSERT INTO t VALUES ('it''s; fine')"}},
+		{"dash comment", "-- drop it; later\nDROP TABLE a;", []string{"DROP TABLE a"}},
+		{"hash comment", "SELECT 1; # trailing; comment\nSELECT 2;", []string{"SELECT 1", "SELECT 2"}},
+		{"comment only", "-- nothing to do;\n# still nothing\n", nil},
+		{"block comment", "/* one; two */ SELECT 1;\nSELECT /* inline; */ 2;", []string{"SELECT 1", "SELECT   2"}},
+		{"comment marker in quotes", "INSERT INTO t VALUES ('-- not; a comment', '#x', '/* y */');", []string{"INSERT INTO t VALUES ('-- not; a comment', '#x', '/* y */')"}},
+		{"quote in comment", "-- don't\nSELECT 1;", []string{"SELECT 1"}},
+		{"unicode", "INSERT INTO t VALUES ('tiếng Việt; ok');", []string{"INSERT INTO t VALUES ('tiếng Việt; ok')"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitStatements(tt.script); !slices.Equal(got, tt.want) {
+				t.Errorf("splitStatements(%q)\ngot  %q\nwant %q", tt.script, got, tt.want)
+			}
+		})
+	}
+}
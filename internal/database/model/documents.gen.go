@@ -12,15 +12,26 @@ const TableNameDocument = "documents"
 
 // Document mapped from table <documents>
 type Document struct {
-	ID               int64      `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
-	UserID           int64      `gorm:"column:user_id;not null" json:"user_id"`
-	Title            *string    `gorm:"column:title" json:"title"`
-	OriginalFilename *string    `gorm:"column:original_filename" json:"original_filename"`
-	FilePath         *string    `gorm:"column:file_path" json:"file_path"`
-	Language         *string    `gorm:"column:language;default:en" json:"language"`
-	PageCount        *int32     `gorm:"column:page_count" json:"page_count"`
-	Sha256           *string    `gorm:"column:sha256" json:"sha256"`
-	UploadedAt       *time.Time `gorm:"column:uploaded_at;default:CURRENT_TIMESTAMP" json:"uploaded_at"`
+	ID                 int64      `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	UserID             int64      `gorm:"column:user_id;not null" json:"user_id"`
+	Title              *string    `gorm:"column:title" json:"title"`
+	OriginalFilename   *string    `gorm:"column:original_filename" json:"original_filename"`
+	FilePath           *string    `gorm:"column:file_path" json:"file_path"`
+	Language           *string    `gorm:"column:language;default:en" json:"language"`
+	PageCount          *int32     `gorm:"column:page_count" json:"page_count"`
+	Sha256             *string    `gorm:"column:sha256" json:"sha256"`
+	UploadedAt         *time.Time `gorm:"column:uploaded_at;default:CURRENT_TIMESTAMP" json:"uploaded_at"`
+	Status             string     `gorm:"column:status;not null;default:uploaded" json:"status"`
+	StatusReason       *string    `gorm:"column:status_reason" json:"status_reason"`
+	ChunksTotal        int32      `gorm:"column:chunks_total;not null" json:"chunks_total"`
+	ChunksEmbedded     int32      `gorm:"column:chunks_embedded;not null" json:"chunks_embedded"`
+	StatusUpdatedAt    *time.Time `gorm:"column:status_updated_at;default:CURRENT_TIMESTAMP" json:"status_updated_at"`
+	VocabularyAt       *time.Time `gorm:"column:vocabulary_at" json:"vocabulary_at"`
+	FleschKincaidGrade *float64   `gorm:"column:flesch_kincaid_grade" json:"flesch_kincaid_grade"`
+	DaleChallScore     *float64   `gorm:"column:dale_chall_score" json:"dale_chall_score"`
+	AvgSentenceLength  *float64   `gorm:"column:avg_sentence_length" json:"avg_sentence_length"`
+	RareWordRatio      *float64   `gorm:"column:rare_word_ratio" json:"rare_word_ratio"`
+	CefrLevel          *string    `gorm:"column:cefr_level" json:"cefr_level"`
 }
 
 // TableName Document's table name
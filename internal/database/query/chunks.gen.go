@@ -39,6 +39,11 @@ func newChunk(db *gorm.DB, opts ...gen.DOOption) chunk {
 	_chunk.MilvusID = field.NewInt64(tableName, "milvus_id")
 	_chunk.ContentHash = field.NewString(tableName, "content_hash")
 	_chunk.CreatedAt = field.NewTime(tableName, "created_at")
+	_chunk.FleschKincaidGrade = field.NewFloat64(tableName, "flesch_kincaid_grade")
+	_chunk.DaleChallScore = field.NewFloat64(tableName, "dale_chall_score")
+	_chunk.AvgSentenceLength = field.NewFloat64(tableName, "avg_sentence_length")
+	_chunk.RareWordRatio = field.NewFloat64(tableName, "rare_word_ratio")
+	_chunk.CefrLevel = field.NewString(tableName, "cefr_level")
 
 	_chunk.fillFieldMap()
 
@@ -48,18 +53,23 @@ func newChunk(db *gorm.DB, opts ...gen.DOOption) chunk {
 type chunk struct {
 	chunkDo chunkDo
 
-	ALL              field.Asterisk
-	ID               field.Int64
-	DocumentID       field.Int64
-	ChunkIndex       field.Int32
-	PageIndex        field.Int32
-	Content          field.String
-	ContentPreview   field.String
-	TokenCount       field.Int32
-	MilvusCollection field.String
-	MilvusID         field.Int64
-	ContentHash      field.String
-	CreatedAt        field.Time
+	ALL                field.Asterisk
+	ID                 field.Int64
+	DocumentID         field.Int64
+	ChunkIndex         field.Int32
+	PageIndex          field.Int32
+	Content            field.String
+	ContentPreview     field.String
+	TokenCount         field.Int32
+	MilvusCollection   field.String
+	MilvusID           field.Int64
+	ContentHash        field.String
+	CreatedAt          field.Time
+	FleschKincaidGrade field.Float64
+	DaleChallScore     field.Float64
+	AvgSentenceLength  field.Float64
+	RareWordRatio      field.Float64
+	CefrLevel          field.String
 
 	fieldMap map[string]field.Expr
 }
@@ -87,6 +97,11 @@ func (c *chunk) updateTableName(table string) *chunk {
 	c.MilvusID = field.NewInt64(table, "milvus_id")
 	c.ContentHash = field.NewString(table, "content_hash")
 	c.CreatedAt = field.NewTime(table, "created_at")
+	c.FleschKincaidGrade = field.NewFloat64(table, "flesch_kincaid_grade")
+	c.DaleChallScore = field.NewFloat64(table, "dale_chall_score")
+	c.AvgSentenceLength = field.NewFloat64(table, "avg_sentence_length")
+	c.RareWordRatio = field.NewFloat64(table, "rare_word_ratio")
+	c.CefrLevel = field.NewString(table, "cefr_level")
 
 	c.fillFieldMap()
 
@@ -111,7 +126,7 @@ func (c *chunk) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (c *chunk) fillFieldMap() {
-	c.fieldMap = make(map[string]field.Expr, 11)
+	c.fieldMap = make(map[string]field.Expr, 16)
 	c.fieldMap["id"] = c.ID
 	c.fieldMap["document_id"] = c.DocumentID
 	c.fieldMap["chunk_index"] = c.ChunkIndex
@@ -123,6 +138,11 @@ func (c *chunk) fillFieldMap() {
 	c.fieldMap["milvus_id"] = c.MilvusID
 	c.fieldMap["content_hash"] = c.ContentHash
 	c.fieldMap["created_at"] = c.CreatedAt
+	c.fieldMap["flesch_kincaid_grade"] = c.FleschKincaidGrade
+	c.fieldMap["dale_chall_score"] = c.DaleChallScore
+	c.fieldMap["avg_sentence_length"] = c.AvgSentenceLength
+	c.fieldMap["rare_word_ratio"] = c.RareWordRatio
+	c.fieldMap["cefr_level"] = c.CefrLevel
 }
 
 func (c chunk) clone(db *gorm.DB) chunk {
@@ -37,6 +37,17 @@ func newDocument(db *gorm.DB, opts ...gen.DOOption) document {
 	_document.PageCount = field.NewInt32(tableName, "page_count")
 	_document.Sha256 = field.NewString(tableName, "sha256")
 	_document.UploadedAt = field.NewTime(tableName, "uploaded_at")
+	_document.Status = field.NewString(tableName, "status")
+	_document.StatusReason = field.NewString(tableName, "status_reason")
+	_document.ChunksTotal = field.NewInt32(tableName, "chunks_total")
+	_document.ChunksEmbedded = field.NewInt32(tableName, "chunks_embedded")
+	_document.StatusUpdatedAt = field.NewTime(tableName, "status_updated_at")
+	_document.VocabularyAt = field.NewTime(tableName, "vocabulary_at")
+	_document.FleschKincaidGrade = field.NewFloat64(tableName, "flesch_kincaid_grade")
+	_document.DaleChallScore = field.NewFloat64(tableName, "dale_chall_score")
+	_document.AvgSentenceLength = field.NewFloat64(tableName, "avg_sentence_length")
+	_document.RareWordRatio = field.NewFloat64(tableName, "rare_word_ratio")
+	_document.CefrLevel = field.NewString(tableName, "cefr_level")
 
 	_document.fillFieldMap()
 
@@ -46,16 +57,27 @@ func newDocument(db *gorm.DB, opts ...gen.DOOption) document {
 type document struct {
 	documentDo documentDo
 
-	ALL              field.Asterisk
-	ID               field.Int64
-	UserID           field.Int64
-	Title            field.String
-	OriginalFilename field.String
-	FilePath         field.String
-	Language         field.String
-	PageCount        field.Int32
-	Sha256           field.String
-	UploadedAt       field.Time
+	ALL                field.Asterisk
+	ID                 field.Int64
+	UserID             field.Int64
+	Title              field.String
+	OriginalFilename   field.String
+	FilePath           field.String
+	Language           field.String
+	PageCount          field.Int32
+	Sha256             field.String
+	UploadedAt         field.Time
+	Status             field.String
+	StatusReason       field.String
+	ChunksTotal        field.Int32
+	ChunksEmbedded     field.Int32
+	StatusUpdatedAt    field.Time
+	VocabularyAt       field.Time
+	FleschKincaidGrade field.Float64
+	DaleChallScore     field.Float64
+	AvgSentenceLength  field.Float64
+	RareWordRatio      field.Float64
+	CefrLevel          field.String
 
 	fieldMap map[string]field.Expr
 }
@@ -81,6 +103,17 @@ func (d *document) updateTableName(table string) *document {
 	d.PageCount = field.NewInt32(table, "page_count")
 	d.Sha256 = field.NewString(table, "sha256")
 	d.UploadedAt = field.NewTime(table, "uploaded_at")
+	d.Status = field.NewString(table, "status")
+	d.StatusReason = field.NewString(table, "status_reason")
+	d.ChunksTotal = field.NewInt32(table, "chunks_total")
+	d.ChunksEmbedded = field.NewInt32(table, "chunks_embedded")
+	d.StatusUpdatedAt = field.NewTime(table, "status_updated_at")
+	d.VocabularyAt = field.NewTime(table, "vocabulary_at")
+	d.FleschKincaidGrade = field.NewFloat64(table, "flesch_kincaid_grade")
+	d.DaleChallScore = field.NewFloat64(table, "dale_chall_score")
+	d.AvgSentenceLength = field.NewFloat64(table, "avg_sentence_length")
+	d.RareWordRatio = field.NewFloat64(table, "rare_word_ratio")
+	d.CefrLevel = field.NewString(table, "cefr_level")
 
 	d.fillFieldMap()
 
@@ -105,7 +138,7 @@ func (d *document) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (d *document) fillFieldMap() {
-	d.fieldMap = make(map[string]field.Expr, 9)
+	d.fieldMap = make(map[string]field.Expr, 20)
 	d.fieldMap["id"] = d.ID
 	d.fieldMap["user_id"] = d.UserID
 	d.fieldMap["title"] = d.Title
@@ -115,6 +148,17 @@ func (d *document) fillFieldMap() {
 	d.fieldMap["page_count"] = d.PageCount
 	d.fieldMap["sha256"] = d.Sha256
 	d.fieldMap["uploaded_at"] = d.UploadedAt
+	d.fieldMap["status"] = d.Status
+	d.fieldMap["status_reason"] = d.StatusReason
+	d.fieldMap["chunks_total"] = d.ChunksTotal
+	d.fieldMap["chunks_embedded"] = d.ChunksEmbedded
+	d.fieldMap["status_updated_at"] = d.StatusUpdatedAt
+	d.fieldMap["vocabulary_at"] = d.VocabularyAt
+	d.fieldMap["flesch_kincaid_grade"] = d.FleschKincaidGrade
+	d.fieldMap["dale_chall_score"] = d.DaleChallScore
+	d.fieldMap["avg_sentence_length"] = d.AvgSentenceLength
+	d.fieldMap["rare_word_ratio"] = d.RareWordRatio
+	d.fieldMap["cefr_level"] = d.CefrLevel
 }
 
 func (d document) clone(db *gorm.DB) document {
@@ -16,54 +16,134 @@ import (
 )
 
 var (
-	Q              = new(Query)
-	AlembicVersion *alembicVersion
-	Chunk          *chunk
-	Document       *document
-	Message        *message
-	User           *user
+	Q                   = new(Query)
+	AlembicVersion      *alembicVersion
+	Chunk               *chunk
+	Conversation        *conversation
+	Document            *document
+	DocumentVocabulary  *documentVocabulary
+	EmbeddingCollection *embeddingCollection
+	Flashcard           *flashcard
+	Job                 *job
+	LearnerProfile      *learnerProfile
+	Message             *message
+	PlacementAnswer     *placementAnswer
+	PlacementItem       *placementItem
+	PlacementSession    *placementSession
+	Quiz                *quiz
+	QuizAnswer          *quizAnswer
+	QuizAttempt         *quizAttempt
+	QuizQuestion        *quizQuestion
+	RefreshToken        *refreshToken
+	Review              *review
+	User                *user
+	WordBank            *wordBank
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
 	AlembicVersion = &Q.AlembicVersion
 	Chunk = &Q.Chunk
+	Conversation = &Q.Conversation
 	Document = &Q.Document
+	DocumentVocabulary = &Q.DocumentVocabulary
+	EmbeddingCollection = &Q.EmbeddingCollection
+	Flashcard = &Q.Flashcard
+	Job = &Q.Job
+	LearnerProfile = &Q.LearnerProfile
 	Message = &Q.Message
+	PlacementAnswer = &Q.PlacementAnswer
+	PlacementItem = &Q.PlacementItem
+	PlacementSession = &Q.PlacementSession
+	Quiz = &Q.Quiz
+	QuizAnswer = &Q.QuizAnswer
+	QuizAttempt = &Q.QuizAttempt
+	QuizQuestion = &Q.QuizQuestion
+	RefreshToken = &Q.RefreshToken
+	Review = &Q.Review
 	User = &Q.User
+	WordBank = &Q.WordBank
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:             db,
-		AlembicVersion: newAlembicVersion(db, opts...),
-		Chunk:          newChunk(db, opts...),
-		Document:       newDocument(db, opts...),
-		Message:        newMessage(db, opts...),
-		User:           newUser(db, opts...),
+		db:                  db,
+		AlembicVersion:      newAlembicVersion(db, opts...),
+		Chunk:               newChunk(db, opts...),
+		Conversation:        newConversation(db, opts...),
+		Document:            newDocument(db, opts...),
+		DocumentVocabulary:  newDocumentVocabulary(db, opts...),
+		EmbeddingCollection: newEmbeddingCollection(db, opts...),
+		Flashcard:           newFlashcard(db, opts...),
+		Job:                 newJob(db, opts...),
+		LearnerProfile:      newLearnerProfile(db, opts...),
+		Message:             newMessage(db, opts...),
+		PlacementAnswer:     newPlacementAnswer(db, opts...),
+		PlacementItem:       newPlacementItem(db, opts...),
+		PlacementSession:    newPlacementSession(db, opts...),
+		Quiz:                newQuiz(db, opts...),
+		QuizAnswer:          newQuizAnswer(db, opts...),
+		QuizAttempt:         newQuizAttempt(db, opts...),
+		QuizQuestion:        newQuizQuestion(db, opts...),
+		RefreshToken:        newRefreshToken(db, opts...),
+		Review:              newReview(db, opts...),
+		User:                newUser(db, opts...),
+		WordBank:            newWordBank(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	AlembicVersion alembicVersion
-	Chunk          chunk
-	Document       document
-	Message        message
-	User           user
+	AlembicVersion      alembicVersion
+	Chunk               chunk
+	Conversation        conversation
+	Document            document
+	DocumentVocabulary  documentVocabulary
+	EmbeddingCollection embeddingCollection
+	Flashcard           flashcard
+	Job                 job
+	LearnerProfile      learnerProfile
+	Message             message
+	PlacementAnswer     placementAnswer
+	PlacementItem       placementItem
+	PlacementSession    placementSession
+	Quiz                quiz
+	QuizAnswer          quizAnswer
+	QuizAttempt         quizAttempt
+	QuizQuestion        quizQuestion
+	RefreshToken        refreshToken
+	Review              review
+	User                user
+	WordBank            wordBank
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:             db,
-		AlembicVersion: q.AlembicVersion.clone(db),
-		Chunk:          q.Chunk.clone(db),
-		Document:       q.Document.clone(db),
-		Message:        q.Message.clone(db),
-		User:           q.User.clone(db),
+		db:                  db,
+		AlembicVersion:      q.AlembicVersion.clone(db),
+		Chunk:               q.Chunk.clone(db),
+		Conversation:        q.Conversation.clone(db),
+		Document:            q.Document.clone(db),
+		DocumentVocabulary:  q.DocumentVocabulary.clone(db),
+		EmbeddingCollection: q.EmbeddingCollection.clone(db),
+		Flashcard:           q.Flashcard.clone(db),
+		Job:                 q.Job.clone(db),
+		LearnerProfile:      q.LearnerProfile.clone(db),
+		Message:             q.Message.clone(db),
+		PlacementAnswer:     q.PlacementAnswer.clone(db),
+		PlacementItem:       q.PlacementItem.clone(db),
+		PlacementSession:    q.PlacementSession.clone(db),
+		Quiz:                q.Quiz.clone(db),
+		QuizAnswer:          q.QuizAnswer.clone(db),
+		QuizAttempt:         q.QuizAttempt.clone(db),
+		QuizQuestion:        q.QuizQuestion.clone(db),
+		RefreshToken:        q.RefreshToken.clone(db),
+		Review:              q.Review.clone(db),
+		User:                q.User.clone(db),
+		WordBank:            q.WordBank.clone(db),
 	}
 }
 
@@ -77,30 +157,78 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:             db,
-		AlembicVersion: q.AlembicVersion.replaceDB(db),
-		Chunk:          q.Chunk.replaceDB(db),
-		Document:       q.Document.replaceDB(db),
-		Message:        q.Message.replaceDB(db),
-		User:           q.User.replaceDB(db),
+		db:                  db,
+		AlembicVersion:      q.AlembicVersion.replaceDB(db),
+		Chunk:               q.Chunk.replaceDB(db),
+		Conversation:        q.Conversation.replaceDB(db),
+		Document:            q.Document.replaceDB(db),
+		DocumentVocabulary:  q.DocumentVocabulary.replaceDB(db),
+		EmbeddingCollection: q.EmbeddingCollection.replaceDB(db),
+		Flashcard:           q.Flashcard.replaceDB(db),
+		Job:                 q.Job.replaceDB(db),
+		LearnerProfile:      q.LearnerProfile.replaceDB(db),
+		Message:             q.Message.replaceDB(db),
+		PlacementAnswer:     q.PlacementAnswer.replaceDB(db),
+		PlacementItem:       q.PlacementItem.replaceDB(db),
+		PlacementSession:    q.PlacementSession.replaceDB(db),
+		Quiz:                q.Quiz.replaceDB(db),
+		QuizAnswer:          q.QuizAnswer.replaceDB(db),
+		QuizAttempt:         q.QuizAttempt.replaceDB(db),
+		QuizQuestion:        q.QuizQuestion.replaceDB(db),
+		RefreshToken:        q.RefreshToken.replaceDB(db),
+		Review:              q.Review.replaceDB(db),
+		User:                q.User.replaceDB(db),
+		WordBank:            q.WordBank.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	AlembicVersion IAlembicVersionDo
-	Chunk          IChunkDo
-	Document       IDocumentDo
-	Message        IMessageDo
-	User           IUserDo
+	AlembicVersion      IAlembicVersionDo
+	Chunk               IChunkDo
+	Conversation        IConversationDo
+	Document            IDocumentDo
+	DocumentVocabulary  IDocumentVocabularyDo
+	EmbeddingCollection IEmbeddingCollectionDo
+	Flashcard           IFlashcardDo
+	Job                 IJobDo
+	LearnerProfile      ILearnerProfileDo
+	Message             IMessageDo
+	PlacementAnswer     IPlacementAnswerDo
+	PlacementItem       IPlacementItemDo
+	PlacementSession    IPlacementSessionDo
+	Quiz                IQuizDo
+	QuizAnswer          IQuizAnswerDo
+	QuizAttempt         IQuizAttemptDo
+	QuizQuestion        IQuizQuestionDo
+	RefreshToken        IRefreshTokenDo
+	Review              IReviewDo
+	User                IUserDo
+	WordBank            IWordBankDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		AlembicVersion: q.AlembicVersion.WithContext(ctx),
-		Chunk:          q.Chunk.WithContext(ctx),
-		Document:       q.Document.WithContext(ctx),
-		Message:        q.Message.WithContext(ctx),
-		User:           q.User.WithContext(ctx),
+		AlembicVersion:      q.AlembicVersion.WithContext(ctx),
+		Chunk:               q.Chunk.WithContext(ctx),
+		Conversation:        q.Conversation.WithContext(ctx),
+		Document:            q.Document.WithContext(ctx),
+		DocumentVocabulary:  q.DocumentVocabulary.WithContext(ctx),
+		EmbeddingCollection: q.EmbeddingCollection.WithContext(ctx),
+		Flashcard:           q.Flashcard.WithContext(ctx),
+		Job:                 q.Job.WithContext(ctx),
+		LearnerProfile:      q.LearnerProfile.WithContext(ctx),
+		Message:             q.Message.WithContext(ctx),
+		PlacementAnswer:     q.PlacementAnswer.WithContext(ctx),
+		PlacementItem:       q.PlacementItem.WithContext(ctx),
+		PlacementSession:    q.PlacementSession.WithContext(ctx),
+		Quiz:                q.Quiz.WithContext(ctx),
+		QuizAnswer:          q.QuizAnswer.WithContext(ctx),
+		QuizAttempt:         q.QuizAttempt.WithContext(ctx),
+		QuizQuestion:        q.QuizQuestion.WithContext(ctx),
+		RefreshToken:        q.RefreshToken.WithContext(ctx),
+		Review:              q.Review.WithContext(ctx),
+		User:                q.User.WithContext(ctx),
+		WordBank:            q.WordBank.WithContext(ctx),
 	}
 }
 
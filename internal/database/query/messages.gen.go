@@ -34,6 +34,7 @@ func newMessage(db *gorm.DB, opts ...gen.DOOption) message {
 	_message.Content = field.NewString(tableName, "content")
 	_message.DocumentID = field.NewInt64(tableName, "document_id")
 	_message.CreatedAt = field.NewTime(tableName, "created_at")
+	_message.ConversationID = field.NewInt64(tableName, "conversation_id")
 
 	_message.fillFieldMap()
 
@@ -43,13 +44,14 @@ func newMessage(db *gorm.DB, opts ...gen.DOOption) message {
 type message struct {
 	messageDo messageDo
 
-	ALL        field.Asterisk
-	ID         field.Int64
-	UserID     field.Int64
-	Role       field.String
-	Content    field.String
-	DocumentID field.Int64
-	CreatedAt  field.Time
+	ALL            field.Asterisk
+	ID             field.Int64
+	UserID         field.Int64
+	Role           field.String
+	Content        field.String
+	DocumentID     field.Int64
+	CreatedAt      field.Time
+	ConversationID field.Int64
 
 	fieldMap map[string]field.Expr
 }
@@ -72,6 +74,7 @@ func (m *message) updateTableName(table string) *message {
 	m.Content = field.NewString(table, "content")
 	m.DocumentID = field.NewInt64(table, "document_id")
 	m.CreatedAt = field.NewTime(table, "created_at")
+	m.ConversationID = field.NewInt64(table, "conversation_id")
 
 	m.fillFieldMap()
 
@@ -96,13 +99,14 @@ func (m *message) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (m *message) fillFieldMap() {
-	m.fieldMap = make(map[string]field.Expr, 6)
+	m.fieldMap = make(map[string]field.Expr, 7)
 	m.fieldMap["id"] = m.ID
 	m.fieldMap["user_id"] = m.UserID
 	m.fieldMap["role"] = m.Role
 	m.fieldMap["content"] = m.Content
 	m.fieldMap["document_id"] = m.DocumentID
 	m.fieldMap["created_at"] = m.CreatedAt
+	m.fieldMap["conversation_id"] = m.ConversationID
 }
 
 func (m message) clone(db *gorm.DB) message {
@@ -0,0 +1,120 @@
+// Package embedding turns text into vectors for the vector store. OpenAI and
+// Gemini are used in production; the local embedder is deterministic and
+// works offline for development and tests.
+package embedding
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/internal/vectorstore"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var httpClient = &http.Client{Timeout: 60 * time.Second}
+
+// Embedder converts texts to vectors of a fixed dimension.
+type Embedder interface {
+	// Name identifies the provider and model, e.g. "openai/text-embedding-3-small".
+	Name() string
+	Dimension() int
+	// Embed returns one vector per text, in order.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// New builds the embedder selected by cfg.Provider, using the API keys of
+// the matching provider config.
+func New(cfg config.EmbeddingConfig, openai config.OpenAIConfig, gemini config.GeminiConfig) (Embedder, error) {
+	if cfg.Dimension <= 0 {
+		return nil, fmt.Errorf("embedding dimension must be positive, got %d", cfg.Dimension)
+	}
+	var e Embedder
+	switch cfg.Provider {
+	case "openai":
+		e = NewOpenAI(openai.Key, cfg.Model, cfg.Dimension)
+	case "gemini":
+		e = NewGemini(gemini.Key, cfg.Model, cfg.Dimension)
+	case "", "local":
+		e = NewLocal(cfg.Dimension)
+	default:
+		return nil, fmt.Errorf("unknown embedding provider %q", cfg.Provider)
+	}
+	return Batched(e, cfg.BatchSize), nil
+}
+
+// CollectionName returns the vector collection that holds vectors produced
+// by e. Every model and dimension gets its own collection, so the name
+// stored in Chunk.MilvusCollection identifies how a chunk was embedded.
+func CollectionName(e Embedder) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return '_'
+		}
+	}, e.Name())
+	return fmt.Sprintf("chunks_%s_%d", name, e.Dimension())
+}
+
+// EnsureCollection creates the collection for e if needed and checks that
+// its vector dimension matches the embedder.
+func EnsureCollection(ctx context.Context, store vectorstore.VectorStore, collection string, e Embedder) error {
+	if err := store.CreateCollection(ctx, collection, e.Dimension()); err != nil {
+		return err
+	}
+	dim, err := store.Dimension(ctx, collection)
+	if err != nil {
+		return err
+	}
+	if dim != e.Dimension() {
+		return fmt.Errorf("%w: collection %s has %d dimensions, embedder %s produces %d",
+			vectorstore.ErrDimensionMismatch, collection, dim, e.Name(), e.Dimension())
+	}
+	return nil
+}
+
+type batched struct {
+	Embedder
+	size int
+}
+
+// Batched wraps e so that Embed sends at most size texts per call to the
+// underlying provider. A size of zero or less disables batching.
+func Batched(e Embedder, size int) Embedder {
+	if size <= 0 {
+		return e
+	}
+	return &batched{Embedder: e, size: size}
+}
+
+func (b *batched) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += b.size {
+		end := min(start+b.size, len(texts))
+		vectors, err := b.Embedder.Embed(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, vectors...)
+	}
+	return out, nil
+}
+
+// checkVectors verifies a provider response has one vector of the expected
+// dimension per input text.
+func checkVectors(name string, vectors [][]float32, texts, dim int) error {
+	if len(vectors) != texts {
+		return fmt.Errorf("%s returned %d embeddings for %d texts", name, len(vectors), texts)
+	}
+	for _, v := range vectors {
+		if len(v) != dim {
+			return fmt.Errorf("%w: %s returned %d dimensions, expected %d", vectorstore.ErrDimensionMismatch, name, len(v), dim)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package embedding
+
+import (
+	"ai-learn-english/internal/vectorstore"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+func norm(v []float32) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	return math.Sqrt(sum)
+}
+
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
+
+func TestLocal(t *testing.T) {
+	texts := []string{
+		"The cat sat on the mat.",
+		"the CAT sat on the mat",
+		"Quarterly revenue grew by 12 percent.",
+		"",
+	}
+	for _, dim := range []int{8, 64, 768} {
+		t.Run(fmt.Sprint(dim), func(t *testing.T) {
+			l := NewLocal(dim)
+			if l.Dimension() != dim {
+				t.Fatalf("Dimension() = %d", l.Dimension())
+			}
+			first, err := l.Embed(context.Background(), texts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			again, err := NewLocal(dim).Embed(context.Background(), texts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(first) != len(texts) {
+				t.Fatalf("%d vectors for %d texts", len(first), len(texts))
+			}
+			for i, v := range first {
+				if len(v) != dim {
+					t.Errorf("text %d: %d dimensions", i, len(v))
+				}
+				if !slices.Equal(v, again[i]) {
+					t.Errorf("text %d: embedding is not deterministic", i)
+				}
+				want := 1.0
+				if texts[i] == "" {
+					want = 0
+				}
+				if n := norm(v); math.Abs(n-want) > 1e-5 {
+					t.Errorf("text %d: norm %v, want %v", i, n, want)
+				}
+			}
+			// Case and punctuation do not change the words.
+			if !slices.Equal(first[0], first[1]) {
+				t.Error("case and punctuation changed the embedding")
+			}
+		})
+	}
+
+	v, _ := NewLocal(256).Embed(context.Background(), []string{
+		"the present perfect tense links past and present",
+		"the present perfect tense connects past and present",
+		"quarterly revenue grew by twelve percent",
+	})
+	if related, unrelated := dot(v[0], v[1]), dot(v[0], v[2]); related <= unrelated {
+		t.Errorf("similar texts score %v, unrelated %v", related, unrelated)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewLocal(8).Embed(ctx, texts); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled Embed: %v", err)
+	}
+}
+
+// recorder embeds each text as its index in the full input, so the order of
+// the output can be checked, and records the size of every call.
+type recorder struct {
+	calls []int
+	next  float32
+	err   error
+}
+
+func (r *recorder) Name() string   { return "test/recorder" }
+func (r *recorder) Dimension() int { return 1 }
+
+func (r *recorder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	r.calls = append(r.calls, len(texts))
+	if r.err != nil {
+		return nil, r.err
+	}
+	out := make([][]float32, len(texts))
+	for i := range texts {
+		out[i] = []float32{r.next}
+		r.next++
+	}
+	return out, nil
+}
+
+func TestBatched(t *testing.T) {
+	tests := []struct {
+		size  int
+		texts int
+		calls []int
+	}{
+		{3, 7, []int{3, 3, 1}},
+		{3, 6, []int{3, 3}},
+		{10, 4, []int{4}},
+		{1, 3, []int{1, 1, 1}},
+		{0, 5, []int{5}},
+		{-1, 5, []int{5}},
+		{4, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d texts by %d", tt.texts, tt.size), func(t *testing.T) {
+			r := &recorder{}
+			e := Batched(r, tt.size)
+			if e.Name() != r.Name() || e.Dimension() != 1 {
+				t.Errorf("Batched changed the name or dimension")
+			}
+			texts := make([]string, tt.texts)
+			vectors, err := e.Embed(context.Background(), texts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(r.calls, tt.calls) {
+				t.Errorf("calls of %v, want %v", r.calls, tt.calls)
+			}
+			if len(vectors) != tt.texts {
+				t.Fatalf("%d vectors for %d texts", len(vectors), tt.texts)
+			}
+			for i, v := range vectors {
+				if v[0] != float32(i) {
+					t.Errorf("vector %d is %v: order not kept", i, v)
+				}
+			}
+		})
+	}
+
+	boom := errors.New("boom")
+	if _, err := Batched(&recorder{err: boom}, 2).Embed(context.Background(), make([]string, 5)); !errors.Is(err, boom) {
+		t.Errorf("err = %v, want the provider's error", err)
+	}
+}
+
+func TestCheckVectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		vectors  [][]float32
+		texts    int
+		wantErr  bool
+		mismatch bool
+	}{
+		{"ok", [][]float32{{1, 2}, {3, 4}}, 2, false, false},
+		{"none for none", nil, 0, false, false},
+		{"too few", [][]float32{{1, 2}}, 2, true, false},
+		{"too many", [][]float32{{1, 2}, {3, 4}, {5, 6}}, 2, true, false},
+		{"short vector", [][]float32{{1, 2}, {3}}, 2, true, true},
+		{"long vector", [][]float32{{1, 2, 3}, {3, 4}}, 2, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkVectors("test", tt.vectors, tt.texts, 2)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if errors.Is(err, vectorstore.ErrDimensionMismatch) != tt.mismatch {
+				t.Errorf("err = %v, ErrDimensionMismatch %v", err, tt.mismatch)
+			}
+		})
+	}
+}
+
+func TestEnsureCollection(t *testing.T) {
+	ctx := context.Background()
+	store := vectorstore.NewMemory()
+	e := NewLocal(16)
+	name := CollectionName(e)
+	if name != "chunks_local_hashing_16" {
+		t.Errorf("CollectionName = %q", name)
+	}
+
+	if err := EnsureCollection(ctx, store, name, e); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if dim, err := store.Dimension(ctx, name); err != nil || dim != 16 {
+		t.Errorf("Dimension = %d, %v; want 16", dim, err)
+	}
+	if err := EnsureCollection(ctx, store, name, e); err != nil {
+		t.Errorf("existing collection: %v", err)
+	}
+	if err := EnsureCollection(ctx, store, name, NewLocal(32)); !errors.Is(err, vectorstore.ErrDimensionMismatch) {
+		t.Errorf("other dimension: err = %v, want ErrDimensionMismatch", err)
+	}
+}
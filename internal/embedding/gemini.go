@@ -0,0 +1,79 @@
+package embedding
+
+import (
+	"ai-learn-english/pkg/httpjson"
+	"context"
+	"fmt"
+	"net/url"
+)
+
+const geminiBaseURL = "https://generativelanguage.googleapis.com/v1beta/models/"
+
+// Gemini embeds text with the Gemini batchEmbedContents API.
+type Gemini struct {
+	key   string
+	model string
+	dim   int
+}
+
+func NewGemini(key, model string, dim int) *Gemini {
+	return &Gemini{key: key, model: model, dim: dim}
+}
+
+func (g *Gemini) Name() string   { return "gemini/" + g.model }
+func (g *Gemini) Dimension() int { return g.dim }
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiContent struct {
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiEmbedRequest struct {
+	Model                string        `json:"model"`
+	Content              geminiContent `json:"content"`
+	TaskType             string        `json:"taskType,omitempty"`
+	OutputDimensionality int           `json:"outputDimensionality,omitempty"`
+}
+
+type geminiBatchEmbedRequest struct {
+	Requests []geminiEmbedRequest `json:"requests"`
+}
+
+type geminiBatchEmbedResponse struct {
+	Embeddings []struct {
+		Values []float32 `json:"values"`
+	} `json:"embeddings"`
+}
+
+func (g *Gemini) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	model := "models/" + g.model
+	body := geminiBatchEmbedRequest{Requests: make([]geminiEmbedRequest, len(texts))}
+	for i, t := range texts {
+		body.Requests[i] = geminiEmbedRequest{
+			Model:                model,
+			Content:              geminiContent{Parts: []geminiPart{{Text: t}}},
+			OutputDimensionality: g.dim,
+		}
+	}
+
+	endpoint := fmt.Sprintf("%s%s:batchEmbedContents", geminiBaseURL, url.PathEscape(g.model))
+	var res geminiBatchEmbedResponse
+	if err := httpjson.Post(ctx, httpClient, "gemini", endpoint, map[string]string{"x-goog-api-key": g.key}, body, &res); err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float32, len(res.Embeddings))
+	for i, e := range res.Embeddings {
+		vectors[i] = e.Values
+	}
+	if err := checkVectors(g.Name(), vectors, len(texts), g.dim); err != nil {
+		return nil, err
+	}
+	return vectors, nil
+}
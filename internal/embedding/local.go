@@ -0,0 +1,72 @@
+package embedding
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Local is a deterministic embedder based on feature hashing. Words and
+// word bigrams are hashed into the vector with a hashed sign and the result
+// is L2-normalised, so texts sharing vocabulary have a high cosine
+// similarity. It needs no network and always returns the same vector for
+// the same text.
+type Local struct {
+	dim int
+}
+
+func NewLocal(dim int) *Local {
+	return &Local{dim: dim}
+}
+
+func (l *Local) Name() string   { return "local/hashing" }
+func (l *Local) Dimension() int { return l.dim }
+
+func (l *Local) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, t := range texts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		vectors[i] = l.embed(t)
+	}
+	return vectors, nil
+}
+
+func (l *Local) embed(text string) []float32 {
+	v := make([]float32, l.dim)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+	for i, w := range words {
+		l.add(v, w, 1)
+		if i > 0 {
+			l.add(v, words[i-1]+" "+w, 0.5)
+		}
+	}
+
+	var norm float64
+	for _, x := range v {
+		norm += float64(x) * float64(x)
+	}
+	if norm > 0 {
+		inv := float32(1 / math.Sqrt(norm))
+		for i := range v {
+			v[i] *= inv
+		}
+	}
+	return v
+}
+
+func (l *Local) add(v []float32, feature string, weight float32) {
+	h := fnv.New64a()
+	h.Write([]byte(feature))
+	sum := h.Sum64()
+	idx := int(sum % uint64(l.dim))
+	if sum>>63 == 1 {
+		weight = -weight
+	}
+	v[idx] += weight
+}
@@ -0,0 +1,60 @@
+package embedding
+
+import (
+	"ai-learn-english/pkg/httpjson"
+	"context"
+	"sort"
+)
+
+const openAIEmbeddingsURL = "https://api.openai.com/v1/embeddings"
+
+// OpenAI embeds text with the OpenAI embeddings API.
+type OpenAI struct {
+	key   string
+	model string
+	dim   int
+}
+
+func NewOpenAI(key, model string, dim int) *OpenAI {
+	return &OpenAI{key: key, model: model, dim: dim}
+}
+
+func (o *OpenAI) Name() string   { return "openai/" + o.model }
+func (o *OpenAI) Dimension() int { return o.dim }
+
+type openAIEmbeddingRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
+}
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+func (o *OpenAI) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	var res openAIEmbeddingResponse
+	err := httpjson.Post(ctx, httpClient, "openai", openAIEmbeddingsURL,
+		map[string]string{"Authorization": "Bearer " + o.key},
+		openAIEmbeddingRequest{Model: o.model, Input: texts, Dimensions: o.dim},
+		&res)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(res.Data, func(i, j int) bool { return res.Data[i].Index < res.Data[j].Index })
+	vectors := make([][]float32, len(res.Data))
+	for i, d := range res.Data {
+		vectors[i] = d.Embedding
+	}
+	if err := checkVectors(o.Name(), vectors, len(texts), o.dim); err != nil {
+		return nil, err
+	}
+	return vectors, nil
+}
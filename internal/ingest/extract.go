@@ -0,0 +1,198 @@
+// Package ingest turns uploaded documents into chunk rows that the teacher
+// can retrieve and cite.
+package ingest
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/internal/embedding"
+	"ai-learn-english/internal/jobqueue"
+	"ai-learn-english/internal/vectorstore"
+	"ai-learn-english/pkg/chunker"
+	"ai-learn-english/pkg/pdftext"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+var ErrNoFile = errors.New("document has no file path")
+
+// Collections tells the pipeline which vector collection new chunks go to
+// and which embedder fills it.
+type Collections interface {
+	ActiveCollection(ctx context.Context) (string, embedding.Embedder, error)
+}
+
+// Pipeline runs the ingestion stages for a document. Each stage leaves its
+// output in the database or in the document's work directory, so the stages
+// can run as separate jobs and be retried on their own.
+type Pipeline struct {
+	q           *query.Query
+	chunker     *chunker.Chunker
+	collections Collections
+	store       vectorstore.VectorStore
+	workDir     string
+}
+
+func NewPipeline(q *query.Query, c *chunker.Chunker, collections Collections, store vectorstore.VectorStore, workDir string) *Pipeline {
+	return &Pipeline{q: q, chunker: c, collections: collections, store: store, workDir: workDir}
+}
+
+// NewChunker returns the chunker sized by the chunker section of the config.
+func NewChunker(cfg config.ChunkerConfig) *chunker.Chunker {
+	return chunker.New(chunker.Options{
+		MaxTokens:     cfg.MaxTokens,
+		OverlapTokens: cfg.OverlapTokens,
+		PreviewChars:  cfg.PreviewChars,
+	})
+}
+
+// Process runs every ingestion stage for doc.
+func (p *Pipeline) Process(ctx context.Context, doc *model.Document) error {
+	for _, stage := range []func(context.Context, *model.Document) error{p.Extract, p.Chunk, p.Vocabulary, p.Embed, p.Index} {
+		if err := stage(ctx, doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Extract reads the text of every page of doc, records its page count and
+// keeps the pages in the work directory for the chunk stage. A missing or
+// unreadable file is a permanent failure.
+func (p *Pipeline) Extract(ctx context.Context, doc *model.Document) error {
+	if err := p.setStatus(ctx, doc, StatusExtracting, nil); err != nil {
+		return err
+	}
+	if doc.FilePath == nil || *doc.FilePath == "" {
+		return jobqueue.Permanent(ErrNoFile)
+	}
+
+	pages, err := pdftext.ExtractFile(*doc.FilePath)
+	if err != nil {
+		return jobqueue.Permanent(fmt.Errorf("extract document %d: %w", doc.ID, err))
+	}
+	if err := p.writeWork(doc, pagesFile, pages); err != nil {
+		return err
+	}
+
+	pageCount := int32(len(pages))
+	d := p.q.Document
+	if _, err := d.WithContext(ctx).Where(d.ID.Eq(doc.ID)).Update(d.PageCount, pageCount); err != nil {
+		return fmt.Errorf("save page count of document %d: %w", doc.ID, err)
+	}
+	doc.PageCount = &pageCount
+	return nil
+}
+
+// Chunk replaces the document's chunks with token-bounded windows of each
+// extracted page. Chunks never span pages and Chunk.PageIndex holds the
+// 1-based page number so answers can cite it. Each chunk and the document
+// are scored for readability on the way.
+func (p *Pipeline) Chunk(ctx context.Context, doc *model.Document) error {
+	if err := p.setStatus(ctx, doc, StatusChunking, nil); err != nil {
+		return err
+	}
+	var pages []pdftext.Page
+	if err := p.readWork(doc, pagesFile, &pages); err != nil {
+		return err
+	}
+
+	chunks := p.split(doc.ID, pages)
+	counts := scoreChunks(chunks)
+
+	err := p.q.Transaction(func(tx *query.Query) error {
+		if _, err := tx.Chunk.WithContext(ctx).Where(tx.Chunk.DocumentID.Eq(doc.ID)).Delete(); err != nil {
+			return err
+		}
+		if len(chunks) > 0 {
+			if err := tx.Chunk.WithContext(ctx).CreateInBatches(chunks, 100); err != nil {
+				return err
+			}
+		}
+		d := tx.Document
+		_, err := d.WithContext(ctx).Where(d.ID.Eq(doc.ID)).
+			UpdateSimple(append(scoreDocument(tx, doc, counts), d.ChunksTotal.Value(int32(len(chunks))), d.ChunksEmbedded.Zero())...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("save chunks for document %d: %w", doc.ID, err)
+	}
+	doc.ChunksTotal = int32(len(chunks))
+	doc.ChunksEmbedded = 0
+	return nil
+}
+
+// split chunks each page on its own and numbers the chunks in reading
+// order.
+func (p *Pipeline) split(documentID int64, pages []pdftext.Page) []*model.Chunk {
+	chunks := make([]*model.Chunk, 0, len(pages))
+	for _, page := range pages {
+		pageIndex := int32(page.Number)
+		for _, c := range p.chunker.Split(page.Text) {
+			chunks = append(chunks, newChunk(documentID, int32(len(chunks)), &pageIndex, c))
+		}
+	}
+	return chunks
+}
+
+// newChunk builds a chunk row that is not yet indexed in the vector store.
+func newChunk(documentID int64, index int32, pageIndex *int32, c chunker.Chunk) *model.Chunk {
+	tokens := int32(c.TokenCount)
+	preview := c.Preview
+	return &model.Chunk{
+		DocumentID:     documentID,
+		ChunkIndex:     index,
+		PageIndex:      pageIndex,
+		Content:        c.Content,
+		ContentPreview: &preview,
+		TokenCount:     &tokens,
+		ContentHash:    c.ContentHash,
+	}
+}
+
+const (
+	pagesFile   = "pages.json"
+	vectorsFile = "vectors.json"
+)
+
+// docWorkDir holds the intermediate output of the stages for doc.
+func (p *Pipeline) docWorkDir(doc *model.Document) string {
+	return filepath.Join(p.workDir, strconv.FormatInt(doc.ID, 10))
+}
+
+func (p *Pipeline) writeWork(doc *model.Document, name string, v any) error {
+	dir := p.docWorkDir(doc)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create work dir: %w", err)
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", name, err)
+	}
+	// Write then rename so a crash never leaves a truncated file behind.
+	tmp := filepath.Join(dir, name+".tmp")
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, name)); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
+
+func (p *Pipeline) readWork(doc *model.Document, name string, v any) error {
+	raw, err := os.ReadFile(filepath.Join(p.docWorkDir(doc), name))
+	if err != nil {
+		return fmt.Errorf("read %s of document %d: %w", name, doc.ID, err)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("decode %s of document %d: %w", name, doc.ID, err)
+	}
+	return nil
+}
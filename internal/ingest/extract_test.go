@@ -0,0 +1,61 @@
+package ingest
+
+import (
+	"ai-learn-english/pkg/chunker"
+	"ai-learn-english/pkg/pdftext"
+	"path/filepath"
+	"testing"
+)
+
+func TestSplitPageIndex(t *testing.T) {
+	tests := []struct {
+		file string
+		// pages holds the PageIndex of every chunk in order.
+		pages []int32
+	}{
+		{"single.pdf", []int32{1}},
+		{"multipage.pdf", []int32{1, 2, 3}},
+		{"empty_page.pdf", []int32{1, 3}},
+	}
+	p := &Pipeline{chunker: chunker.New(chunker.Options{MaxTokens: 400})}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			pages, err := pdftext.ExtractFile(filepath.Join("..", "..", "pkg", "pdftext", "testdata", tt.file))
+			if err != nil {
+				t.Fatalf("ExtractFile: %v", err)
+			}
+			chunks := p.split(7, pages)
+			if len(chunks) != len(tt.pages) {
+				t.Fatalf("got %d chunks, want %d", len(chunks), len(tt.pages))
+			}
+			for i, c := range chunks {
+				if c.DocumentID != 7 {
+					t.Errorf("chunk %d: DocumentID = %d, want 7", i, c.DocumentID)
+				}
+				if c.ChunkIndex != int32(i) {
+					t.Errorf("chunk %d: ChunkIndex = %d", i, c.ChunkIndex)
+				}
+				if c.PageIndex == nil || *c.PageIndex != tt.pages[i] {
+					t.Errorf("chunk %d: PageIndex = %v, want %d", i, c.PageIndex, tt.pages[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSplitKeepsPagesApart(t *testing.T) {
+	// Two short pages would fit in one window, but chunks never span pages.
+	p := &Pipeline{chunker: chunker.New(chunker.Options{MaxTokens: 400})}
+	chunks := p.split(1, []pdftext.Page{
+		{Number: 4, Text: "The first page is short."},
+		{Number: 5, Text: "So is the second one."},
+	})
+	if len(chunks) != 2 {
+		t.Fatalf("got %d chunks, want 2", len(chunks))
+	}
+	for i, want := range []int32{4, 5} {
+		if *chunks[i].PageIndex != want {
+			t.Errorf("chunk %d: PageIndex = %d, want %d", i, *chunks[i].PageIndex, want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package ingest
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/embedding"
+	"ai-learn-english/internal/vectorstore"
+	"ai-learn-english/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// embeddedChunk is one line of the vectors file written by Embed.
+type embeddedChunk struct {
+	ChunkID     int64     `json:"chunk_id"`
+	ContentHash string    `json:"content_hash"`
+	Vector      []float32 `json:"vector"`
+}
+
+// embeddedDocument is the vectors file written by Embed. Keeping it on disk
+// means a failing vector store does not cost another round of paid
+// embedding calls when the index stage is retried.
+type embeddedDocument struct {
+	Collection string          `json:"collection"`
+	Chunks     []embeddedChunk `json:"chunks"`
+}
+
+// embedBatch is how many chunks are embedded between progress updates.
+const embedBatch = 64
+
+// Embed computes a vector for every chunk of doc and keeps them in the work
+// directory for the index stage. documents.chunks_embedded is advanced after
+// every batch so clients can show progress.
+func (p *Pipeline) Embed(ctx context.Context, doc *model.Document) error {
+	if err := p.setStatus(ctx, doc, StatusEmbedding, nil); err != nil {
+		return err
+	}
+	c := p.q.Chunk
+	chunks, err := c.WithContext(ctx).Where(c.DocumentID.Eq(doc.ID)).Order(c.ChunkIndex).Find()
+	if err != nil {
+		return fmt.Errorf("load chunks for document %d: %w", doc.ID, err)
+	}
+
+	collection, embedder, err := p.collections.ActiveCollection(ctx)
+	if err != nil {
+		return err
+	}
+	out := embeddedDocument{
+		Collection: collection,
+		Chunks:     make([]embeddedChunk, len(chunks)),
+	}
+	d := p.q.Document
+	for start := 0; start < len(chunks); start += embedBatch {
+		batch := chunks[start:min(start+embedBatch, len(chunks))]
+		texts := make([]string, len(batch))
+		for i, ch := range batch {
+			texts[i] = ch.Content
+		}
+		vectors, err := embedder.Embed(ctx, texts)
+		if err != nil {
+			return fmt.Errorf("embed chunks of document %d: %w", doc.ID, err)
+		}
+		for i, ch := range batch {
+			out.Chunks[start+i] = embeddedChunk{ChunkID: ch.ID, ContentHash: ch.ContentHash, Vector: vectors[i]}
+		}
+
+		done := int32(start + len(batch))
+		if _, err := d.WithContext(ctx).Where(d.ID.Eq(doc.ID)).Update(d.ChunksEmbedded, done); err != nil {
+			return fmt.Errorf("save progress of document %d: %w", doc.ID, err)
+		}
+		doc.ChunksEmbedded = done
+	}
+	return p.writeWork(doc, vectorsFile, out)
+}
+
+// Index stores the vectors computed by Embed in the active collection and
+// records the collection name and vector id on each chunk. Vectors left
+// over from a previous run for the same document are removed first, since
+// re-chunking gives chunks new ids. The document is ready once it finishes;
+// the work directory is removed only after that is stored, and a retry that
+// finds the vectors file gone embeds the chunks again.
+func (p *Pipeline) Index(ctx context.Context, doc *model.Document) error {
+	c := p.q.Chunk
+	chunks, err := c.WithContext(ctx).Where(c.DocumentID.Eq(doc.ID)).Find()
+	if err != nil {
+		return fmt.Errorf("load chunks for document %d: %w", doc.ID, err)
+	}
+	collection, embedder, err := p.collections.ActiveCollection(ctx)
+	if err != nil {
+		return err
+	}
+	in, err := p.vectors(doc)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if in == nil || !in.matches(collection, chunks) {
+		// The vectors file is gone, or the document was re-chunked or the
+		// active collection changed since Embed ran, so the stored vectors
+		// no longer fit.
+		if err := p.Embed(ctx, doc); err != nil {
+			return err
+		}
+		if in, err = p.vectors(doc); err != nil {
+			return err
+		}
+	}
+
+	if err := embedding.EnsureCollection(ctx, p.store, in.Collection, embedder); err != nil {
+		return err
+	}
+	if err := p.store.DeleteByDocument(ctx, in.Collection, doc.ID); err != nil {
+		return err
+	}
+
+	if len(in.Chunks) > 0 {
+		records := make([]vectorstore.Record, len(in.Chunks))
+		ids := make([]int64, len(in.Chunks))
+		for i, ec := range in.Chunks {
+			records[i] = vectorstore.Record{ID: ec.ChunkID, UserID: doc.UserID, DocumentID: doc.ID, Vector: ec.Vector}
+			ids[i] = ec.ChunkID
+		}
+		if err := p.store.Upsert(ctx, in.Collection, records); err != nil {
+			return err
+		}
+		_, err = c.WithContext(ctx).Where(c.ID.In(ids...)).UpdateSimple(c.MilvusCollection.Value(in.Collection), c.MilvusID.SetCol(c.ID))
+		if err != nil {
+			return fmt.Errorf("record vector ids for document %d: %w", doc.ID, err)
+		}
+	}
+
+	if err := p.setStatus(ctx, doc, StatusReady, nil); err != nil {
+		return err
+	}
+	// The document is ready, so a leftover work directory is only wasted disk.
+	if err := os.RemoveAll(p.docWorkDir(doc)); err != nil {
+		logger.Error(err, "clean up work dir of document %d", doc.ID)
+	}
+	return nil
+}
+
+func (p *Pipeline) vectors(doc *model.Document) (*embeddedDocument, error) {
+	var in embeddedDocument
+	if err := p.readWork(doc, vectorsFile, &in); err != nil {
+		return nil, err
+	}
+	return &in, nil
+}
+
+// matches reports whether d holds one vector for collection for each of
+// chunks.
+func (d *embeddedDocument) matches(collection string, chunks []*model.Chunk) bool {
+	if d.Collection != collection || len(d.Chunks) != len(chunks) {
+		return false
+	}
+	current := make(map[int64]string, len(chunks))
+	for _, ch := range chunks {
+		current[ch.ID] = ch.ContentHash
+	}
+	for _, ec := range d.Chunks {
+		if hash, ok := current[ec.ChunkID]; !ok || hash != ec.ContentHash {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+package ingest
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/jobqueue"
+	"ai-learn-english/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Job kinds for the ingestion stages, in the order they run. Vocabulary
+// extraction runs next to embedding once the chunks exist.
+const (
+	KindExtract    = "extract_document"
+	KindChunk      = "chunk_document"
+	KindEmbed      = "embed_chunks"
+	KindIndex      = "index_milvus"
+	KindVocabulary = "extract_vocabulary"
+)
+
+// documentPayload is the payload of every ingestion job.
+type documentPayload struct {
+	DocumentID int64 `json:"document_id"`
+}
+
+// Scheduler queues the ingestion of uploaded documents.
+type Scheduler struct {
+	queue *jobqueue.Queue
+}
+
+func NewScheduler(queue *jobqueue.Queue) *Scheduler {
+	return &Scheduler{queue: queue}
+}
+
+// Schedule enqueues the first stage for doc; each stage enqueues the next
+// one when it succeeds.
+func (s *Scheduler) Schedule(ctx context.Context, doc *model.Document) error {
+	_, err := s.queue.Enqueue(ctx, KindExtract, documentPayload{DocumentID: doc.ID})
+	return err
+}
+
+// ScheduleVocabulary enqueues vocabulary extraction for the document with
+// id on its own, for documents ingested before it existed.
+func (s *Scheduler) ScheduleVocabulary(ctx context.Context, documentID int64) error {
+	_, err := s.queue.Enqueue(ctx, KindVocabulary, documentPayload{DocumentID: documentID})
+	return err
+}
+
+// RegisterHandlers wires the ingestion stages of p into w.
+func RegisterHandlers(w *jobqueue.Worker, queue *jobqueue.Queue, p *Pipeline) {
+	w.Handle(KindExtract, p.handler(queue, p.Extract, KindChunk))
+	w.Handle(KindChunk, p.handler(queue, p.Chunk, KindEmbed, KindVocabulary))
+	w.Handle(KindEmbed, p.handler(queue, p.Embed, KindIndex))
+	w.Handle(KindIndex, p.handler(queue, p.Index))
+	w.Handle(KindVocabulary, p.vocabularyHandler())
+}
+
+// handler loads the job's document, runs stage on it and enqueues next.
+// Jobs for documents deleted or superseded in the meantime succeed without
+// doing anything. When the job will not be retried the document is marked
+// failed with the error as its reason.
+func (p *Pipeline) handler(queue *jobqueue.Queue, stage func(context.Context, *model.Document) error, next ...string) jobqueue.Handler {
+	return func(ctx context.Context, job *model.Job) error {
+		doc, payload, err := p.load(ctx, job)
+		if doc == nil || err != nil {
+			return err
+		}
+
+		err = stage(ctx, doc)
+		if errors.Is(err, ErrSuperseded) {
+			logger.Info("job %d (%s): %v", job.ID, job.Kind, err)
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() == nil && (jobqueue.LastAttempt(job) || jobqueue.IsPermanent(err)) {
+				if failErr := p.Fail(context.WithoutCancel(ctx), doc, err); failErr != nil {
+					logger.Error(failErr, "mark document %d failed", doc.ID)
+				}
+			}
+			return err
+		}
+		for _, kind := range next {
+			if _, err := queue.Enqueue(ctx, kind, payload); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// vocabularyHandler runs the vocabulary stage. Unlike the other stages its
+// failure leaves the document status alone.
+func (p *Pipeline) vocabularyHandler() jobqueue.Handler {
+	return func(ctx context.Context, job *model.Job) error {
+		doc, _, err := p.load(ctx, job)
+		if doc == nil || err != nil {
+			return err
+		}
+		return p.Vocabulary(ctx, doc)
+	}
+}
+
+// load returns the document of an ingestion job, or nil when it has been
+// deleted.
+func (p *Pipeline) load(ctx context.Context, job *model.Job) (*model.Document, documentPayload, error) {
+	var payload documentPayload
+	if err := jobqueue.Decode(job, &payload); err != nil {
+		return nil, payload, err
+	}
+	d := p.q.Document
+	doc, err := d.WithContext(ctx).Where(d.ID.Eq(payload.DocumentID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, payload, nil
+	}
+	if err != nil {
+		return nil, payload, fmt.Errorf("load document %d: %w", payload.DocumentID, err)
+	}
+	return doc, payload, nil
+}
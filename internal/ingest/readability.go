@@ -0,0 +1,97 @@
+package ingest
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/pkg/readability"
+	"context"
+	"fmt"
+
+	"gorm.io/gen/field"
+)
+
+// scoreChunks sets the readability of chunks and returns the counts of the
+// document they make up. Overlapping chunks count the overlap twice, which
+// barely moves the document's ratios.
+func scoreChunks(chunks []*model.Chunk) readability.Counts {
+	var total readability.Counts
+	for _, c := range chunks {
+		counts := readability.Measure(c.Content)
+		total = total.Add(counts)
+		score, ok := counts.Score()
+		c.FleschKincaidGrade, c.DaleChallScore, c.AvgSentenceLength, c.RareWordRatio, c.CefrLevel = scoreFields(score, ok)
+	}
+	return total
+}
+
+// scoreDocument sets the readability of doc from the counts of its chunks
+// and returns the assignments that store it.
+func scoreDocument(q *query.Query, doc *model.Document, counts readability.Counts) []field.AssignExpr {
+	score, ok := counts.Score()
+	doc.FleschKincaidGrade, doc.DaleChallScore, doc.AvgSentenceLength, doc.RareWordRatio, doc.CefrLevel = scoreFields(score, ok)
+	t := q.Document
+	return scoreAssigns(t.FleschKincaidGrade, t.DaleChallScore, t.AvgSentenceLength, t.RareWordRatio, t.CefrLevel,
+		doc.FleschKincaidGrade, doc.DaleChallScore, doc.AvgSentenceLength, doc.RareWordRatio, doc.CefrLevel)
+}
+
+// ScoreReadability scores the current chunks of a document and the
+// document itself, e.g. for documents chunked before readability was
+// scored.
+func ScoreReadability(ctx context.Context, q *query.Query, doc *model.Document) error {
+	c := q.Chunk
+	chunks, err := c.WithContext(ctx).Where(c.DocumentID.Eq(doc.ID)).Find()
+	if err != nil {
+		return fmt.Errorf("load chunks for document %d: %w", doc.ID, err)
+	}
+	total := scoreChunks(chunks)
+
+	err = q.Transaction(func(tx *query.Query) error {
+		c := tx.Chunk
+		for _, ch := range chunks {
+			_, err := c.WithContext(ctx).Where(c.ID.Eq(ch.ID)).UpdateSimple(scoreAssigns(
+				c.FleschKincaidGrade, c.DaleChallScore, c.AvgSentenceLength, c.RareWordRatio, c.CefrLevel,
+				ch.FleschKincaidGrade, ch.DaleChallScore, ch.AvgSentenceLength, ch.RareWordRatio, ch.CefrLevel,
+			)...)
+			if err != nil {
+				return err
+			}
+		}
+		d := tx.Document
+		_, err := d.WithContext(ctx).Where(d.ID.Eq(doc.ID)).UpdateSimple(scoreDocument(tx, doc, total)...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("save readability of document %d: %w", doc.ID, err)
+	}
+	return nil
+}
+
+// scoreFields returns the column values of score, all nil when the text
+// had no words to score.
+func scoreFields(score readability.Score, ok bool) (grade, daleChall, sentenceLength, rareRatio *float64, level *string) {
+	if !ok {
+		return nil, nil, nil, nil, nil
+	}
+	return &score.FleschKincaidGrade, &score.DaleChall, &score.AvgSentenceLength, &score.RareWordRatio, &score.Level
+}
+
+func scoreAssigns(grade, daleChall, sentenceLength, rareRatio field.Float64, level field.String,
+	gradeV, daleChallV, sentenceLengthV, rareRatioV *float64, levelV *string) []field.AssignExpr {
+	out := make([]field.AssignExpr, 0, 5)
+	for _, f := range []struct {
+		col field.Float64
+		v   *float64
+	}{{grade, gradeV}, {daleChall, daleChallV}, {sentenceLength, sentenceLengthV}, {rareRatio, rareRatioV}} {
+		if f.v == nil {
+			out = append(out, f.col.Null())
+		} else {
+			out = append(out, f.col.Value(*f.v))
+		}
+	}
+	if levelV == nil {
+		out = append(out, level.Null())
+	} else {
+		out = append(out, level.Value(*levelV))
+	}
+	return out
+}
@@ -0,0 +1,93 @@
+package ingest
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// Document processing states, stored in documents.status.
+const (
+	StatusUploaded   = "uploaded"
+	StatusExtracting = "extracting"
+	StatusChunking   = "chunking"
+	StatusEmbedding  = "embedding"
+	StatusReady      = "ready"
+	StatusFailed     = "failed"
+)
+
+// transitions lists the states a document may move to from each state.
+// Stages may re-enter their own state because jobs are retried.
+var transitions = map[string][]string{
+	StatusUploaded:   {StatusExtracting, StatusFailed},
+	StatusExtracting: {StatusExtracting, StatusChunking, StatusFailed},
+	StatusChunking:   {StatusChunking, StatusEmbedding, StatusFailed},
+	StatusEmbedding:  {StatusEmbedding, StatusReady, StatusFailed},
+	StatusReady:      {StatusUploaded},
+	StatusFailed:     {StatusUploaded},
+}
+
+// ErrSuperseded is returned by a stage when the document has moved on to a
+// state the stage cannot start from, e.g. a stage job that is re-run after
+// the next stage already started. The job has nothing left to do.
+var ErrSuperseded = errors.New("document has moved past this stage")
+
+// Terminal reports whether status is final for the current processing run.
+func Terminal(status string) bool {
+	return status == StatusReady || status == StatusFailed
+}
+
+// allowed reports whether the state machine permits from -> to.
+func allowed(from, to string) bool {
+	for _, dst := range transitions[from] {
+		if dst == to {
+			return true
+		}
+	}
+	return false
+}
+
+// setStatus moves doc to status, rejecting transitions the state machine
+// does not allow with ErrSuperseded. reason is only kept for failures.
+func (p *Pipeline) setStatus(ctx context.Context, doc *model.Document, status string, reason *string) error {
+	now := time.Now()
+	err := p.q.Transaction(func(tx *query.Query) error {
+		d := tx.Document
+		cur, err := d.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select(d.Status).Where(d.ID.Eq(doc.ID)).First()
+		if err != nil {
+			return err
+		}
+		if !allowed(cur.Status, status) {
+			return fmt.Errorf("%w: document %d cannot move from %s to %s", ErrSuperseded, doc.ID, cur.Status, status)
+		}
+		reasonCol := d.StatusReason.Null()
+		if reason != nil {
+			reasonCol = d.StatusReason.Value(*reason)
+		}
+		_, err = d.WithContext(ctx).Where(d.ID.Eq(doc.ID)).
+			UpdateSimple(d.Status.Value(status), reasonCol, d.StatusUpdatedAt.Value(now))
+		return err
+	})
+	if errors.Is(err, ErrSuperseded) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("set status of document %d to %s: %w", doc.ID, status, err)
+	}
+	doc.Status = status
+	doc.StatusReason = reason
+	doc.StatusUpdatedAt = &now
+	return nil
+}
+
+// Fail marks doc as failed with the reason shown to the learner.
+func (p *Pipeline) Fail(ctx context.Context, doc *model.Document, cause error) error {
+	reason := cause.Error()
+	return p.setStatus(ctx, doc, StatusFailed, &reason)
+}
@@ -0,0 +1,64 @@
+package ingest
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/pkg/vocab"
+	"context"
+	"fmt"
+	"time"
+)
+
+// Vocabulary replaces the study vocabulary of doc with the words found in
+// its current chunks. It does not change the document status: a document
+// is usable without vocabulary, and the stage can run again at any time,
+// e.g. after the word list changes.
+func (p *Pipeline) Vocabulary(ctx context.Context, doc *model.Document) error {
+	c := p.q.Chunk
+	chunks, err := c.WithContext(ctx).Where(c.DocumentID.Eq(doc.ID)).Order(c.ChunkIndex).Find()
+	if err != nil {
+		return fmt.Errorf("load chunks for document %d: %w", doc.ID, err)
+	}
+	sources := make([]vocab.Source, len(chunks))
+	for i, ch := range chunks {
+		sources[i] = vocab.Source{ChunkID: ch.ID, PageIndex: ch.PageIndex, Text: ch.Content}
+	}
+
+	candidates := vocab.Extract(sources)
+	rows := make([]*model.DocumentVocabulary, len(candidates))
+	for i, cand := range candidates {
+		chunkID := cand.ChunkID
+		rows[i] = &model.DocumentVocabulary{
+			DocumentID:    doc.ID,
+			Lemma:         cand.Lemma,
+			Form:          cand.Form,
+			Occurrences:   int32(cand.Occurrences),
+			FrequencyBand: int32(cand.Band),
+			CefrLevel:     cand.Level,
+			Example:       cand.Example,
+			ChunkID:       &chunkID,
+			PageIndex:     cand.PageIndex,
+		}
+	}
+
+	now := time.Now()
+	err = p.q.Transaction(func(tx *query.Query) error {
+		v := tx.DocumentVocabulary
+		if _, err := v.WithContext(ctx).Where(v.DocumentID.Eq(doc.ID)).Delete(); err != nil {
+			return err
+		}
+		if len(rows) > 0 {
+			if err := v.WithContext(ctx).CreateInBatches(rows, 500); err != nil {
+				return err
+			}
+		}
+		d := tx.Document
+		_, err := d.WithContext(ctx).Where(d.ID.Eq(doc.ID)).UpdateSimple(d.VocabularyAt.Value(now))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("save vocabulary for document %d: %w", doc.ID, err)
+	}
+	doc.VocabularyAt = &now
+	return nil
+}
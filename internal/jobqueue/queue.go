@@ -0,0 +1,269 @@
+// Package jobqueue is a database-backed job queue. Workers lease jobs for a
+// limited time and extend the lease with heartbeats, so a job held by a
+// worker that crashed becomes available again once its lease runs out.
+package jobqueue
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Job states. A failed job is waiting for its next attempt; a dead job has
+// used all of its attempts and is only retried by hand.
+const (
+	StateQueued    = "queued"
+	StateRunning   = "running"
+	StateSucceeded = "succeeded"
+	StateFailed    = "failed"
+	StateDead      = "dead"
+)
+
+const (
+	defaultMaxAttempts = 5
+	baseBackoff        = 10 * time.Second
+	maxBackoff         = time.Hour
+	maxErrorLen        = 4000
+)
+
+// ErrLeaseLost is returned when a worker touches a job whose lease has
+// expired and was taken over by another worker.
+var ErrLeaseLost = errors.New("job lease lost")
+
+// permanentError marks a failure that retrying cannot fix.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so that the job fails straight to dead instead of
+// being retried.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was wrapped with Permanent.
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// Queue enqueues and leases jobs stored in the jobs table.
+type Queue struct {
+	q   *query.Query
+	now func() time.Time
+}
+
+func New(q *query.Query) *Queue {
+	return &Queue{q: q, now: time.Now}
+}
+
+// EnqueueOptions tweak a single Enqueue call.
+type EnqueueOptions struct {
+	// RunAt delays the first attempt; zero means now.
+	RunAt time.Time
+	// MaxAttempts defaults to 5.
+	MaxAttempts int
+}
+
+// Enqueue stores a new job of the given kind with payload encoded as JSON.
+func (qu *Queue) Enqueue(ctx context.Context, kind string, payload any, opts ...EnqueueOptions) (*model.Job, error) {
+	var o EnqueueOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.RunAt.IsZero() {
+		o.RunAt = qu.now()
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = defaultMaxAttempts
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s payload: %w", kind, err)
+	}
+	job := &model.Job{
+		Kind:        kind,
+		Payload:     string(raw),
+		State:       StateQueued,
+		MaxAttempts: int32(o.MaxAttempts),
+		RunAt:       o.RunAt,
+	}
+	if err := qu.q.Job.WithContext(ctx).Create(job); err != nil {
+		return nil, fmt.Errorf("enqueue %s: %w", kind, err)
+	}
+	return job, nil
+}
+
+// Lease claims the next runnable job of one of kinds for worker and holds it
+// for lease. Runnable jobs are queued or failed jobs whose run_at has passed,
+// and running jobs whose lease expired. It returns nil when nothing is due.
+func (qu *Queue) Lease(ctx context.Context, worker string, kinds []string, lease time.Duration) (*model.Job, error) {
+	var leased *model.Job
+	err := qu.q.Transaction(func(tx *query.Query) error {
+		j := tx.Job
+		now := qu.now()
+		due := j.WithContext(ctx).
+			Where(j.State.In(StateQueued, StateFailed), j.RunAt.Lte(now)).
+			Or(j.State.Eq(StateRunning), j.LeaseExpiresAt.Lt(now))
+		job, err := j.WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: clause.LockingOptionsSkipLocked}).
+			Where(j.Kind.In(kinds...)).
+			Where(due).
+			Order(j.RunAt, j.ID).
+			First()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		expires := now.Add(lease)
+		_, err = j.WithContext(ctx).Where(j.ID.Eq(job.ID)).UpdateSimple(
+			j.State.Value(StateRunning),
+			j.Attempts.Add(1),
+			j.LockedBy.Value(worker),
+			j.LeaseExpiresAt.Value(expires),
+		)
+		if err != nil {
+			return err
+		}
+		job.State = StateRunning
+		job.Attempts++
+		job.LockedBy = &worker
+		job.LeaseExpiresAt = &expires
+		leased = job
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lease job: %w", err)
+	}
+	return leased, nil
+}
+
+// Heartbeat extends the lease worker holds on job.
+func (qu *Queue) Heartbeat(ctx context.Context, job *model.Job, worker string, lease time.Duration) error {
+	j := qu.q.Job
+	expires := qu.now().Add(lease)
+	info, err := qu.owned(ctx, job, worker).UpdateSimple(j.LeaseExpiresAt.Value(expires))
+	if err != nil {
+		return fmt.Errorf("heartbeat job %d: %w", job.ID, err)
+	}
+	if info.RowsAffected == 0 {
+		return ErrLeaseLost
+	}
+	job.LeaseExpiresAt = &expires
+	return nil
+}
+
+// Complete marks job as succeeded.
+func (qu *Queue) Complete(ctx context.Context, job *model.Job, worker string) error {
+	return qu.finish(ctx, job, worker, StateSucceeded, qu.now(), nil)
+}
+
+// Fail records cause on job and schedules another attempt with exponential
+// backoff, or marks the job dead once it has used all of its attempts or
+// cause is permanent.
+func (qu *Queue) Fail(ctx context.Context, job *model.Job, worker string, cause error) error {
+	msg := cause.Error()
+	if len(msg) > maxErrorLen {
+		msg = msg[:maxErrorLen]
+	}
+	if LastAttempt(job) || IsPermanent(cause) {
+		return qu.finish(ctx, job, worker, StateDead, qu.now(), &msg)
+	}
+	return qu.finish(ctx, job, worker, StateFailed, qu.now().Add(Backoff(int(job.Attempts))), &msg)
+}
+
+// LastAttempt reports whether a failure of the current attempt of job makes
+// it dead.
+func LastAttempt(job *model.Job) bool {
+	return job.Attempts >= job.MaxAttempts
+}
+
+// Release hands job back to the queue without counting the attempt, for
+// workers that are shutting down.
+func (qu *Queue) Release(ctx context.Context, job *model.Job, worker string) error {
+	j := qu.q.Job
+	info, err := qu.owned(ctx, job, worker).UpdateSimple(
+		j.State.Value(StateQueued),
+		j.Attempts.Sub(1),
+		j.LockedBy.Null(),
+		j.LeaseExpiresAt.Null(),
+	)
+	if err != nil {
+		return fmt.Errorf("release job %d: %w", job.ID, err)
+	}
+	if info.RowsAffected == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (qu *Queue) finish(ctx context.Context, job *model.Job, worker, state string, runAt time.Time, lastError *string) error {
+	j := qu.q.Job
+	lastErr := j.LastError.Null()
+	if lastError != nil {
+		lastErr = j.LastError.Value(*lastError)
+	}
+	info, err := qu.owned(ctx, job, worker).UpdateSimple(
+		j.State.Value(state),
+		j.RunAt.Value(runAt),
+		j.LockedBy.Null(),
+		j.LeaseExpiresAt.Null(),
+		lastErr,
+	)
+	if err != nil {
+		return fmt.Errorf("mark job %d %s: %w", job.ID, state, err)
+	}
+	if info.RowsAffected == 0 {
+		return ErrLeaseLost
+	}
+	job.State = state
+	job.RunAt = runAt
+	job.LastError = lastError
+	return nil
+}
+
+// owned scopes an update to job while worker still holds its lease.
+func (qu *Queue) owned(ctx context.Context, job *model.Job, worker string) query.IJobDo {
+	j := qu.q.Job
+	return j.WithContext(ctx).Where(j.ID.Eq(job.ID), j.State.Eq(StateRunning), j.LockedBy.Eq(worker))
+}
+
+// Backoff returns the delay before the attempt following attempt: base
+// doubled per attempt, capped, with the upper half jittered so jobs that
+// failed together do not retry together.
+func Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := maxBackoff
+	if attempt < 20 {
+		d = min(baseBackoff<<(attempt-1), maxBackoff)
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// Decode unmarshals the payload of job into v.
+func Decode(job *model.Job, v any) error {
+	if err := json.Unmarshal([]byte(job.Payload), v); err != nil {
+		return fmt.Errorf("decode %s payload of job %d: %w", job.Kind, job.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package jobqueue
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Handler runs one job. Returning an error fails the attempt; handlers must
+// be safe to run again for the same job since a job can be retried after a
+// crash or an expired lease.
+type Handler func(ctx context.Context, job *model.Job) error
+
+// WorkerOptions configure a Worker. Zero values fall back to defaults.
+type WorkerOptions struct {
+	// ID identifies the worker in jobs.locked_by.
+	ID           string
+	Concurrency  int
+	PollInterval time.Duration
+	Lease        time.Duration
+}
+
+// Worker leases jobs from a Queue and dispatches them to the handler
+// registered for their kind.
+type Worker struct {
+	queue    *Queue
+	opts     WorkerOptions
+	handlers map[string]Handler
+}
+
+func NewWorker(queue *Queue, opts WorkerOptions) *Worker {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = time.Minute
+	}
+	return &Worker{queue: queue, opts: opts, handlers: make(map[string]Handler)}
+}
+
+// Handle registers h for jobs of kind.
+func (w *Worker) Handle(kind string, h Handler) {
+	w.handlers[kind] = h
+}
+
+// Run processes jobs until ctx is cancelled and then waits for the jobs in
+// flight, which are handed back to the queue if they were interrupted.
+func (w *Worker) Run(ctx context.Context) error {
+	kinds := make([]string, 0, len(w.handlers))
+	for kind := range w.handlers {
+		kinds = append(kinds, kind)
+	}
+	if len(kinds) == 0 {
+		return errors.New("jobqueue: no handlers registered")
+	}
+	sort.Strings(kinds)
+
+	slots := make(chan struct{}, w.opts.Concurrency)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case slots <- struct{}{}:
+		}
+
+		job, err := w.queue.Lease(ctx, w.opts.ID, kinds, w.opts.Lease)
+		if err != nil && ctx.Err() == nil {
+			logger.Error(err, "worker %s", w.opts.ID)
+		}
+		if job == nil {
+			<-slots
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(w.opts.PollInterval):
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			w.process(ctx, job)
+		}()
+	}
+}
+
+// process runs job while a heartbeat keeps its lease alive, then records the
+// outcome. Bookkeeping uses a context detached from ctx so that it still
+// happens during shutdown.
+func (w *Worker) process(ctx context.Context, job *model.Job) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go w.heartbeat(jobCtx, cancel, job)
+
+	err := w.run(jobCtx, job)
+	cancel()
+
+	bg := context.WithoutCancel(ctx)
+	switch {
+	case err == nil:
+		err = w.queue.Complete(bg, job, w.opts.ID)
+	case ctx.Err() != nil:
+		logger.Info("job %d (%s) interrupted by shutdown", job.ID, job.Kind)
+		err = w.queue.Release(bg, job, w.opts.ID)
+	default:
+		logger.Error(err, "job %d (%s) attempt %d/%d", job.ID, job.Kind, job.Attempts, job.MaxAttempts)
+		err = w.queue.Fail(bg, job, w.opts.ID, err)
+	}
+	if errors.Is(err, ErrLeaseLost) {
+		logger.Warn("job %d (%s) was taken over by another worker", job.ID, job.Kind)
+	} else if err != nil {
+		logger.Error(err, "record outcome of job %d", job.ID)
+	}
+}
+
+func (w *Worker) run(ctx context.Context, job *model.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return w.handlers[job.Kind](ctx, job)
+}
+
+// heartbeat extends the lease every third of its length and cancels the job
+// when the lease was lost to another worker.
+func (w *Worker) heartbeat(ctx context.Context, cancel context.CancelFunc, job *model.Job) {
+	ticker := time.NewTicker(w.opts.Lease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		err := w.queue.Heartbeat(ctx, job, w.opts.ID, w.opts.Lease)
+		if errors.Is(err, ErrLeaseLost) {
+			cancel()
+			return
+		}
+		if err != nil && ctx.Err() == nil {
+			logger.Error(err, "heartbeat job %d", job.ID)
+		}
+	}
+}
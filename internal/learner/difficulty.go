@@ -0,0 +1,27 @@
+package learner
+
+import (
+	"ai-learn-english/pkg/vocab"
+	"slices"
+)
+
+// Distance returns how many CEFR levels a text of level lies outside the
+// band the learner reads best in: their own level and the one above it.
+// It is 0 when the text is in the band or either level is unknown.
+func (p *Profile) Distance(level *string) int {
+	if p == nil || p.CEFRLevel == nil || level == nil {
+		return 0
+	}
+	own := slices.Index(vocab.Levels, *p.CEFRLevel)
+	text := slices.Index(vocab.Levels, *level)
+	switch {
+	case own < 0 || text < 0:
+		return 0
+	case text < own:
+		return own - text
+	case text > own+1:
+		return text - own - 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,41 @@
+package learner
+
+import "testing"
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		own, text *string
+		want      int
+	}{
+		{str("B1"), str("B1"), 0},
+		{str("B1"), str("B2"), 0}, // one level up is still comfortable
+		{str("B1"), str("C1"), 1},
+		{str("B1"), str("C2"), 2},
+		{str("B1"), str("A2"), 1},
+		{str("B1"), str("A1"), 2},
+		{str("A1"), str("C2"), 4},
+		{str("C2"), str("A1"), 5},
+		{str("C2"), str("C2"), 0},
+		{nil, str("C2"), 0},
+		{str("B1"), nil, 0},
+		{str("B1"), str("X1"), 0},
+		{str("b1"), str("C2"), 0},
+	}
+	for _, tt := range tests {
+		p := &Profile{CEFRLevel: tt.own}
+		if got := p.Distance(tt.text); got != tt.want {
+			t.Errorf("Distance(%v, %v) = %d, want %d", deref(tt.own), deref(tt.text), got, tt.want)
+		}
+	}
+	var none *Profile
+	if got := none.Distance(str("C2")); got != 0 {
+		t.Errorf("nil profile: Distance = %d", got)
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return "nil"
+	}
+	return *s
+}
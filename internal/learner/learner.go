@@ -0,0 +1,104 @@
+// Package learner describes who the learner is, so that the teacher can
+// pitch its English, examples and explanation language at them.
+package learner
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Study goals.
+const (
+	GoalIELTS        = "ielts"
+	GoalTOEIC        = "toeic"
+	GoalBusiness     = "business"
+	GoalTravel       = "travel"
+	GoalAcademic     = "academic"
+	GoalConversation = "conversation"
+)
+
+// Goals lists the study goals a profile can hold.
+var Goals = []string{GoalIELTS, GoalTOEIC, GoalBusiness, GoalTravel, GoalAcademic, GoalConversation}
+
+// Languages maps the ISO 639-1 codes a profile can use for the native and
+// explanation languages to their English names.
+var Languages = map[string]string{
+	"en": "English",
+	"vi": "Vietnamese",
+	"zh": "Chinese",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"th": "Thai",
+	"fr": "French",
+	"es": "Spanish",
+	"de": "German",
+}
+
+// Profile is a learner profile with its lists decoded. Fields the learner
+// has not filled in are nil or empty.
+type Profile struct {
+	CEFRLevel           *string    `json:"cefr_level"`
+	NativeLanguage      *string    `json:"native_language"`
+	ExplanationLanguage *string    `json:"explanation_language"`
+	Goals               []string   `json:"goals"`
+	Interests           []string   `json:"interests"`
+	PlacementSessionID  *int64     `json:"placement_session_id"`
+	UpdatedAt           *time.Time `json:"updated_at"`
+}
+
+// FromModel decodes a learner_profiles row. A nil row gives an empty
+// profile.
+func FromModel(m *model.LearnerProfile) *Profile {
+	p := &Profile{Goals: []string{}, Interests: []string{}}
+	if m == nil {
+		return p
+	}
+	p.CEFRLevel = m.CefrLevel
+	p.NativeLanguage = m.NativeLanguage
+	p.ExplanationLanguage = m.ExplanationLanguage
+	p.PlacementSessionID = m.PlacementSessionID
+	p.UpdatedAt = m.UpdatedAt
+	decodeList(m.Goals, &p.Goals)
+	decodeList(m.Interests, &p.Interests)
+	return p
+}
+
+// EncodeList encodes a list column; empty lists are stored as NULL.
+func EncodeList(list []string) *string {
+	if len(list) == 0 {
+		return nil
+	}
+	raw, _ := json.Marshal(list)
+	s := string(raw)
+	return &s
+}
+
+// decodeList leaves out untouched when the column is NULL or malformed.
+func decodeList(raw *string, out *[]string) {
+	if raw == nil {
+		return
+	}
+	var list []string
+	if json.Unmarshal([]byte(*raw), &list) == nil {
+		*out = list
+	}
+}
+
+// Load returns the user's profile, empty when the user has none yet.
+func Load(ctx context.Context, q *query.Query, userID int64) (*Profile, error) {
+	lp := q.LearnerProfile
+	m, err := lp.WithContext(ctx).Where(lp.UserID.Eq(userID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return FromModel(nil), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return FromModel(m), nil
+}
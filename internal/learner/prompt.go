@@ -0,0 +1,67 @@
+package learner
+
+import (
+	"ai-learn-english/pkg/vocab"
+	"fmt"
+	"strings"
+)
+
+// levelStyles tells the teacher how to write for each CEFR level.
+var levelStyles = map[string]string{
+	vocab.A1: "The learner is a beginner (CEFR A1). Use very simple English: short sentences, only the most common everyday words, no idioms or phrasal verbs. Give one short example at a time.",
+	vocab.A2: "The learner is elementary (CEFR A2). Use simple English with short sentences and common words. Avoid idioms, and explain any grammar term you use in plain words.",
+	vocab.B1: "The learner is intermediate (CEFR B1). Use clear everyday English. When you use a less common word, give a short definition.",
+	vocab.B2: "The learner is upper intermediate (CEFR B2). Use natural English and introduce useful less common words and collocations with a brief explanation.",
+	vocab.C1: "The learner is advanced (CEFR C1). Use natural, precise English and discuss nuance, register and idiomatic usage where it helps.",
+	vocab.C2: "The learner is proficient (CEFR C2). Write as you would to an educated native speaker and focus on subtle differences in meaning, style and register.",
+}
+
+var goalContexts = map[string]string{
+	GoalIELTS:        "the IELTS exam",
+	GoalTOEIC:        "the TOEIC exam",
+	GoalBusiness:     "business and the workplace",
+	GoalTravel:       "travel",
+	GoalAcademic:     "academic study",
+	GoalConversation: "everyday conversation",
+}
+
+// Instructions returns the part of the teacher's system prompt that adapts
+// it to p, or "" when the profile says nothing useful.
+func (p *Profile) Instructions() string {
+	var lines []string
+	level := ""
+	if p.CEFRLevel != nil {
+		level = *p.CEFRLevel
+	}
+	if style, ok := levelStyles[level]; ok {
+		lines = append(lines, style)
+	}
+
+	if name, ok := languageName(p.ExplanationLanguage); ok && *p.ExplanationLanguage != "en" {
+		lines = append(lines, fmt.Sprintf("Write your explanations in %s. Keep English examples, quotes and the words being taught in English, and translate examples into %s.", name, name))
+	} else if name, ok := languageName(p.NativeLanguage); ok && *p.NativeLanguage != "en" && p.ExplanationLanguage == nil && (level == vocab.A1 || level == vocab.A2) {
+		lines = append(lines, fmt.Sprintf("The learner's native language is %s. When a point is hard, add a short explanation in %s.", name, name))
+	}
+
+	var goals []string
+	for _, g := range p.Goals {
+		if c, ok := goalContexts[g]; ok {
+			goals = append(goals, c)
+		}
+	}
+	if len(goals) > 0 {
+		lines = append(lines, "The learner is studying English for "+strings.Join(goals, ", ")+". Prefer examples from these contexts.")
+	}
+	if len(p.Interests) > 0 {
+		lines = append(lines, "The learner is interested in "+strings.Join(p.Interests, ", ")+". Use these topics in examples when it is natural.")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func languageName(code *string) (string, bool) {
+	if code == nil {
+		return "", false
+	}
+	name, ok := Languages[*code]
+	return name, ok
+}
@@ -0,0 +1,88 @@
+package learner
+
+import (
+	"strings"
+	"testing"
+)
+
+func str(s string) *string { return &s }
+
+func TestInstructions(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile Profile
+		want    []string
+		not     []string
+	}{
+		{"empty", Profile{}, nil, nil},
+		{"unknown level", Profile{CEFRLevel: str("D1")}, nil, nil},
+		{
+			"Vietnamese beginner",
+			Profile{CEFRLevel: str("A1"), NativeLanguage: str("vi")},
+			[]string{"beginner (CEFR A1)", "native language is Vietnamese", "short explanation in Vietnamese"},
+			[]string{"Write your explanations in"},
+		},
+		{
+			"Vietnamese elementary",
+			Profile{CEFRLevel: str("A2"), NativeLanguage: str("vi")},
+			[]string{"elementary (CEFR A2)", "short explanation in Vietnamese"},
+			nil,
+		},
+		{
+			// Past A2 the teacher stays in English unless asked otherwise.
+			"Vietnamese intermediate",
+			Profile{CEFRLevel: str("B1"), NativeLanguage: str("vi")},
+			[]string{"intermediate (CEFR B1)"},
+			[]string{"Vietnamese"},
+		},
+		{
+			"Vietnamese without a level",
+			Profile{NativeLanguage: str("vi")},
+			nil,
+			nil,
+		},
+		{
+			"English native beginner",
+			Profile{CEFRLevel: str("A1"), NativeLanguage: str("en")},
+			[]string{"beginner"},
+			[]string{"native language"},
+		},
+		{
+			"explanation language",
+			Profile{CEFRLevel: str("C1"), NativeLanguage: str("vi"), ExplanationLanguage: str("ja")},
+			[]string{"advanced (CEFR C1)", "Write your explanations in Japanese", "translate examples into Japanese"},
+			[]string{"Vietnamese"},
+		},
+		{
+			// Choosing English explanations turns off the native-language hint.
+			"English explanations for a beginner",
+			Profile{CEFRLevel: str("A1"), NativeLanguage: str("vi"), ExplanationLanguage: str("en")},
+			[]string{"beginner"},
+			[]string{"Vietnamese", "Write your explanations"},
+		},
+		{
+			"goals and interests",
+			Profile{Goals: []string{GoalIELTS, "unknown", GoalTravel}, Interests: []string{"football", "cooking"}},
+			[]string{"studying English for the IELTS exam, travel.", "interested in football, cooking."},
+			[]string{"unknown"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.profile.Instructions()
+			if tt.want == nil && got != "" {
+				t.Fatalf("Instructions() = %q, want none", got)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("Instructions() = %q, missing %q", got, w)
+				}
+			}
+			for _, n := range tt.not {
+				if strings.Contains(got, n) {
+					t.Errorf("Instructions() = %q, should not mention %q", got, n)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// FakeReply scripts one call to a Fake model. When Err is set the call
+// fails with it; for streams the Deltas are delivered first, which allows
+// simulating a failure in the middle of an answer. When Deltas is empty the
+// Content is streamed as a single delta.
+type FakeReply struct {
+	Content string
+	Deltas  []string
+	Err     error
+}
+
+// Fake is a scripted ChatModel for tests and local development. It returns
+// the scripted replies in order and records every request it receives.
+type Fake struct {
+	name string
+
+	mu       sync.Mutex
+	replies  []FakeReply
+	requests []Request
+}
+
+// ErrFakeExhausted is returned when a Fake runs out of scripted replies.
+var ErrFakeExhausted = errors.New("fake llm: no scripted reply left")
+
+func NewFake(name string, replies ...FakeReply) *Fake {
+	return &Fake{name: name, replies: replies}
+}
+
+func (f *Fake) Name() string { return "fake/" + f.name }
+
+// Script appends replies to the queue.
+func (f *Fake) Script(replies ...FakeReply) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.replies = append(f.replies, replies...)
+}
+
+// Requests returns the requests received so far.
+func (f *Fake) Requests() []Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Request(nil), f.requests...)
+}
+
+func (f *Fake) next(req Request) (FakeReply, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req)
+	if len(f.replies) == 0 {
+		return FakeReply{}, ErrFakeExhausted
+	}
+	r := f.replies[0]
+	f.replies = f.replies[1:]
+	return r, nil
+}
+
+func (f *Fake) Chat(ctx context.Context, req Request) (*Response, error) {
+	r, err := f.next(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	content := r.Content
+	if content == "" {
+		content = strings.Join(r.Deltas, "")
+	}
+	return &Response{Content: content, Model: f.name}, nil
+}
+
+func (f *Fake) Stream(ctx context.Context, req Request, onDelta func(delta string) error) (*Response, error) {
+	r, err := f.next(req)
+	if err != nil {
+		return nil, err
+	}
+	deltas := r.Deltas
+	if len(deltas) == 0 && r.Content != "" {
+		deltas = []string{r.Content}
+	}
+
+	res := &Response{Model: f.name}
+	var content strings.Builder
+	for _, d := range deltas {
+		if err := ctx.Err(); err != nil {
+			res.Content = content.String()
+			return res, err
+		}
+		if err := onDelta(d); err != nil {
+			res.Content = content.String()
+			return res, err
+		}
+		content.WriteString(d)
+	}
+	res.Content = content.String()
+	return res, r.Err
+}
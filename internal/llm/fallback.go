@@ -0,0 +1,66 @@
+package llm
+
+import (
+	"ai-learn-english/pkg/logger"
+	"context"
+	"errors"
+	"strings"
+)
+
+type fallback struct {
+	models []ChatModel
+}
+
+// Fallback returns a ChatModel that tries models in order and moves on to
+// the next one when a call fails with a retryable error. A stream only
+// fails over while no text has been delivered.
+func Fallback(models ...ChatModel) ChatModel {
+	if len(models) == 1 {
+		return models[0]
+	}
+	return &fallback{models: models}
+}
+
+func (f *fallback) Name() string {
+	names := make([]string, len(f.models))
+	for i, m := range f.models {
+		names[i] = m.Name()
+	}
+	return strings.Join(names, ",")
+}
+
+func (f *fallback) Chat(ctx context.Context, req Request) (*Response, error) {
+	var errs []error
+	for i, m := range f.models {
+		res, err := m.Chat(ctx, req)
+		if err == nil {
+			return res, nil
+		}
+		errs = append(errs, err)
+		if ctx.Err() != nil || !Retryable(err) || i == len(f.models)-1 {
+			break
+		}
+		logger.Warn("llm %s failed, falling back: %v", m.Name(), err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (f *fallback) Stream(ctx context.Context, req Request, onDelta func(delta string) error) (*Response, error) {
+	var errs []error
+	for i, m := range f.models {
+		delivered := false
+		res, err := m.Stream(ctx, req, func(delta string) error {
+			delivered = true
+			return onDelta(delta)
+		})
+		if err == nil {
+			return res, nil
+		}
+		errs = append(errs, err)
+		if delivered || ctx.Err() != nil || !Retryable(err) || i == len(f.models)-1 {
+			return res, errors.Join(errs...)
+		}
+		logger.Warn("llm %s failed, falling back: %v", m.Name(), err)
+	}
+	return nil, errors.Join(errs...)
+}
@@ -0,0 +1,93 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFallbackChat(t *testing.T) {
+	tests := []struct {
+		name       string
+		primaryErr error
+		fallback   bool
+	}{
+		{"5xx", statusErr(500), true},
+		{"429", statusErr(429), true},
+		{"timeout", context.DeadlineExceeded, true},
+		{"4xx", statusErr(400), false},
+		{"other", errors.New("bad reply"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := NewFake("primary", FakeReply{Err: tt.primaryErr})
+			secondary := NewFake("secondary", FakeReply{Content: "ok"})
+			res, err := Fallback(primary, secondary).Chat(context.Background(), Request{Temperature: 0.5})
+
+			if got := len(secondary.Requests()) > 0; got != tt.fallback {
+				t.Fatalf("secondary called = %v, want %v", got, tt.fallback)
+			}
+			if !tt.fallback {
+				if !errors.Is(err, tt.primaryErr) {
+					t.Errorf("err = %v, want the primary's error", err)
+				}
+				return
+			}
+			if err != nil || res.Content != "ok" || res.Model != "secondary" {
+				t.Errorf("got %+v, %v; want the secondary's reply", res, err)
+			}
+			if secondary.Requests()[0].Temperature != 0.5 {
+				t.Error("the secondary did not get the same request")
+			}
+		})
+	}
+}
+
+func TestFallbackChatAllFail(t *testing.T) {
+	first, second := statusErr(500), statusErr(503)
+	_, err := Fallback(NewFake("a", FakeReply{Err: first}), NewFake("b", FakeReply{Err: second})).Chat(context.Background(), Request{})
+	if !errors.Is(err, first) || !errors.Is(err, second) {
+		t.Errorf("err = %v, want both failures", err)
+	}
+}
+
+func TestFallbackStream(t *testing.T) {
+	tests := []struct {
+		name      string
+		primary   FakeReply
+		content   string
+		secondary bool
+		wantErr   bool
+	}{
+		{"fails over before any text", FakeReply{Err: statusErr(502)}, "xy", true, false},
+		{"keeps a failure after text", FakeReply{Deltas: []string{"a"}, Err: statusErr(502)}, "a", false, true},
+		{"keeps a 4xx", FakeReply{Err: statusErr(422)}, "", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := NewFake("primary", tt.primary)
+			secondary := NewFake("secondary", FakeReply{Deltas: []string{"x", "y"}})
+			var got string
+			_, err := Fallback(primary, secondary).Stream(context.Background(), Request{}, func(delta string) error {
+				got += delta
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if called := len(secondary.Requests()) > 0; called != tt.secondary {
+				t.Errorf("secondary called = %v, want %v", called, tt.secondary)
+			}
+			if got != tt.content {
+				t.Errorf("delivered %q, want %q", got, tt.content)
+			}
+		})
+	}
+}
+
+func TestFallbackSingleModel(t *testing.T) {
+	m := NewFake("only")
+	if Fallback(m) != ChatModel(m) {
+		t.Error("Fallback of one model should return it unwrapped")
+	}
+}
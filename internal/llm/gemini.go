@@ -0,0 +1,151 @@
+package llm
+
+import (
+	"ai-learn-english/pkg/httpjson"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const geminiBaseURL = "https://generativelanguage.googleapis.com/v1beta/models/"
+
+// Gemini is a ChatModel backed by the Gemini generateContent API.
+type Gemini struct {
+	key   string
+	model string
+}
+
+func NewGemini(key, model string) *Gemini {
+	return &Gemini{key: key, model: model}
+}
+
+func (g *Gemini) Name() string { return "gemini/" + g.model }
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiGenerationConfig struct {
+	Temperature        float64        `json:"temperature"`
+	MaxOutputTokens    int            `json:"maxOutputTokens,omitempty"`
+	ResponseMimeType   string         `json:"responseMimeType,omitempty"`
+	ResponseJSONSchema map[string]any `json:"responseJsonSchema,omitempty"`
+}
+
+type geminiRequest struct {
+	SystemInstruction *geminiContent         `json:"systemInstruction,omitempty"`
+	Contents          []geminiContent        `json:"contents"`
+	GenerationConfig  geminiGenerationConfig `json:"generationConfig"`
+}
+
+type geminiResponse struct {
+	ModelVersion string `json:"modelVersion"`
+	Candidates   []struct {
+		Content geminiContent `json:"content"`
+	} `json:"candidates"`
+}
+
+// text joins the parts of the first candidate.
+func (r *geminiResponse) text() string {
+	if len(r.Candidates) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, p := range r.Candidates[0].Content.Parts {
+		b.WriteString(p.Text)
+	}
+	return b.String()
+}
+
+func (g *Gemini) Chat(ctx context.Context, req Request) (*Response, error) {
+	var res geminiResponse
+	if err := httpjson.Post(ctx, httpClient, "gemini", g.endpoint("generateContent"), g.headers(), g.request(req), &res); err != nil {
+		return nil, err
+	}
+	if len(res.Candidates) == 0 {
+		return nil, errors.New("gemini: response has no candidates")
+	}
+	return &Response{Content: res.text(), Model: g.modelName(res.ModelVersion)}, nil
+}
+
+func (g *Gemini) Stream(ctx context.Context, req Request, onDelta func(delta string) error) (*Response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resp, err := httpjson.Do(ctx, httpClient, "gemini", g.endpoint("streamGenerateContent")+"?alt=sse", g.headers(), g.request(req))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	out := &Response{Model: g.model}
+	var content strings.Builder
+	err = readSSE(resp.Body, func(data string) error {
+		var chunk geminiResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("gemini: decode stream chunk: %w", err)
+		}
+		out.Model = g.modelName(chunk.ModelVersion)
+		delta := chunk.text()
+		if delta == "" {
+			return nil
+		}
+		if err := onDelta(delta); err != nil {
+			return err
+		}
+		content.WriteString(delta)
+		return nil
+	})
+	out.Content = content.String()
+	return out, err
+}
+
+func (g *Gemini) endpoint(method string) string {
+	return fmt.Sprintf("%s%s:%s", geminiBaseURL, url.PathEscape(g.model), method)
+}
+
+func (g *Gemini) headers() map[string]string {
+	return map[string]string{"x-goog-api-key": g.key}
+}
+
+func (g *Gemini) modelName(version string) string {
+	if version != "" {
+		return version
+	}
+	return g.model
+}
+
+// request converts req to the Gemini format: system messages become the
+// system instruction and the assistant role is called "model".
+func (g *Gemini) request(req Request) geminiRequest {
+	out := geminiRequest{
+		GenerationConfig: geminiGenerationConfig{Temperature: req.Temperature, MaxOutputTokens: req.MaxTokens},
+	}
+	if req.Schema != nil {
+		out.GenerationConfig.ResponseMimeType = "application/json"
+		out.GenerationConfig.ResponseJSONSchema = req.Schema.Schema
+	}
+	var system []geminiPart
+	for _, m := range req.Messages {
+		switch m.Role {
+		case RoleSystem:
+			system = append(system, geminiPart{Text: m.Content})
+		case RoleAssistant:
+			out.Contents = append(out.Contents, geminiContent{Role: "model", Parts: []geminiPart{{Text: m.Content}}})
+		default:
+			out.Contents = append(out.Contents, geminiContent{Role: "user", Parts: []geminiPart{{Text: m.Content}}})
+		}
+	}
+	if len(system) > 0 {
+		out.SystemInstruction = &geminiContent{Parts: system}
+	}
+	return out
+}
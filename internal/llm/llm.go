@@ -0,0 +1,108 @@
+// Package llm talks to the chat models that power the teacher.
+package llm
+
+import (
+	"ai-learn-english/config"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// httpClient has no Timeout: it would also bound reading the body and cut
+// every stream short. Deadlines come from the context, set per attempt by
+// WithRetry.
+var httpClient = &http.Client{}
+
+type Role string
+
+const (
+	RoleSystem    Role = "system"
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+)
+
+type Message struct {
+	Role    Role   `json:"role"`
+	Content string `json:"content"`
+}
+
+// Request is a provider independent chat completion request. When Schema
+// is set the model is asked to reply with a JSON object matching it.
+type Request struct {
+	Messages    []Message
+	Temperature float64
+	MaxTokens   int
+	Schema      *Schema
+}
+
+// Schema names a JSON schema for structured output. It should stay within
+// the subset both providers accept: every property listed as required,
+// additionalProperties false, and optional values typed as [T, "null"].
+// Providers treat it as a strong hint, so replies must still be validated.
+type Schema struct {
+	Name   string
+	Schema map[string]any
+}
+
+// StripFence removes a Markdown code fence some models put around the JSON
+// of a structured reply.
+func StripFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") {
+		return s
+	}
+	s = strings.TrimPrefix(s, "```")
+	s = strings.TrimPrefix(s, "json")
+	return strings.TrimSpace(strings.TrimSuffix(s, "```"))
+}
+
+type Response struct {
+	Content string
+	Model   string
+}
+
+// ChatModel generates the next assistant message for a conversation.
+type ChatModel interface {
+	// Name identifies the provider and model, e.g. "openai/gpt-4o-mini".
+	Name() string
+	Chat(ctx context.Context, req Request) (*Response, error)
+	// Stream generates the message like Chat but calls onDelta with each
+	// piece of text as the provider produces it. When onDelta returns an
+	// error the upstream request is abandoned and that error is returned.
+	Stream(ctx context.Context, req Request, onDelta func(delta string) error) (*Response, error)
+}
+
+// New builds the chat model selected by cfg.Provider, falling back to
+// cfg.Fallback on transient failures when it is set. Each provider is
+// wrapped with its own timeout and retry policy.
+func New(cfg config.LLMConfig, openai config.OpenAIConfig, gemini config.GeminiConfig) (ChatModel, error) {
+	primary, err := newProvider(cfg.Provider, openai, gemini)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Fallback == "" || cfg.Fallback == cfg.Provider {
+		return primary, nil
+	}
+	secondary, err := newProvider(cfg.Fallback, openai, gemini)
+	if err != nil {
+		return nil, err
+	}
+	return Fallback(primary, secondary), nil
+}
+
+func newProvider(name string, openai config.OpenAIConfig, gemini config.GeminiConfig) (ChatModel, error) {
+	switch name {
+	case "", "openai":
+		return WithRetry(NewOpenAI(openai.Key, openai.Model), openai.MaxRetries, seconds(openai.TimeoutSeconds)), nil
+	case "gemini":
+		return WithRetry(NewGemini(gemini.Key, gemini.Model), gemini.MaxRetries, seconds(gemini.TimeoutSeconds)), nil
+	default:
+		return nil, fmt.Errorf("unknown llm provider %q", name)
+	}
+}
+
+func seconds(n int) time.Duration {
+	return time.Duration(n) * time.Second
+}
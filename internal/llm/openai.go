@@ -0,0 +1,139 @@
+package llm
+
+import (
+	"ai-learn-english/pkg/httpjson"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const openAIChatURL = "https://api.openai.com/v1/chat/completions"
+
+// OpenAI is a ChatModel backed by the OpenAI chat completions API.
+type OpenAI struct {
+	key   string
+	model string
+}
+
+func NewOpenAI(key, model string) *OpenAI {
+	return &OpenAI{key: key, model: model}
+}
+
+func (o *OpenAI) Name() string { return "openai/" + o.model }
+
+type openAIChatRequest struct {
+	Model          string                `json:"model"`
+	Messages       []Message             `json:"messages"`
+	Temperature    float64               `json:"temperature"`
+	MaxTokens      int                   `json:"max_tokens,omitempty"`
+	Stream         bool                  `json:"stream,omitempty"`
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+}
+
+type openAIResponseFormat struct {
+	Type       string           `json:"type"`
+	JSONSchema openAIJSONSchema `json:"json_schema"`
+}
+
+type openAIJSONSchema struct {
+	Name   string         `json:"name"`
+	Schema map[string]any `json:"schema"`
+	Strict bool           `json:"strict"`
+}
+
+type openAIChatResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message Message `json:"message"`
+	} `json:"choices"`
+}
+
+func (o *OpenAI) Chat(ctx context.Context, req Request) (*Response, error) {
+	var res openAIChatResponse
+	err := httpjson.Post(ctx, httpClient, "openai", openAIChatURL,
+		map[string]string{"Authorization": "Bearer " + o.key},
+		o.request(req, false),
+		&res)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Choices) == 0 {
+		return nil, errors.New("openai: response has no choices")
+	}
+	return &Response{Content: res.Choices[0].Message.Content, Model: res.Model}, nil
+}
+
+type openAIStreamChunk struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+func (o *OpenAI) Stream(ctx context.Context, req Request, onDelta func(delta string) error) (*Response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resp, err := httpjson.Do(ctx, httpClient, "openai", openAIChatURL,
+		map[string]string{"Authorization": "Bearer " + o.key},
+		o.request(req, true))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	out := &Response{Model: o.model}
+	var content strings.Builder
+	done := false
+	err = readSSE(resp.Body, func(data string) error {
+		if data == "[DONE]" {
+			done = true
+			return errStreamDone
+		}
+		var chunk openAIStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("openai: decode stream chunk: %w", err)
+		}
+		if chunk.Model != "" {
+			out.Model = chunk.Model
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			return nil
+		}
+		delta := chunk.Choices[0].Delta.Content
+		if err := onDelta(delta); err != nil {
+			return err
+		}
+		content.WriteString(delta)
+		return nil
+	})
+	// OpenAI always ends a complete stream with [DONE]; without it the
+	// connection was cut and the answer is truncated.
+	if err == nil && !done {
+		err = fmt.Errorf("openai: stream ended before [DONE]: %w", io.ErrUnexpectedEOF)
+	}
+	out.Content = content.String()
+	return out, err
+}
+
+func (o *OpenAI) request(req Request, stream bool) openAIChatRequest {
+	out := openAIChatRequest{
+		Model:       o.model,
+		Messages:    req.Messages,
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+		Stream:      stream,
+	}
+	if req.Schema != nil {
+		out.ResponseFormat = &openAIResponseFormat{
+			Type:       "json_schema",
+			JSONSchema: openAIJSONSchema{Name: req.Schema.Name, Schema: req.Schema.Schema, Strict: true},
+		}
+	}
+	return out
+}
@@ -0,0 +1,142 @@
+package llm
+
+import (
+	"ai-learn-english/pkg/httpjson"
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+)
+
+// retryBaseDelay bounds the first backoff. It is a variable so tests can
+// shorten it.
+var retryBaseDelay = 500 * time.Millisecond
+
+// Retryable reports whether err is a transient provider failure worth
+// retrying or failing over: a timeout, a rate limit or a 5xx response.
+func Retryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var status *httpjson.StatusError
+	if errors.As(err, &status) {
+		return status.StatusCode == http.StatusTooManyRequests || status.StatusCode >= 500
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+type retrying struct {
+	ChatModel
+	retries int
+	timeout time.Duration
+}
+
+// WithRetry wraps m so that each attempt is bounded by timeout and
+// retryable failures are retried up to retries times with exponential
+// backoff and full jitter. For streams the timeout bounds the wait for the
+// first delta, and a stream is never retried once text has been delivered.
+func WithRetry(m ChatModel, retries int, timeout time.Duration) ChatModel {
+	return &retrying{ChatModel: m, retries: retries, timeout: timeout}
+}
+
+func (r *retrying) Chat(ctx context.Context, req Request) (*Response, error) {
+	var lastErr error
+	for attempt := 0; attempt <= r.retries; attempt++ {
+		if attempt > 0 {
+			if err := sleepContext(ctx, backoff(attempt)); err != nil {
+				return nil, err
+			}
+		}
+		res, err := r.chatOnce(ctx, req)
+		if err == nil {
+			return res, nil
+		}
+		if ctx.Err() != nil || !Retryable(err) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func (r *retrying) chatOnce(ctx context.Context, req Request) (*Response, error) {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+	return r.ChatModel.Chat(ctx, req)
+}
+
+func (r *retrying) Stream(ctx context.Context, req Request, onDelta func(delta string) error) (*Response, error) {
+	var lastErr error
+	for attempt := 0; attempt <= r.retries; attempt++ {
+		if attempt > 0 {
+			if err := sleepContext(ctx, backoff(attempt)); err != nil {
+				return nil, err
+			}
+		}
+		res, delivered, err := r.streamOnce(ctx, req, onDelta)
+		if err == nil || delivered || ctx.Err() != nil || !Retryable(err) {
+			return res, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// streamOnce runs one streaming attempt and reports whether any delta was
+// delivered. The attempt is cancelled if no delta arrives within timeout.
+func (r *retrying) streamOnce(ctx context.Context, req Request, onDelta func(delta string) error) (*Response, bool, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	firstDelta := make(chan struct{})
+	if r.timeout > 0 {
+		timer := time.AfterFunc(r.timeout, func() { cancel(context.DeadlineExceeded) })
+		defer timer.Stop()
+		go func() {
+			select {
+			case <-firstDelta:
+				timer.Stop()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	delivered := false
+	res, err := r.ChatModel.Stream(ctx, req, func(delta string) error {
+		if !delivered {
+			delivered = true
+			close(firstDelta)
+		}
+		return onDelta(delta)
+	})
+	if err != nil && errors.Is(context.Cause(ctx), context.DeadlineExceeded) {
+		err = context.DeadlineExceeded
+	}
+	return res, delivered, err
+}
+
+// backoff returns a random delay in [0, base*2^(attempt-1)).
+func backoff(attempt int) time.Duration {
+	max := retryBaseDelay << (attempt - 1)
+	return time.Duration(rand.Int64N(int64(max)))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
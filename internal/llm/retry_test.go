@@ -0,0 +1,170 @@
+package llm
+
+import (
+	"ai-learn-english/pkg/httpjson"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	retryBaseDelay = time.Millisecond
+	m.Run()
+}
+
+func statusErr(code int) error {
+	return &httpjson.StatusError{Service: "test", StatusCode: code, Body: http.StatusText(code)}
+}
+
+type netErr struct{ timeout bool }
+
+func (e netErr) Error() string   { return "network error" }
+func (e netErr) Timeout() bool   { return e.timeout }
+func (e netErr) Temporary() bool { return false }
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"500", statusErr(http.StatusInternalServerError), true},
+		{"502", statusErr(http.StatusBadGateway), true},
+		{"503 wrapped", fmt.Errorf("openai: %w", statusErr(http.StatusServiceUnavailable)), true},
+		{"429", statusErr(http.StatusTooManyRequests), true},
+		{"400", statusErr(http.StatusBadRequest), false},
+		{"401", statusErr(http.StatusUnauthorized), false},
+		{"404", statusErr(http.StatusNotFound), false},
+		{"deadline", context.DeadlineExceeded, true},
+		{"deadline wrapped", fmt.Errorf("call: %w", context.DeadlineExceeded), true},
+		{"canceled", context.Canceled, false},
+		{"network timeout", netErr{timeout: true}, true},
+		{"network error", netErr{timeout: false}, false},
+		{"other", errors.New("bad json"), false},
+	}
+	for _, tt := range tests {
+		if got := Retryable(tt.err); got != tt.want {
+			t.Errorf("%s: Retryable = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWithRetryChat(t *testing.T) {
+	tests := []struct {
+		name    string
+		replies []FakeReply
+		calls   int
+		wantErr bool
+	}{
+		{"first try", []FakeReply{{Content: "ok"}}, 1, false},
+		{"5xx then success", []FakeReply{{Err: statusErr(500)}, {Content: "ok"}}, 2, false},
+		{"429 then success", []FakeReply{{Err: statusErr(429)}, {Content: "ok"}}, 2, false},
+		{"timeout then success", []FakeReply{{Err: context.DeadlineExceeded}, {Content: "ok"}}, 2, false},
+		{"4xx is not retried", []FakeReply{{Err: statusErr(400)}, {Content: "ok"}}, 1, true},
+		{"retries run out", []FakeReply{{Err: statusErr(503)}, {Err: statusErr(503)}, {Err: statusErr(503)}, {Content: "ok"}}, 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := NewFake("m", tt.replies...)
+			res, err := WithRetry(fake, 2, 0).Chat(context.Background(), Request{})
+			if calls := len(fake.Requests()); calls != tt.calls {
+				t.Errorf("%d calls, want %d", calls, tt.calls)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && res.Content != "ok" {
+				t.Errorf("Content = %q", res.Content)
+			}
+		})
+	}
+}
+
+func TestWithRetryStream(t *testing.T) {
+	tests := []struct {
+		name    string
+		replies []FakeReply
+		calls   int
+		content string
+		wantErr bool
+	}{
+		{"5xx before any text is retried", []FakeReply{{Err: statusErr(500)}, {Deltas: []string{"a", "b"}}}, 2, "ab", false},
+		{"5xx after text is not retried", []FakeReply{{Deltas: []string{"a"}, Err: statusErr(500)}, {Deltas: []string{"a", "b"}}}, 1, "a", true},
+		{"4xx is not retried", []FakeReply{{Err: statusErr(400)}, {Deltas: []string{"a"}}}, 1, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := NewFake("m", tt.replies...)
+			var got string
+			_, err := WithRetry(fake, 2, 0).Stream(context.Background(), Request{}, func(delta string) error {
+				got += delta
+				return nil
+			})
+			if calls := len(fake.Requests()); calls != tt.calls {
+				t.Errorf("%d calls, want %d", calls, tt.calls)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.content {
+				t.Errorf("delivered %q, want %q", got, tt.content)
+			}
+		})
+	}
+}
+
+// hangingModel never answers before its context ends.
+type hangingModel struct {
+	calls atomic.Int32
+}
+
+func (m *hangingModel) Name() string { return "hanging" }
+
+func (m *hangingModel) Chat(ctx context.Context, req Request) (*Response, error) {
+	m.calls.Add(1)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (m *hangingModel) Stream(ctx context.Context, req Request, onDelta func(string) error) (*Response, error) {
+	m.calls.Add(1)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestWithRetryTimeout(t *testing.T) {
+	m := &hangingModel{}
+	_, err := WithRetry(m, 1, 10*time.Millisecond).Chat(context.Background(), Request{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Chat err = %v, want deadline exceeded", err)
+	}
+	if n := m.calls.Load(); n != 2 {
+		t.Errorf("Chat made %d calls, want 2", n)
+	}
+
+	m = &hangingModel{}
+	_, err = WithRetry(m, 1, 10*time.Millisecond).Stream(context.Background(), Request{}, func(string) error { return nil })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Stream err = %v, want deadline exceeded", err)
+	}
+	if n := m.calls.Load(); n != 2 {
+		t.Errorf("Stream made %d calls, want 2", n)
+	}
+}
+
+func TestWithRetryStopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fake := NewFake("m", FakeReply{Err: statusErr(500)}, FakeReply{Content: "ok"})
+	if _, err := WithRetry(fake, 3, 0).Chat(ctx, Request{}); err == nil {
+		t.Fatal("a canceled call succeeded")
+	}
+	if calls := len(fake.Requests()); calls != 1 {
+		t.Errorf("%d calls after cancel, want 1", calls)
+	}
+}
@@ -0,0 +1,52 @@
+package llm
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+)
+
+// errStreamDone is returned by a readSSE callback to stop reading cleanly.
+var errStreamDone = errors.New("stream done")
+
+// readSSE reads a provider's Server-Sent Events body and calls fn with the
+// data of every event. Reading stops at EOF, when fn returns an error, or
+// when fn returns errStreamDone, which is not reported as an error.
+func readSSE(body io.Reader, fn func(data string) error) error {
+	sc := bufio.NewScanner(body)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var data []string
+	dispatch := func() error {
+		if len(data) == 0 {
+			return nil
+		}
+		payload := strings.Join(data, "\n")
+		data = data[:0]
+		return fn(payload)
+	}
+
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case line == "":
+			if err := dispatch(); err != nil {
+				return ignoreDone(err)
+			}
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	return ignoreDone(dispatch())
+}
+
+func ignoreDone(err error) error {
+	if errors.Is(err, errStreamDone) {
+		return nil
+	}
+	return err
+}
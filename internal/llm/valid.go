@@ -0,0 +1,43 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ValidAttempts is how many replies a model usually gets to produce a
+// valid structured reply.
+const ValidAttempts = 3
+
+// ErrInvalidReply is returned by ChatValid when the model never produced a
+// reply parse accepted.
+var ErrInvalidReply = errors.New("llm: no valid reply")
+
+// ChatValid sends req to model until parse accepts the reply, at most
+// attempts times. Each retry shows the model its previous reply and what
+// was wrong with it. An error from the model is returned as it is; running
+// out of attempts returns ErrInvalidReply wrapped with the last parse
+// error.
+func ChatValid(ctx context.Context, model ChatModel, req Request, attempts int, parse func(reply string) error) error {
+	// Retries append to the conversation, which must not write into the
+	// caller's backing array.
+	req.Messages = append([]Message(nil), req.Messages...)
+	for attempt := 1; ; attempt++ {
+		res, err := model.Chat(ctx, req)
+		if err != nil {
+			return err
+		}
+		err = parse(res.Content)
+		if err == nil {
+			return nil
+		}
+		if attempt >= attempts {
+			return fmt.Errorf("%w after %d replies: %v", ErrInvalidReply, attempt, err)
+		}
+		req.Messages = append(req.Messages,
+			Message{Role: RoleAssistant, Content: res.Content},
+			Message{Role: RoleUser, Content: "Your reply is invalid: " + err.Error() + ". Reply again with the complete JSON object only."},
+		)
+	}
+}
@@ -0,0 +1,63 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func parseOK(reply string) error {
+	if reply != "ok" {
+		return errors.New("want ok")
+	}
+	return nil
+}
+
+func TestChatValid(t *testing.T) {
+	boom := errors.New("boom")
+	tests := []struct {
+		name    string
+		replies []FakeReply
+		calls   int
+		wantErr error
+	}{
+		{"first reply valid", []FakeReply{{Content: "ok"}}, 1, nil},
+		{"valid after a retry", []FakeReply{{Content: "nope"}, {Content: "ok"}}, 2, nil},
+		{"never valid", []FakeReply{{Content: "a"}, {Content: "b"}, {Content: "c"}, {Content: "ok"}}, 3, ErrInvalidReply},
+		{"model error", []FakeReply{{Content: "nope"}, {Err: boom}}, 2, boom},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := NewFake("m", tt.replies...)
+			req := Request{Messages: make([]Message, 1, 4), Temperature: 0.3}
+			req.Messages[0] = Message{Role: RoleUser, Content: "say ok"}
+
+			err := ChatValid(context.Background(), fake, req, 3, parseOK)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			reqs := fake.Requests()
+			if len(reqs) != tt.calls {
+				t.Fatalf("%d calls, want %d", len(reqs), tt.calls)
+			}
+			for i, r := range reqs {
+				if len(r.Messages) != 1+2*i || r.Temperature != 0.3 {
+					t.Errorf("call %d sent %d messages at temperature %v", i+1, len(r.Messages), r.Temperature)
+				}
+			}
+			if tt.calls > 1 {
+				retry := reqs[1].Messages
+				if retry[1].Role != RoleAssistant || retry[1].Content != tt.replies[0].Content {
+					t.Errorf("retry does not replay the invalid reply: %+v", retry[1])
+				}
+				if !strings.Contains(retry[2].Content, "want ok") {
+					t.Errorf("retry does not say what was wrong: %q", retry[2].Content)
+				}
+			}
+			if req.Messages[:cap(req.Messages)][1] != (Message{}) {
+				t.Error("ChatValid wrote into the caller's messages")
+			}
+		})
+	}
+}
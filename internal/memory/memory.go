@@ -0,0 +1,132 @@
+// Package memory decides how much of a conversation the teacher sees. The
+// most recent turns are replayed verbatim, older ones survive as a running
+// summary written by the model, and the assembled prompt is kept under a
+// token budget.
+package memory
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/internal/llm"
+	"context"
+	"fmt"
+)
+
+// roleAssistant is the role of the teacher's messages in the messages table.
+const roleAssistant = "assistant"
+
+// Manager assembles prompts from conversation history and keeps the
+// running summary of each conversation up to date.
+type Manager struct {
+	store  store
+	model  llm.ChatModel
+	tokens Tokens
+	cfg    config.MemoryConfig
+}
+
+// New returns a manager that summarizes with model and counts tokens the
+// way model does. Missing settings fall back to sensible defaults.
+func New(q *query.Query, model llm.ChatModel, cfg config.MemoryConfig) *Manager {
+	if cfg.RecentTurns <= 0 {
+		cfg.RecentTurns = 6
+	}
+	if cfg.PromptBudget <= 0 {
+		cfg.PromptBudget = 6000
+	}
+	if cfg.SummaryTokens <= 0 {
+		cfg.SummaryTokens = 300
+	}
+	return &Manager{store: queryStore{q: q}, model: model, tokens: TokensFor(model.Name()), cfg: cfg}
+}
+
+// Tokens returns the token accounting used for the prompts.
+func (m *Manager) Tokens() Tokens {
+	return m.tokens
+}
+
+// Prompt returns the messages for asking question in conv: the system
+// prompt followed by the conversation summary, the recent turns and the
+// question. conv may be a conversation that is not stored yet.
+func (m *Manager) Prompt(ctx context.Context, conv *model.Conversation, system, question string) ([]llm.Message, error) {
+	var (
+		summary string
+		history []llm.Message
+	)
+	if conv.ID != 0 {
+		if conv.Summary != nil {
+			summary = *conv.Summary
+		}
+		msgs, err := m.recent(ctx, conv)
+		if err != nil {
+			return nil, err
+		}
+		history = toLLM(msgs)
+	}
+	return Assemble(m.tokens, m.cfg.PromptBudget, system, summary, history, question), nil
+}
+
+// Assemble builds a prompt within budget tokens. The system prompt and the
+// question are always kept. The summary is attached to the system prompt
+// when it fits, then as many of the newest history messages as fit are
+// added, whole user/assistant exchanges at a time.
+func Assemble(tokens Tokens, budget int, system, summary string, history []llm.Message, question string) []llm.Message {
+	sys := llm.Message{Role: llm.RoleSystem, Content: system}
+	q := llm.Message{Role: llm.RoleUser, Content: question}
+	used := tokens.Request([]llm.Message{sys, q})
+
+	if summary != "" {
+		withSummary := sys
+		withSummary.Content = system + "\n\nSummary of the conversation so far:\n" + summary
+		if extra := tokens.Message(withSummary) - tokens.Message(sys); used+extra <= budget {
+			sys = withSummary
+			used += extra
+		}
+	}
+
+	// Walk back one exchange at a time so the model never sees an answer
+	// without its question.
+	start := len(history)
+	for start > 0 {
+		from := start - 1
+		for from > 0 && history[from].Role != llm.RoleUser {
+			from--
+		}
+		cost := 0
+		for _, msg := range history[from:start] {
+			cost += tokens.Message(msg)
+		}
+		if used+cost > budget {
+			break
+		}
+		used += cost
+		start = from
+	}
+
+	out := make([]llm.Message, 0, len(history)-start+2)
+	out = append(out, sys)
+	out = append(out, history[start:]...)
+	return append(out, q)
+}
+
+// recent returns the last RecentTurns exchanges of conv that are not
+// covered by its summary, oldest first.
+func (m *Manager) recent(ctx context.Context, conv *model.Conversation) ([]*model.Message, error) {
+	msgs, err := m.store.Messages(ctx, conv.ID, conv.SummaryMessageID, 2*m.cfg.RecentTurns)
+	if err != nil {
+		return nil, fmt.Errorf("load history of conversation %d: %w", conv.ID, err)
+	}
+	return msgs, nil
+}
+
+func toLLM(msgs []*model.Message) []llm.Message {
+	out := make([]llm.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		role := llm.RoleUser
+		if msg.Role == roleAssistant {
+			role = llm.RoleAssistant
+		}
+		out = append(out, llm.Message{Role: role, Content: msg.Content})
+	}
+	return out
+}
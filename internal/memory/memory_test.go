@@ -0,0 +1,323 @@
+package memory
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/llm"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// wordTokens counts one token per word and per message, which keeps the
+// budgets in these tests easy to follow.
+var wordTokens = Tokens{count: func(s string) int { return len(strings.Fields(s)) }, perMessage: 1}
+
+func exchange(n int) []llm.Message {
+	return []llm.Message{
+		{Role: llm.RoleUser, Content: fmt.Sprintf("question %d", n)},
+		{Role: llm.RoleAssistant, Content: fmt.Sprintf("answer number %d", n)},
+	}
+}
+
+func history(n int) []llm.Message {
+	var out []llm.Message
+	for i := 1; i <= n; i++ {
+		out = append(out, exchange(i)...)
+	}
+	return out
+}
+
+func TestAssembleBudget(t *testing.T) {
+	// System prompt and question cost 4 each; each exchange 3 + 4 = 7.
+	hist := history(4)
+	for budget := 0; budget <= 40; budget++ {
+		out := Assemble(wordTokens, budget, "be very helpful", "", hist, "what is this")
+		if out[0].Role != llm.RoleSystem || out[len(out)-1].Content != "what is this" {
+			t.Fatalf("budget %d: prompt must start with the system prompt and end with the question: %+v", budget, out)
+		}
+		used := wordTokens.Request(out)
+		if used > max(budget, 8) {
+			t.Errorf("budget %d: prompt uses %d tokens", budget, used)
+		}
+		kept := len(out) - 2
+		if want := min(max(budget-8, 0)/7, 4) * 2; kept != want {
+			t.Errorf("budget %d: kept %d history messages, want %d", budget, kept, want)
+		}
+	}
+}
+
+func TestAssembleKeepsWholeExchanges(t *testing.T) {
+	hist := history(3)
+	// Room for the last exchange and the previous answer, but not for the
+	// previous question.
+	out := Assemble(wordTokens, 8+7+4, "be very helpful", "", hist, "what is this")
+	got := out[1 : len(out)-1]
+	if len(got) != 2 || got[0].Content != "question 3" || got[1].Content != "answer number 3" {
+		t.Errorf("history = %+v, want only the last exchange", got)
+	}
+	for i := 1; i < len(out)-1; i += 2 {
+		if out[i].Role != llm.RoleUser {
+			t.Errorf("message %d starts an exchange with role %s", i, out[i].Role)
+		}
+	}
+}
+
+func TestAssembleSummary(t *testing.T) {
+	summary := "the learner studies the present perfect"
+	hist := history(2)
+
+	out := Assemble(wordTokens, 100, "be helpful", summary, hist, "why")
+	if !strings.HasSuffix(out[0].Content, "Summary of the conversation so far:\n"+summary) {
+		t.Errorf("system prompt lacks the summary: %q", out[0].Content)
+	}
+	if len(out) != 6 {
+		t.Errorf("got %d messages, want system, 4 history and question", len(out))
+	}
+
+	// The summary costs 12 more tokens than the bare system prompt; with
+	// 10 to spare it is dropped but an exchange still fits.
+	out = Assemble(wordTokens, 5+10, "be helpful", summary, hist, "why")
+	if out[0].Content != "be helpful" {
+		t.Errorf("summary kept although it does not fit: %q", out[0].Content)
+	}
+	if len(out) != 4 || out[1].Content != "question 2" {
+		t.Errorf("got %+v, want the last exchange", out)
+	}
+}
+
+// memStore keeps one conversation in memory and applies the same guard as
+// the database update.
+type memStore struct {
+	mu    sync.Mutex
+	conv  model.Conversation
+	msgs  []*model.Message
+	saves int
+}
+
+func newMemStore(roles ...string) *memStore {
+	s := &memStore{conv: model.Conversation{ID: 1}}
+	for i, role := range roles {
+		s.msgs = append(s.msgs, &model.Message{ID: int64(i + 1), ConversationID: 1, Role: role, Content: fmt.Sprintf("%s message %d", role, i+1)})
+	}
+	return s
+}
+
+func (s *memStore) Conversation(ctx context.Context, id int64) (*model.Conversation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id != s.conv.ID {
+		return nil, nil
+	}
+	conv := s.conv
+	return &conv, nil
+}
+
+func (s *memStore) Messages(ctx context.Context, conversationID int64, after *int64, limit int) ([]*model.Message, error) {
+	var out []*model.Message
+	for _, m := range s.msgs {
+		if after == nil || m.ID > *after {
+			out = append(out, m)
+		}
+	}
+	if limit > 0 && len(out) > limit {
+		out = out[len(out)-limit:]
+	}
+	return out, nil
+}
+
+func (s *memStore) SaveSummary(ctx context.Context, conversationID int64, since *int64, summary string, through int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cur := s.conv.SummaryMessageID
+	if (since == nil) != (cur == nil) || (since != nil && *since != *cur) {
+		return nil
+	}
+	s.conv.Summary = &summary
+	s.conv.SummaryMessageID = &through
+	s.saves++
+	return nil
+}
+
+func newTestManager(s store, chatModel llm.ChatModel, cfg config.MemoryConfig) *Manager {
+	return &Manager{store: s, model: chatModel, tokens: wordTokens, cfg: cfg}
+}
+
+const (
+	user      = "user"
+	assistant = roleAssistant
+)
+
+func TestSummarizeFoldsWholeExchanges(t *testing.T) {
+	// Two turns are kept; the last message is a question still waiting
+	// for its answer, so only the first exchange can be folded.
+	s := newMemStore(user, assistant, user, assistant, user)
+	fake := llm.NewFake("m", llm.FakeReply{Content: "  The learner asked one question.  "})
+	m := newTestManager(s, fake, config.MemoryConfig{RecentTurns: 1, PromptBudget: 1000, SummaryTokens: 50})
+
+	if err := m.Summarize(context.Background(), 1); err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+	if s.conv.Summary == nil || *s.conv.Summary != "The learner asked one question." {
+		t.Errorf("summary = %v", s.conv.Summary)
+	}
+	if s.conv.SummaryMessageID == nil || *s.conv.SummaryMessageID != 2 {
+		t.Errorf("SummaryMessageID = %v, want 2, the last assistant turn folded", s.conv.SummaryMessageID)
+	}
+	reqs := fake.Requests()
+	if len(reqs) != 1 {
+		t.Fatalf("%d model calls, want 1", len(reqs))
+	}
+	prompt := reqs[0].Messages[1].Content
+	if !strings.Contains(prompt, "Learner: user message 1\nTeacher: assistant message 2") || strings.Contains(prompt, "message 3") {
+		t.Errorf("summary request = %q, want only the first exchange", prompt)
+	}
+}
+
+func TestSummarizeSkipsShortConversations(t *testing.T) {
+	tests := []struct {
+		name  string
+		roles []string
+	}{
+		{"within the recent window", []string{user, assistant, user, assistant}},
+		{"nothing answered outside the window", []string{user, user, assistant, user, assistant}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMemStore(tt.roles...)
+			fake := llm.NewFake("m")
+			m := newTestManager(s, fake, config.MemoryConfig{RecentTurns: 2, PromptBudget: 1000, SummaryTokens: 50})
+			if err := m.Summarize(context.Background(), 1); err != nil {
+				t.Fatalf("Summarize: %v", err)
+			}
+			if n := len(fake.Requests()); n != 0 || s.saves != 0 {
+				t.Errorf("%d model calls and %d saves, want none", n, s.saves)
+			}
+		})
+	}
+	m := newTestManager(newMemStore(), llm.NewFake("m"), config.MemoryConfig{RecentTurns: 1})
+	if err := m.Summarize(context.Background(), 99); err != nil {
+		t.Errorf("unknown conversation: %v", err)
+	}
+}
+
+func TestSummarizeStartsAfterTheSummary(t *testing.T) {
+	s := newMemStore(user, assistant, user, assistant, user, assistant)
+	old, through := "Earlier summary.", int64(2)
+	s.conv.Summary, s.conv.SummaryMessageID = &old, &through
+	fake := llm.NewFake("m", llm.FakeReply{Content: "Newer summary."})
+	m := newTestManager(s, fake, config.MemoryConfig{RecentTurns: 1, PromptBudget: 1000, SummaryTokens: 50})
+
+	if err := m.Summarize(context.Background(), 1); err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+	prompt := fake.Requests()[0].Messages[1].Content
+	if !strings.HasPrefix(prompt, "Summary so far:\nEarlier summary.") || strings.Contains(prompt, "message 2") || !strings.Contains(prompt, "message 4") {
+		t.Errorf("summary request = %q", prompt)
+	}
+	if *s.conv.Summary != "Newer summary." || *s.conv.SummaryMessageID != 4 {
+		t.Errorf("saved %q through %d", *s.conv.Summary, *s.conv.SummaryMessageID)
+	}
+}
+
+func TestSummarizeInBatches(t *testing.T) {
+	s := newMemStore(user, assistant, user, assistant, user, assistant, user, assistant)
+	var replies []llm.FakeReply
+	for i := 1; i <= 6; i++ {
+		replies = append(replies, llm.FakeReply{Content: fmt.Sprintf("summary %d", i)})
+	}
+	fake := llm.NewFake("m", replies...)
+	// Each transcript line costs 5 tokens and the fixed part of a summary
+	// request leaves room for exactly one per request.
+	m := newTestManager(s, fake, config.MemoryConfig{RecentTurns: 1, PromptBudget: 84, SummaryTokens: 5})
+	if fixed := m.tokens.Request(m.summaryRequest("summary 1", nil).Messages) + 5; fixed+5 > 84 || fixed+10 <= 84 {
+		t.Fatalf("fixed part of the request costs %d tokens; adjust the budget", fixed)
+	}
+
+	if err := m.Summarize(context.Background(), 1); err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+	reqs := fake.Requests()
+	if len(reqs) != 6 {
+		t.Fatalf("%d model calls, want one per folded message", len(reqs))
+	}
+	for i, req := range reqs[1:] {
+		prev := fmt.Sprintf("Summary so far:\nsummary %d\n", i+1)
+		if !strings.HasPrefix(req.Messages[1].Content, prev) {
+			t.Errorf("batch %d does not build on the previous summary: %q", i+2, req.Messages[1].Content)
+		}
+	}
+	if *s.conv.Summary != "summary 6" || *s.conv.SummaryMessageID != 6 {
+		t.Errorf("saved %q through %d", *s.conv.Summary, *s.conv.SummaryMessageID)
+	}
+}
+
+func TestSummarizeModelFailure(t *testing.T) {
+	for _, reply := range []llm.FakeReply{{Err: fmt.Errorf("boom")}, {Content: "   "}} {
+		s := newMemStore(user, assistant, user, assistant)
+		m := newTestManager(s, llm.NewFake("m", reply), config.MemoryConfig{RecentTurns: 1, PromptBudget: 1000, SummaryTokens: 50})
+		if err := m.Summarize(context.Background(), 1); err == nil {
+			t.Errorf("reply %+v: Summarize succeeded", reply)
+		}
+		if s.saves != 0 {
+			t.Errorf("reply %+v: summary saved after a failure", reply)
+		}
+	}
+}
+
+// racingModel lets another summary update land while the model is
+// answering, as a concurrent Summarize would.
+type racingModel struct {
+	*llm.Fake
+	race func()
+}
+
+func (m racingModel) Chat(ctx context.Context, req llm.Request) (*llm.Response, error) {
+	m.race()
+	return m.Fake.Chat(ctx, req)
+}
+
+func TestSummarizeDiscardsLosingRace(t *testing.T) {
+	s := newMemStore(user, assistant, user, assistant, user, assistant)
+	chatModel := racingModel{
+		Fake: llm.NewFake("m", llm.FakeReply{Content: "late summary"}),
+		race: func() {
+			if err := s.SaveSummary(context.Background(), 1, nil, "winning summary", 4); err != nil {
+				t.Fatal(err)
+			}
+		},
+	}
+	m := newTestManager(s, chatModel, config.MemoryConfig{RecentTurns: 1, PromptBudget: 1000, SummaryTokens: 50})
+
+	if err := m.Summarize(context.Background(), 1); err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+	if *s.conv.Summary != "winning summary" || *s.conv.SummaryMessageID != 4 || s.saves != 1 {
+		t.Errorf("stored %q through %d after %d saves; the late summary must be discarded", *s.conv.Summary, *s.conv.SummaryMessageID, s.saves)
+	}
+}
+
+func TestClip(t *testing.T) {
+	s := "one two three four five"
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, s},
+		{10, s},
+		{5, s},
+		{3, "one two three "},
+		{1, "one "},
+	}
+	for _, tt := range tests {
+		got := clip(wordTokens, s, tt.n)
+		if got != tt.want {
+			t.Errorf("clip(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+		if tt.n > 0 && wordTokens.Text(got) > tt.n {
+			t.Errorf("clip(%d) kept %d tokens", tt.n, wordTokens.Text(got))
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// store reads conversation history and saves summaries. queryStore is the
+// database implementation.
+type store interface {
+	// Conversation returns the conversation with id, or nil when there is
+	// none.
+	Conversation(ctx context.Context, id int64) (*model.Conversation, error)
+	// Messages returns the newest limit messages of a conversation with an
+	// id above after, oldest first. A nil after or a limit <= 0 does not
+	// restrict.
+	Messages(ctx context.Context, conversationID int64, after *int64, limit int) ([]*model.Message, error)
+	// SaveSummary stores summary as covering the messages up to through,
+	// unless the conversation's summary no longer covers exactly up to
+	// since, which means another update won the race.
+	SaveSummary(ctx context.Context, conversationID int64, since *int64, summary string, through int64) error
+}
+
+type queryStore struct {
+	q *query.Query
+}
+
+func (s queryStore) Conversation(ctx context.Context, id int64) (*model.Conversation, error) {
+	c := s.q.Conversation
+	conv, err := c.WithContext(ctx).Where(c.ID.Eq(id)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return conv, err
+}
+
+func (s queryStore) Messages(ctx context.Context, conversationID int64, after *int64, limit int) ([]*model.Message, error) {
+	msg := s.q.Message
+	do := msg.WithContext(ctx).Where(msg.ConversationID.Eq(conversationID))
+	if after != nil {
+		do = do.Where(msg.ID.Gt(*after))
+	}
+	if limit <= 0 {
+		return do.Order(msg.ID).Find()
+	}
+	msgs, err := do.Order(msg.ID.Desc()).Limit(limit).Find()
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(msgs)
+	return msgs, nil
+}
+
+func (s queryStore) SaveSummary(ctx context.Context, conversationID int64, since *int64, summary string, through int64) error {
+	c := s.q.Conversation
+	guard := c.SummaryMessageID.IsNull()
+	if since != nil {
+		guard = c.SummaryMessageID.Eq(*since)
+	}
+	_, err := c.WithContext(ctx).Where(c.ID.Eq(conversationID), guard).UpdateSimple(
+		c.Summary.Value(summary),
+		c.SummaryMessageID.Value(through),
+		c.SummaryUpdatedAt.Value(time.Now()),
+	)
+	return err
+}
@@ -0,0 +1,147 @@
+package memory
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/llm"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const summaryPrompt = `You keep the memory of a tutoring conversation between an English learner and their teacher.
+Rewrite the summary so far to include the new exchanges below. Keep what the learner is studying, their level, mistakes they keep making, open questions and anything they asked you to remember. Leave out greetings and small talk.
+Write plain prose in English, at most %d words.`
+
+// Summarize folds the exchanges of a conversation that have left the recent
+// window into its running summary. It does nothing while the conversation
+// is short, so it can be called after every turn. When two calls race, the
+// first to store its summary wins and the other is discarded.
+func (m *Manager) Summarize(ctx context.Context, conversationID int64) error {
+	conv, err := m.store.Conversation(ctx, conversationID)
+	if err != nil {
+		return fmt.Errorf("load conversation %d: %w", conversationID, err)
+	}
+	if conv == nil {
+		return nil
+	}
+
+	msgs, err := m.store.Messages(ctx, conv.ID, conv.SummaryMessageID, 0)
+	if err != nil {
+		return fmt.Errorf("load history of conversation %d: %w", conv.ID, err)
+	}
+
+	keep := 2 * m.cfg.RecentTurns
+	if len(msgs) <= keep {
+		return nil
+	}
+	fold := msgs[:len(msgs)-keep]
+	// Only whole exchanges are folded, so the summary never ends on a
+	// question whose answer is still replayed verbatim.
+	for len(fold) > 0 && fold[len(fold)-1].Role != roleAssistant {
+		fold = fold[:len(fold)-1]
+	}
+	if len(fold) == 0 {
+		return nil
+	}
+
+	through := fold[len(fold)-1].ID
+
+	summary := ""
+	if conv.Summary != nil {
+		summary = *conv.Summary
+	}
+	for len(fold) > 0 {
+		n := m.batch(summary, fold)
+		if summary, err = m.fold(ctx, summary, fold[:n]); err != nil {
+			return fmt.Errorf("summarize conversation %d with %s: %w", conv.ID, m.model.Name(), err)
+		}
+		fold = fold[n:]
+	}
+
+	if err := m.store.SaveSummary(ctx, conv.ID, conv.SummaryMessageID, summary, through); err != nil {
+		return fmt.Errorf("save summary of conversation %d: %w", conv.ID, err)
+	}
+	return nil
+}
+
+// batch returns how many of msgs can be folded into summary in one request
+// without exceeding the prompt budget. It is at least one; a message too
+// large on its own is clipped by fold.
+func (m *Manager) batch(summary string, msgs []*model.Message) int {
+	used := m.tokens.Request(m.summaryRequest(summary, nil).Messages) + m.cfg.SummaryTokens
+	n := 0
+	for n < len(msgs) {
+		cost := m.tokens.Text(transcriptLine(msgs[n])) + 1
+		if n > 0 && used+cost > m.cfg.PromptBudget {
+			break
+		}
+		used += cost
+		n++
+	}
+	return n
+}
+
+// fold asks the model for summary updated with msgs.
+func (m *Manager) fold(ctx context.Context, summary string, msgs []*model.Message) (string, error) {
+	req := m.summaryRequest(summary, msgs)
+	res, err := m.model.Chat(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	out := strings.TrimSpace(res.Content)
+	if out == "" {
+		return "", errors.New("empty summary")
+	}
+	return out, nil
+}
+
+func (m *Manager) summaryRequest(summary string, msgs []*model.Message) llm.Request {
+	if summary == "" {
+		summary = "(empty)"
+	}
+	var b strings.Builder
+	b.WriteString("Summary so far:\n")
+	b.WriteString(summary)
+	b.WriteString("\n\nNew exchanges:")
+	for _, msg := range msgs {
+		b.WriteString("\n")
+		b.WriteString(transcriptLine(msg))
+	}
+
+	room := m.cfg.PromptBudget - m.cfg.SummaryTokens - m.tokens.Text(summaryPrompt) - 2*m.tokens.perMessage - m.tokens.perRequest
+	return llm.Request{
+		Messages: []llm.Message{
+			{Role: llm.RoleSystem, Content: fmt.Sprintf(summaryPrompt, m.cfg.SummaryTokens*3/4)},
+			{Role: llm.RoleUser, Content: clip(m.tokens, b.String(), room)},
+		},
+		Temperature: 0.2,
+		MaxTokens:   m.cfg.SummaryTokens * 3 / 2,
+	}
+}
+
+func transcriptLine(msg *model.Message) string {
+	speaker := "Learner"
+	if msg.Role == roleAssistant {
+		speaker = "Teacher"
+	}
+	return speaker + ": " + msg.Content
+}
+
+// clip cuts s to at most n tokens, keeping its start.
+func clip(tokens Tokens, s string, n int) string {
+	if n <= 0 || tokens.Text(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	lo, hi := 0, len(runes)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if tokens.Text(string(runes[:mid])) <= n {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return string(runes[:lo])
+}
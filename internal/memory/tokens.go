@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"ai-learn-english/internal/llm"
+	"ai-learn-english/pkg/chunker"
+	"strings"
+)
+
+// Tokens counts the prompt tokens of chat requests for one model.
+type Tokens struct {
+	count chunker.TokenCounter
+	// perMessage covers the role markers the provider wraps around each
+	// message, perRequest the tokens that prime the reply.
+	perMessage int
+	perRequest int
+}
+
+// TokensFor returns the token accounting for the model called name, as
+// reported by llm.ChatModel.Name. For a fallback chain the most expensive
+// model is used so the prompt fits whichever one answers. Only the message
+// framing depends on the provider: text is counted with the shared
+// chunker.EstimateTokens estimate for every model, so budgets are
+// approximate. Use WithCounter to plug in a provider's real tokenizer.
+func TokensFor(name string) Tokens {
+	t := Tokens{count: chunker.EstimateTokens}
+	for _, n := range strings.Split(name, ",") {
+		provider, _, _ := strings.Cut(n, "/")
+		perMessage, perRequest := 4, 3
+		if provider == "gemini" {
+			// Gemini only adds a turn marker around each content.
+			perMessage, perRequest = 3, 0
+		}
+		t.perMessage = max(t.perMessage, perMessage)
+		t.perRequest = max(t.perRequest, perRequest)
+	}
+	return t
+}
+
+// WithCounter replaces the text estimator, e.g. with a real tokenizer.
+func (t Tokens) WithCounter(count chunker.TokenCounter) Tokens {
+	t.count = count
+	return t
+}
+
+// Text returns the tokens of s alone.
+func (t Tokens) Text(s string) int {
+	return t.count(s)
+}
+
+// Message returns the tokens of m including its framing.
+func (t Tokens) Message(m llm.Message) int {
+	return t.perMessage + t.count(m.Content)
+}
+
+// Request returns the prompt tokens of a request made of msgs.
+func (t Tokens) Request(msgs []llm.Message) int {
+	n := t.perRequest
+	for _, m := range msgs {
+		n += t.Message(m)
+	}
+	return n
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"ai-learn-english/pkg/apperror"
+	"ai-learn-english/pkg/logger"
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// ErrorHandler converts errors returned by handlers into JSON responses.
+// Application errors keep their status and code, fiber errors keep their
+// status, and anything else is logged and reported as a 500.
+func ErrorHandler(c fiber.Ctx, err error) error {
+	var appErr *apperror.ErrorResponse
+	if errors.As(err, &appErr) {
+		status := appErr.Status
+		if status == 0 {
+			status = fiber.StatusBadRequest
+		}
+		return c.Status(status).JSON(appErr)
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return c.Status(fiberErr.Code).JSON(apperror.New("", fiberErr.Message))
+	}
+
+	logger.Error(err, "unhandled error on %s %s", c.Method(), c.Path())
+	return c.Status(fiber.StatusInternalServerError).JSON(apperror.New("internal_error", "An unexpected error occurred"))
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/internal/token"
+	"ai-learn-english/pkg/apperror"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type userIDKey struct{}
+
+var ErrUnauthenticated = apperror.New("unauthenticated", "missing or invalid access token").WithStatus(fiber.StatusUnauthorized)
+
+// RequireUser verifies the bearer access token of the request and stores
+// the authenticated user id on the context. Tokens are checked against the
+// secret in config.Cfg.Auth.
+func RequireUser() fiber.Handler {
+	tokens := token.NewManager(config.Cfg.Auth)
+	return func(c fiber.Ctx) error {
+		raw, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok {
+			return ErrUnauthenticated
+		}
+		id, _, err := tokens.Parse(strings.TrimSpace(raw), token.TypeAccess)
+		if err != nil {
+			return ErrUnauthenticated
+		}
+		fiber.Locals(c, userIDKey{}, id)
+		return c.Next()
+	}
+}
+
+// UserID returns the id stored by RequireUser, or 0 when the request is anonymous.
+func UserID(c fiber.Ctx) int64 {
+	return fiber.Locals[int64](c, userIDKey{})
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/internal/token"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestRequireUser(t *testing.T) {
+	config.Cfg.Auth = config.AuthConfig{JWTSecret: "test-secret", AccessTTLMinutes: 5, RefreshTTLHours: 1}
+	tokens := token.NewManager(config.Cfg.Auth)
+	access, err := tokens.IssueAccess(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refresh, err := tokens.IssueRefresh(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreign, err := token.NewManager(config.AuthConfig{JWTSecret: "other-secret", AccessTTLMinutes: 5}).IssueAccess(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/me", RequireUser(), func(c fiber.Ctx) error {
+		return c.SendString(strconv.FormatInt(UserID(c), 10))
+	})
+
+	tests := []struct {
+		name   string
+		header string
+		status int
+	}{
+		{"valid", "Bearer " + access.Token, http.StatusOK},
+		{"surrounding spaces", "Bearer  " + access.Token + " ", http.StatusOK},
+		{"missing", "", http.StatusUnauthorized},
+		{"no scheme", access.Token, http.StatusUnauthorized},
+		{"other scheme", "Basic " + access.Token, http.StatusUnauthorized},
+		{"empty token", "Bearer ", http.StatusUnauthorized},
+		{"malformed token", "Bearer not-a-jwt", http.StatusUnauthorized},
+		{"refresh token", "Bearer " + refresh.Token, http.StatusUnauthorized},
+		{"other secret", "Bearer " + foreign.Token, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			if tt.header != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.header)
+			}
+			res, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(res.Body)
+			if res.StatusCode != tt.status {
+				t.Fatalf("status %d, want %d: %s", res.StatusCode, tt.status, body)
+			}
+			if tt.status == http.StatusOK && string(body) != "42" {
+				t.Errorf("user id = %s, want 42", body)
+			}
+			if tt.status == http.StatusUnauthorized && string(body) != `{"code":"unauthenticated","message":"missing or invalid access token"}` {
+				t.Errorf("body = %s", body)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+// Package reconcile compares the chunk rows in MySQL with the vectors in the
+// vector store, reports where they disagree and optionally repairs them.
+package reconcile
+
+import (
+	"ai-learn-english/internal/collections"
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/internal/ingest"
+	"ai-learn-english/internal/vectorstore"
+	"ai-learn-english/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gen"
+)
+
+// readBatch is the number of chunk rows loaded per query.
+const readBatch = 1000
+
+// ChunkRef identifies a chunk row.
+type ChunkRef struct {
+	ChunkID    int64 `json:"chunk_id"`
+	DocumentID int64 `json:"document_id"`
+}
+
+// VectorRef identifies a record in the vector store.
+type VectorRef struct {
+	ID         int64 `json:"id"`
+	UserID     int64 `json:"user_id"`
+	DocumentID int64 `json:"document_id"`
+}
+
+// CollectionReport describes one vector collection. MissingVectors are chunk
+// rows that point at the collection but have no vector there; OrphanVectors
+// are vectors no chunk row points at.
+type CollectionReport struct {
+	Name           string      `json:"name"`
+	Exists         bool        `json:"exists"`
+	Chunks         int         `json:"chunks"`
+	Vectors        int         `json:"vectors"`
+	MissingVectors []ChunkRef  `json:"missing_vectors"`
+	OrphanVectors  []VectorRef `json:"orphan_vectors"`
+}
+
+// Repairs counts what a repair run changed.
+type Repairs struct {
+	ReEmbedded     int `json:"re_embedded"`
+	DeletedVectors int `json:"deleted_vectors"`
+}
+
+// Report is the result of a run. MissingEmbeddings are chunks of ready
+// documents that were never indexed. Documents still being processed are
+// skipped since their chunks and vectors are in flux.
+type Report struct {
+	StartedAt         time.Time          `json:"started_at"`
+	FinishedAt        time.Time          `json:"finished_at"`
+	DryRun            bool               `json:"dry_run"`
+	Collections       []CollectionReport `json:"collections"`
+	MissingEmbeddings []ChunkRef         `json:"missing_embeddings"`
+	Repairs           *Repairs           `json:"repairs,omitempty"`
+}
+
+// Clean reports whether the run found nothing to repair.
+func (r *Report) Clean() bool {
+	if len(r.MissingEmbeddings) > 0 {
+		return false
+	}
+	for _, c := range r.Collections {
+		if len(c.MissingVectors) > 0 || len(c.OrphanVectors) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Summary is a one-line description of r for logs.
+func (r *Report) Summary() string {
+	var chunks, vectors, missing, orphans int
+	for _, c := range r.Collections {
+		chunks += c.Chunks
+		vectors += c.Vectors
+		missing += len(c.MissingVectors)
+		orphans += len(c.OrphanVectors)
+	}
+	s := fmt.Sprintf("%d collections, %d chunks, %d vectors: %d missing vectors, %d orphan vectors, %d missing embeddings",
+		len(r.Collections), chunks, vectors, missing, orphans, len(r.MissingEmbeddings))
+	if r.Repairs != nil {
+		s += fmt.Sprintf("; re-embedded %d, deleted %d", r.Repairs.ReEmbedded, r.Repairs.DeletedVectors)
+	}
+	return s
+}
+
+// Reconciler checks chunk rows against the vector store. Repairs embed
+// into the active collection.
+type Reconciler struct {
+	q           *query.Query
+	collections *collections.Manager
+	store       vectorstore.VectorStore
+}
+
+func New(q *query.Query, collections *collections.Manager, store vectorstore.VectorStore) *Reconciler {
+	return &Reconciler{q: q, collections: collections, store: store}
+}
+
+// Run compares every chunk row with the vector store. With repair set it
+// deletes orphan vectors and re-embeds chunks whose vector is missing;
+// otherwise nothing is changed.
+func (r *Reconciler) Run(ctx context.Context, repair bool) (*Report, error) {
+	report := &Report{StartedAt: time.Now(), DryRun: !repair}
+
+	busy, err := r.busyDocuments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byCollection, missing, err := r.loadChunks(ctx, busy)
+	if err != nil {
+		return nil, err
+	}
+	report.MissingEmbeddings = missing
+
+	active, err := r.collections.Active(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := byCollection[active.Name]; !ok {
+		byCollection[active.Name] = map[int64]int64{}
+	}
+	names := make([]string, 0, len(byCollection))
+	for name := range byCollection {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		cr, err := r.checkCollection(ctx, name, byCollection[name], busy)
+		if err != nil {
+			return nil, err
+		}
+		report.Collections = append(report.Collections, *cr)
+	}
+
+	if repair {
+		if report.Repairs, err = r.repair(ctx, active, report); err != nil {
+			return nil, err
+		}
+	}
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+// busyDocuments returns the ids of documents that are being processed.
+func (r *Reconciler) busyDocuments(ctx context.Context) (map[int64]bool, error) {
+	d := r.q.Document
+	var ids []int64
+	err := d.WithContext(ctx).
+		Where(d.Status.NotIn(ingest.StatusReady, ingest.StatusFailed)).
+		Pluck(d.ID, &ids)
+	if err != nil {
+		return nil, fmt.Errorf("load documents in progress: %w", err)
+	}
+	busy := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		busy[id] = true
+	}
+	return busy, nil
+}
+
+// loadChunks groups the chunk ids of settled documents by collection, mapped
+// to their document id, and lists the chunks of ready documents that have
+// no collection at all.
+func (r *Reconciler) loadChunks(ctx context.Context, busy map[int64]bool) (map[string]map[int64]int64, []ChunkRef, error) {
+	c, d := r.q.Chunk, r.q.Document
+	ready := make(map[int64]bool)
+	var readyIDs []int64
+	if err := d.WithContext(ctx).Where(d.Status.Eq(ingest.StatusReady)).Pluck(d.ID, &readyIDs); err != nil {
+		return nil, nil, fmt.Errorf("load ready documents: %w", err)
+	}
+	for _, id := range readyIDs {
+		ready[id] = true
+	}
+
+	byCollection := make(map[string]map[int64]int64)
+	var missing []ChunkRef
+	var rows []*model.Chunk
+	err := c.WithContext(ctx).
+		Select(c.ID, c.DocumentID, c.MilvusCollection).
+		FindInBatches(&rows, readBatch, func(tx gen.Dao, batch int) error {
+			for _, ch := range rows {
+				if busy[ch.DocumentID] {
+					continue
+				}
+				if ch.MilvusCollection == "" {
+					if ready[ch.DocumentID] {
+						missing = append(missing, ChunkRef{ChunkID: ch.ID, DocumentID: ch.DocumentID})
+					}
+					continue
+				}
+				ids, ok := byCollection[ch.MilvusCollection]
+				if !ok {
+					ids = make(map[int64]int64)
+					byCollection[ch.MilvusCollection] = ids
+				}
+				ids[ch.ID] = ch.DocumentID
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, nil, fmt.Errorf("load chunks: %w", err)
+	}
+	return byCollection, missing, nil
+}
+
+// checkCollection scans collection and matches its vectors against chunks,
+// the chunk ids that point at it.
+func (r *Reconciler) checkCollection(ctx context.Context, name string, chunks map[int64]int64, busy map[int64]bool) (*CollectionReport, error) {
+	cr := &CollectionReport{Name: name, Exists: true, Chunks: len(chunks)}
+	seen := make(map[int64]bool, len(chunks))
+	err := r.store.Scan(ctx, name, func(batch []vectorstore.Record) error {
+		cr.Vectors += len(batch)
+		for _, rec := range batch {
+			if _, ok := chunks[rec.ID]; ok {
+				seen[rec.ID] = true
+				continue
+			}
+			if busy[rec.DocumentID] {
+				continue
+			}
+			cr.OrphanVectors = append(cr.OrphanVectors, VectorRef{ID: rec.ID, UserID: rec.UserID, DocumentID: rec.DocumentID})
+		}
+		return nil
+	})
+	if errors.Is(err, vectorstore.ErrCollectionNotFound) {
+		cr.Exists = false
+	} else if err != nil {
+		return nil, err
+	}
+
+	for id, docID := range chunks {
+		if !seen[id] {
+			cr.MissingVectors = append(cr.MissingVectors, ChunkRef{ChunkID: id, DocumentID: docID})
+		}
+	}
+	sort.Slice(cr.MissingVectors, func(i, j int) bool { return cr.MissingVectors[i].ChunkID < cr.MissingVectors[j].ChunkID })
+	return cr, nil
+}
+
+// Every runs the reconciler each interval until ctx is done and logs a
+// summary of each report. It is meant for the worker; when several workers
+// run it they only repeat each other's work.
+func (r *Reconciler) Every(ctx context.Context, interval time.Duration, repair bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		report, err := r.Run(ctx, repair)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			logger.Error(err, "reconcile")
+		case err != nil:
+			return
+		case report.Clean():
+			logger.Info("reconcile: %s", report.Summary())
+		default:
+			logger.Warn("reconcile found drift: %s", report.Summary())
+		}
+	}
+}
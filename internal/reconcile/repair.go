@@ -0,0 +1,101 @@
+package reconcile
+
+import (
+	"ai-learn-english/internal/collections"
+	"ai-learn-english/internal/embedding"
+	"ai-learn-english/internal/vectorstore"
+	"context"
+	"fmt"
+)
+
+// embedBatch is the number of chunks re-embedded per embedder call.
+const embedBatch = 64
+
+// repair deletes the orphan vectors found in report and re-embeds every
+// chunk that is missing a vector into the active collection.
+func (r *Reconciler) repair(ctx context.Context, active *collections.Collection, report *Report) (*Repairs, error) {
+	out := &Repairs{}
+	refs := append([]ChunkRef(nil), report.MissingEmbeddings...)
+	for _, cr := range report.Collections {
+		if len(cr.OrphanVectors) > 0 {
+			ids := make([]int64, len(cr.OrphanVectors))
+			for i, v := range cr.OrphanVectors {
+				ids[i] = v.ID
+			}
+			if err := r.store.Delete(ctx, cr.Name, ids); err != nil {
+				return out, err
+			}
+			out.DeletedVectors += len(ids)
+		}
+		refs = append(refs, cr.MissingVectors...)
+	}
+	if len(refs) == 0 {
+		return out, nil
+	}
+
+	if err := embedding.EnsureCollection(ctx, r.store, active.Name, active.Embedder); err != nil {
+		return out, err
+	}
+	for start := 0; start < len(refs); start += embedBatch {
+		n, err := r.reEmbed(ctx, active, refs[start:min(start+embedBatch, len(refs))])
+		out.ReEmbedded += n
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+// reEmbed embeds refs, stores the vectors in active and points the chunk
+// rows at them. Chunks deleted since the scan are skipped.
+func (r *Reconciler) reEmbed(ctx context.Context, active *collections.Collection, refs []ChunkRef) (int, error) {
+	ids := make([]int64, len(refs))
+	for i, ref := range refs {
+		ids[i] = ref.ChunkID
+	}
+	c, d := r.q.Chunk, r.q.Document
+	chunks, err := c.WithContext(ctx).Where(c.ID.In(ids...)).Find()
+	if err != nil {
+		return 0, fmt.Errorf("load chunks: %w", err)
+	}
+	if len(chunks) == 0 {
+		return 0, nil
+	}
+
+	docIDs := make([]int64, 0, len(chunks))
+	for _, ch := range chunks {
+		docIDs = append(docIDs, ch.DocumentID)
+	}
+	docs, err := d.WithContext(ctx).Select(d.ID, d.UserID).Where(d.ID.In(docIDs...)).Find()
+	if err != nil {
+		return 0, fmt.Errorf("load documents: %w", err)
+	}
+	owners := make(map[int64]int64, len(docs))
+	for _, doc := range docs {
+		owners[doc.ID] = doc.UserID
+	}
+
+	texts := make([]string, len(chunks))
+	for i, ch := range chunks {
+		texts[i] = ch.Content
+	}
+	vectors, err := active.Embedder.Embed(ctx, texts)
+	if err != nil {
+		return 0, fmt.Errorf("embed chunks: %w", err)
+	}
+
+	records := make([]vectorstore.Record, len(chunks))
+	found := make([]int64, len(chunks))
+	for i, ch := range chunks {
+		records[i] = vectorstore.Record{ID: ch.ID, UserID: owners[ch.DocumentID], DocumentID: ch.DocumentID, Vector: vectors[i]}
+		found[i] = ch.ID
+	}
+	if err := r.store.Upsert(ctx, active.Name, records); err != nil {
+		return 0, err
+	}
+	_, err = c.WithContext(ctx).Where(c.ID.In(found...)).UpdateSimple(c.MilvusCollection.Value(active.Name), c.MilvusID.SetCol(c.ID))
+	if err != nil {
+		return 0, fmt.Errorf("record vector ids: %w", err)
+	}
+	return len(chunks), nil
+}
@@ -0,0 +1,150 @@
+package retrieval
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// BM25 parameters: k1 controls term frequency saturation and b how much
+// long chunks are penalised.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// stopwords are dropped from both chunks and queries. The list is kept short
+// on purpose: learners search for function words too ("the use of 'whom'"),
+// and IDF already discounts words that appear everywhere.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "in": true, "is": true,
+	"it": true, "of": true, "on": true, "or": true, "that": true, "the": true,
+	"this": true, "to": true, "was": true, "what": true, "with": true,
+}
+
+// terms splits text into lowercased words for indexing. Apostrophes inside a
+// word are kept ("don't") but a trailing possessive "'s" is removed.
+func terms(text string) []string {
+	var out []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '’'
+	}) {
+		w = strings.Trim(strings.ReplaceAll(w, "’", "'"), "'")
+		w = strings.TrimSuffix(w, "'s")
+		if w == "" || stopwords[w] {
+			continue
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+type posting struct {
+	doc int32 // index into bm25Index.docs
+	tf  int32
+}
+
+type indexedChunk struct {
+	chunkID    int64
+	documentID int64
+	length     int
+}
+
+// bm25Index is an immutable inverted index over a set of chunks.
+type bm25Index struct {
+	docs     []indexedChunk
+	postings map[string][]posting
+	avgLen   float64
+}
+
+// keywordHit is a chunk matched by a keyword search.
+type keywordHit struct {
+	ChunkID    int64
+	DocumentID int64
+	Score      float64
+}
+
+func newBM25Index() *bm25Index {
+	return &bm25Index{postings: make(map[string][]posting)}
+}
+
+// add indexes the content of one chunk. It must not be called after search.
+func (ix *bm25Index) add(chunkID, documentID int64, content string) {
+	ts := terms(content)
+	doc := int32(len(ix.docs))
+	ix.docs = append(ix.docs, indexedChunk{chunkID: chunkID, documentID: documentID, length: len(ts)})
+
+	counts := make(map[string]int32, len(ts))
+	for _, t := range ts {
+		counts[t]++
+	}
+	for t, tf := range counts {
+		ix.postings[t] = append(ix.postings[t], posting{doc: doc, tf: tf})
+	}
+}
+
+// finish computes the statistics needed by search.
+func (ix *bm25Index) finish() {
+	var total int
+	for _, d := range ix.docs {
+		total += d.length
+	}
+	if len(ix.docs) > 0 {
+		ix.avgLen = float64(total) / float64(len(ix.docs))
+	}
+}
+
+// search returns the topK chunks with the highest BM25 score for query, best
+// first. When documentIDs is not empty only chunks of those documents count.
+func (ix *bm25Index) search(query string, topK int, documentIDs []int64) []keywordHit {
+	if len(ix.docs) == 0 {
+		return nil
+	}
+	allowed := make(map[int64]bool, len(documentIDs))
+	for _, id := range documentIDs {
+		allowed[id] = true
+	}
+
+	n := float64(len(ix.docs))
+	scores := make(map[int32]float64)
+	seen := make(map[string]bool)
+	for _, t := range terms(query) {
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		list := ix.postings[t]
+		if len(list) == 0 {
+			continue
+		}
+		df := float64(len(list))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for _, p := range list {
+			d := ix.docs[p.doc]
+			if len(allowed) > 0 && !allowed[d.documentID] {
+				continue
+			}
+			tf := float64(p.tf)
+			norm := 1 - bm25B + bm25B*float64(d.length)/ix.avgLen
+			scores[p.doc] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+	}
+
+	hits := make([]keywordHit, 0, len(scores))
+	for doc, score := range scores {
+		d := ix.docs[doc]
+		hits = append(hits, keywordHit{ChunkID: d.chunkID, DocumentID: d.documentID, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ChunkID < hits[j].ChunkID
+	})
+	if topK > 0 && len(hits) > topK {
+		hits = hits[:topK]
+	}
+	return hits
+}
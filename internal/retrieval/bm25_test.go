@@ -0,0 +1,159 @@
+package retrieval
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"The cat's toy", []string{"cat", "toy"}},
+		{"Don't STOP", []string{"don't", "stop"}},
+		{"learner’s “quoted” words", []string{"learner", "quoted", "words"}},
+		{"a, the, of!", nil},
+		{"B2 level, 2024", []string{"b2", "level", "2024"}},
+	}
+	for _, tt := range tests {
+		if got := terms(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("terms(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func newIndex(chunks map[int64]string) *bm25Index {
+	ix := newBM25Index()
+	ids := make([]int64, 0, len(chunks))
+	for id := range chunks {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		ix.add(id, id/100, chunks[id])
+	}
+	ix.finish()
+	return ix
+}
+
+func hitIDs(hits []keywordHit) []int64 {
+	out := make([]int64, len(hits))
+	for i, h := range hits {
+		out[i] = h.ChunkID
+	}
+	return out
+}
+
+func TestBM25Ranking(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks map[int64]string
+		query  string
+		want   []int64
+	}{
+		{
+			"rare term outweighs common term",
+			map[int64]string{
+				101: "grammar grammar lesson",
+				102: "grammar subjunctive lesson",
+				103: "grammar practice lesson",
+			},
+			"grammar subjunctive",
+			[]int64{102, 101, 103},
+		},
+		{
+			"higher term frequency ranks first",
+			map[int64]string{
+				101: "verb noun noun noun",
+				102: "verb verb verb noun",
+				103: "adjective adverb clause phrase",
+			},
+			"verb",
+			[]int64{102, 101},
+		},
+		{
+			"shorter chunk wins at equal frequency",
+			map[int64]string{
+				101: "idiom plus many other unrelated words filling this chunk",
+				102: "idiom here",
+				103: "nothing relevant",
+			},
+			"idiom",
+			[]int64{102, 101},
+		},
+		{
+			"exact phrase beats partial match",
+			map[int64]string{
+				101: "the present perfect continuous tense",
+				102: "the present day is sunny",
+				103: "a perfect score in the test",
+			},
+			"present perfect continuous",
+			[]int64{101, 102, 103},
+		},
+		{
+			"stopwords and repeated query terms are ignored",
+			map[int64]string{
+				101: "the of and to",
+				102: "whom is used as an object",
+			},
+			"the use of whom whom whom",
+			[]int64{102},
+		},
+		{
+			"ties are broken by chunk id",
+			map[int64]string{
+				102: "same words here",
+				101: "same words here",
+			},
+			"same",
+			[]int64{101, 102},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hitIDs(newIndex(tt.chunks).search(tt.query, 0, nil))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBM25Score(t *testing.T) {
+	// Two chunks of equal length, one of them matching once: the score is
+	// the plain BM25 formula with norm = 1.
+	ix := newIndex(map[int64]string{101: "tense practice", 102: "vocabulary practice"})
+	hits := ix.search("tense", 0, nil)
+	if len(hits) != 1 {
+		t.Fatalf("got %d hits, want 1", len(hits))
+	}
+	idf := math.Log(1 + (2-1+0.5)/(1+0.5))
+	want := idf * (bm25K1 + 1) / (1 + bm25K1)
+	if math.Abs(hits[0].Score-want) > 1e-9 {
+		t.Errorf("Score = %v, want %v", hits[0].Score, want)
+	}
+}
+
+func TestBM25Filters(t *testing.T) {
+	ix := newIndex(map[int64]string{
+		101: "phrasal verbs list",
+		102: "phrasal verbs practice",
+		201: "phrasal verbs in context",
+		202: "phrasal verbs quiz",
+	})
+	if got := hitIDs(ix.search("phrasal", 0, []int64{2})); !slices.Equal(got, []int64{201, 202}) {
+		t.Errorf("document filter: got %v, want [201 202]", got)
+	}
+	if got := ix.search("phrasal", 3, nil); len(got) != 3 {
+		t.Errorf("topK 3: got %d hits", len(got))
+	}
+	if got := ix.search("unknown", 0, nil); len(got) != 0 {
+		t.Errorf("unknown term: got %v", got)
+	}
+	if got := newIndex(nil).search("phrasal", 0, nil); got != nil {
+		t.Errorf("empty index: got %v", got)
+	}
+}
@@ -0,0 +1,154 @@
+package retrieval
+
+import (
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/internal/ingest"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gen"
+)
+
+// keywordIndexes keeps one BM25 index per user in memory. An index is
+// rebuilt when the user's set of ready chunks changes, which is detected
+// from the chunk count and highest chunk id since re-chunking always
+// creates new rows. The least recently used indexes are dropped beyond
+// maxUsers.
+type keywordIndexes struct {
+	q        *query.Query
+	maxUsers int
+
+	mu    sync.Mutex
+	users map[int64]*userIndex
+}
+
+type userIndex struct {
+	mu       sync.Mutex // serialises rebuilds for one user
+	ix       *bm25Index
+	count    int64
+	maxID    int64
+	lastUsed time.Time
+}
+
+func newKeywordIndexes(q *query.Query, maxUsers int) *keywordIndexes {
+	if maxUsers <= 0 {
+		maxUsers = 100
+	}
+	return &keywordIndexes{q: q, maxUsers: maxUsers, users: make(map[int64]*userIndex)}
+}
+
+// search runs a BM25 query over the ready chunks of userID.
+func (k *keywordIndexes) search(ctx context.Context, userID int64, question string, topK int, documentIDs []int64) ([]keywordHit, error) {
+	ix, err := k.index(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return ix.search(question, topK, documentIDs), nil
+}
+
+// index returns an up to date index for userID, building it if needed.
+func (k *keywordIndexes) index(ctx context.Context, userID int64) (*bm25Index, error) {
+	u := k.entry(userID)
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	docIDs, err := k.readyDocuments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	count, maxID, err := k.fingerprint(ctx, docIDs)
+	if err != nil {
+		return nil, err
+	}
+	if u.ix != nil && u.count == count && u.maxID == maxID {
+		return u.ix, nil
+	}
+
+	ix, err := k.build(ctx, docIDs)
+	if err != nil {
+		return nil, err
+	}
+	u.ix, u.count, u.maxID = ix, count, maxID
+	return ix, nil
+}
+
+// entry returns the cache slot of userID, evicting the least recently used
+// slot when the cache is full.
+func (k *keywordIndexes) entry(userID int64) *userIndex {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	u, ok := k.users[userID]
+	if !ok {
+		if len(k.users) >= k.maxUsers {
+			var oldest int64
+			var oldestAt time.Time
+			for id, other := range k.users {
+				if oldestAt.IsZero() || other.lastUsed.Before(oldestAt) {
+					oldest, oldestAt = id, other.lastUsed
+				}
+			}
+			delete(k.users, oldest)
+		}
+		u = &userIndex{}
+		k.users[userID] = u
+	}
+	u.lastUsed = time.Now()
+	return u
+}
+
+func (k *keywordIndexes) readyDocuments(ctx context.Context, userID int64) ([]int64, error) {
+	d := k.q.Document
+	var ids []int64
+	err := d.WithContext(ctx).Where(d.UserID.Eq(userID), d.Status.Eq(ingest.StatusReady)).Pluck(d.ID, &ids)
+	if err != nil {
+		return nil, fmt.Errorf("load ready documents: %w", err)
+	}
+	return ids, nil
+}
+
+func (k *keywordIndexes) fingerprint(ctx context.Context, docIDs []int64) (count, maxID int64, err error) {
+	if len(docIDs) == 0 {
+		return 0, 0, nil
+	}
+	c := k.q.Chunk
+	var row struct {
+		Count int64
+		MaxID *int64
+	}
+	err = c.WithContext(ctx).
+		Select(c.ID.Count().As("count"), c.ID.Max().As("max_id")).
+		Where(c.DocumentID.In(docIDs...)).
+		Scan(&row)
+	if err != nil {
+		return 0, 0, fmt.Errorf("fingerprint chunks: %w", err)
+	}
+	if row.MaxID != nil {
+		maxID = *row.MaxID
+	}
+	return row.Count, maxID, nil
+}
+
+func (k *keywordIndexes) build(ctx context.Context, docIDs []int64) (*bm25Index, error) {
+	ix := newBM25Index()
+	if len(docIDs) > 0 {
+		c := k.q.Chunk
+		var rows []*model.Chunk
+		err := c.WithContext(ctx).
+			Select(c.ID, c.DocumentID, c.Content).
+			Where(c.DocumentID.In(docIDs...)).
+			FindInBatches(&rows, 1000, func(tx gen.Dao, batch int) error {
+				for _, ch := range rows {
+					ix.add(ch.ID, ch.DocumentID, ch.Content)
+				}
+				return nil
+			})
+		if err != nil {
+			return nil, fmt.Errorf("load chunks for keyword index: %w", err)
+		}
+	}
+	ix.finish()
+	return ix, nil
+}
@@ -0,0 +1,159 @@
+// Package retrieval finds the chunks of a user's documents that are most
+// relevant to a question.
+package retrieval
+
+import (
+	"ai-learn-english/config"
+	"ai-learn-english/internal/collections"
+	"ai-learn-english/internal/database/model"
+	"ai-learn-english/internal/database/query"
+	"ai-learn-english/internal/vectorstore"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Passage is a retrieved chunk together with its relevance score. Score is
+// the fused reciprocal rank score; VectorRank and KeywordRank are the
+// 1-based positions in each result list, 0 when the chunk was not in it.
+type Passage struct {
+	Chunk       *model.Chunk
+	Score       float32
+	VectorRank  int
+	KeywordRank int
+}
+
+// Retriever runs hybrid search over the chunks of one user: vector
+// similarity and BM25 keyword search, fused with reciprocal rank fusion.
+// Keyword search catches exact words and phrases that embeddings blur.
+type Retriever struct {
+	q           *query.Query
+	collections *collections.Manager
+	store       vectorstore.VectorStore
+	keywords    *keywordIndexes
+	cfg         config.RetrievalConfig
+}
+
+func New(q *query.Query, collections *collections.Manager, store vectorstore.VectorStore, cfg config.RetrievalConfig) *Retriever {
+	return &Retriever{q: q, collections: collections, store: store, keywords: newKeywordIndexes(q, cfg.KeywordCacheUsers), cfg: cfg}
+}
+
+// Retrieve returns up to topK passages of userID's documents most relevant
+// to question, best first. When documentIDs is not empty only those
+// documents are searched. A weight of 0 in the config turns that side of
+// the search off.
+func (r *Retriever) Retrieve(ctx context.Context, userID int64, question string, topK int, documentIDs ...int64) ([]Passage, error) {
+	candidates := max(r.cfg.Candidates, topK)
+
+	var vectorIDs, keywordIDs []int64
+	if r.cfg.VectorWeight > 0 {
+		hits, err := r.vectorSearch(ctx, userID, question, candidates, documentIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hits {
+			vectorIDs = append(vectorIDs, h.ID)
+		}
+	}
+	if r.cfg.KeywordWeight > 0 {
+		hits, err := r.keywords.search(ctx, userID, question, candidates, documentIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hits {
+			keywordIDs = append(keywordIDs, h.ChunkID)
+		}
+	}
+
+	fused := fuse(vectorIDs, keywordIDs, r.cfg.VectorWeight, r.cfg.KeywordWeight, r.cfg.RRFK)
+	if len(fused) > topK {
+		fused = fused[:topK]
+	}
+	if len(fused) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, len(fused))
+	for i, f := range fused {
+		ids[i] = f.Chunk.ID
+	}
+	c := r.q.Chunk
+	chunks, err := c.WithContext(ctx).Where(c.ID.In(ids...)).Find()
+	if err != nil {
+		return nil, fmt.Errorf("load chunks: %w", err)
+	}
+	byID := make(map[int64]*model.Chunk, len(chunks))
+	for _, ch := range chunks {
+		byID[ch.ID] = ch
+	}
+
+	// Keep the fused ranking and skip hits whose chunk row is gone.
+	passages := make([]Passage, 0, len(fused))
+	for _, f := range fused {
+		if ch, ok := byID[f.Chunk.ID]; ok {
+			f.Chunk = ch
+			passages = append(passages, f)
+		}
+	}
+	return passages, nil
+}
+
+// vectorSearch returns the chunks closest to question by embedding, in the
+// active collection.
+func (r *Retriever) vectorSearch(ctx context.Context, userID int64, question string, topK int, documentIDs []int64) ([]vectorstore.Result, error) {
+	active, err := r.collections.Active(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vectors, err := active.Embedder.Embed(ctx, []string{question})
+	if err != nil {
+		return nil, fmt.Errorf("embed question: %w", err)
+	}
+
+	hits, err := r.store.Search(ctx, active.Name, vectors[0], topK, vectorstore.Filter{UserID: userID, DocumentIDs: documentIDs})
+	if errors.Is(err, vectorstore.ErrCollectionNotFound) {
+		return nil, nil
+	}
+	return hits, err
+}
+
+// fuse merges two ranked lists of chunk ids with weighted reciprocal rank
+// fusion: each list contributes weight / (k + rank). The returned passages
+// only carry the chunk id.
+func fuse(vectorIDs, keywordIDs []int64, vectorWeight, keywordWeight float64, k int) []Passage {
+	if k <= 0 {
+		k = 60
+	}
+	byID := make(map[int64]*Passage)
+	scores := make(map[int64]float64)
+	get := func(id int64) *Passage {
+		p, ok := byID[id]
+		if !ok {
+			p = &Passage{Chunk: &model.Chunk{ID: id}}
+			byID[id] = p
+		}
+		return p
+	}
+	for i, id := range vectorIDs {
+		get(id).VectorRank = i + 1
+		scores[id] += vectorWeight / float64(k+i+1)
+	}
+	for i, id := range keywordIDs {
+		get(id).KeywordRank = i + 1
+		scores[id] += keywordWeight / float64(k+i+1)
+	}
+
+	out := make([]Passage, 0, len(byID))
+	for id, p := range byID {
+		p.Score = float32(scores[id])
+		out = append(out, *p)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if scores[out[i].Chunk.ID] != scores[out[j].Chunk.ID] {
+			return scores[out[i].Chunk.ID] > scores[out[j].Chunk.ID]
+		}
+		return out[i].Chunk.ID < out[j].Chunk.ID
+	})
+	return out
+}
@@ -0,0 +1,75 @@
+package retrieval
+
+import (
+	"slices"
+	"testing"
+)
+
+func passageIDs(passages []Passage) []int64 {
+	out := make([]int64, len(passages))
+	for i, p := range passages {
+		out[i] = p.Chunk.ID
+	}
+	return out
+}
+
+func TestFuse(t *testing.T) {
+	tests := []struct {
+		name         string
+		vector, kw   []int64
+		vectorW, kwW float64
+		k            int
+		want         []int64
+	}{
+		{"agreement wins", []int64{1, 2, 3}, []int64{3, 2, 4}, 1, 1, 60, []int64{3, 2, 1, 4}},
+		{"keyword weight 0 keeps vector order", []int64{5, 6, 7}, nil, 1, 0, 60, []int64{5, 6, 7}},
+		{"vector weight 0 keeps keyword order", nil, []int64{9, 8}, 0, 1, 60, []int64{9, 8}},
+		{"zero weight list does not reorder", []int64{1, 2}, []int64{2, 1}, 1, 0, 60, []int64{1, 2}},
+		{"heavier keyword weight", []int64{1, 2}, []int64{2, 1}, 1, 2, 60, []int64{2, 1}},
+		{"k 0 falls back to 60", []int64{1, 2, 3}, []int64{3, 2, 4}, 1, 1, 0, []int64{3, 2, 1, 4}},
+		{"equal scores by chunk id", []int64{4}, []int64{3}, 1, 1, 60, []int64{3, 4}},
+		{"both empty", nil, nil, 1, 1, 60, []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := passageIDs(fuse(tt.vector, tt.kw, tt.vectorW, tt.kwW, tt.k))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("fuse = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuseK(t *testing.T) {
+	// Chunk 1 is first in one list, chunk 2 fourth in both. A small k lets
+	// a single first place win; a large k rewards agreement.
+	vector := []int64{1, 10, 11, 2}
+	keyword := []int64{20, 21, 22, 2}
+	for _, tt := range []struct {
+		k         int
+		oneBefore bool
+	}{{1, true}, {60, false}} {
+		ids := passageIDs(fuse(vector, keyword, 1, 1, tt.k))
+		if got := slices.Index(ids, 1) < slices.Index(ids, 2); got != tt.oneBefore {
+			t.Errorf("k=%d: order %v, chunk 1 before chunk 2 = %v, want %v", tt.k, ids, got, tt.oneBefore)
+		}
+	}
+}
+
+func TestFuseRanksAndScore(t *testing.T) {
+	out := fuse([]int64{1, 2}, []int64{2}, 1, 1, 60)
+	byID := map[int64]Passage{}
+	for _, p := range out {
+		byID[p.Chunk.ID] = p
+	}
+	if p := byID[2]; p.VectorRank != 2 || p.KeywordRank != 1 {
+		t.Errorf("chunk 2 ranks = %d/%d, want 2/1", p.VectorRank, p.KeywordRank)
+	}
+	if p := byID[1]; p.VectorRank != 1 || p.KeywordRank != 0 {
+		t.Errorf("chunk 1 ranks = %d/%d, want 1/0", p.VectorRank, p.KeywordRank)
+	}
+	want := float32(1.0/62 + 1.0/61)
+	if got := byID[2].Score; got != want {
+		t.Errorf("chunk 2 score = %v, want %v", got, want)
+	}
+}
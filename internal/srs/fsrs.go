@@ -0,0 +1,86 @@
+package srs
+
+import (
+	"math"
+	"time"
+)
+
+// fsrsWeights are the default FSRS-4.5 parameters.
+var fsrsWeights = [17]float64{
+	0.4872, 1.4003, 3.7145, 13.8206, 5.1618, 1.2298, 0.8975, 0.031, 1.6474,
+	0.1367, 1.0461, 2.1072, 0.0793, 0.3246, 1.587, 0.2272, 2.8755,
+}
+
+const (
+	fsrsDecay  = -0.5
+	fsrsFactor = 19.0 / 81.0
+)
+
+// fsrs is the Free Spaced Repetition Scheduler (FSRS-4.5). It models each
+// card's memory stability and difficulty and schedules the next review for
+// when recall probability drops to the desired retention.
+type fsrs struct {
+	w           [17]float64
+	retention   float64
+	maxInterval int
+}
+
+func (a *fsrs) Name() string { return FSRS }
+
+func (a *fsrs) Next(s State, g Grade, now time.Time) State {
+	w := a.w
+	grade := float64(g)
+
+	if s.Stability == 0 {
+		// First review, or a card scheduled by SM-2 until now.
+		s.Stability = w[g-1]
+		s.Difficulty = a.initialDifficulty(grade)
+	} else {
+		r := a.retrievability(elapsedDays(s, now), s.Stability)
+		d := s.Difficulty
+		if g == Again {
+			s.Stability = math.Min(s.Stability,
+				w[11]*math.Pow(d, -w[12])*(math.Pow(s.Stability+1, w[13])-1)*math.Exp(w[14]*(1-r)))
+		} else {
+			bonus := 1.0
+			switch g {
+			case Hard:
+				bonus = w[15]
+			case Easy:
+				bonus = w[16]
+			}
+			s.Stability *= 1 + math.Exp(w[8])*(11-d)*math.Pow(s.Stability, -w[9])*(math.Exp(w[10]*(1-r))-1)*bonus
+		}
+		next := d - w[6]*(grade-3)
+		s.Difficulty = clamp(w[7]*a.initialDifficulty(3)+(1-w[7])*next, 1, 10)
+	}
+
+	if g == Again {
+		s.Reps = 0
+		s.Lapses++
+	} else {
+		s.Reps++
+	}
+	return schedule(s, a.interval(s.Stability), now)
+}
+
+func (a *fsrs) initialDifficulty(grade float64) float64 {
+	return clamp(a.w[4]-(grade-3)*a.w[5], 1, 10)
+}
+
+// retrievability is the probability of recalling a card with stability s
+// after t days.
+func (a *fsrs) retrievability(t, s float64) float64 {
+	return math.Pow(1+fsrsFactor*t/s, fsrsDecay)
+}
+
+// interval is the number of days until recall probability falls to the
+// desired retention.
+func (a *fsrs) interval(stability float64) int {
+	days := stability / fsrsFactor * (math.Pow(a.retention, 1/fsrsDecay) - 1)
+	return min(max(int(math.Round(days)), 1), a.maxInterval)
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Min(hi, math.Max(lo, v))
+}
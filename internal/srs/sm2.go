@@ -0,0 +1,44 @@
+package srs
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	sm2InitialEase = 2.5
+	sm2MinEase     = 1.3
+)
+
+// sm2 is the SuperMemo-2 algorithm. Grades map to SM-2 quality as
+// Again=1, Hard=3, Good=4 and Easy=5.
+type sm2 struct {
+	maxInterval int
+}
+
+func (a *sm2) Name() string { return SM2 }
+
+func (a *sm2) Next(s State, g Grade, now time.Time) State {
+	q := map[Grade]float64{Again: 1, Hard: 3, Good: 4, Easy: 5}[g]
+	if s.Ease == 0 {
+		s.Ease = sm2InitialEase
+	}
+
+	days := 1
+	if q < 3 {
+		s.Reps = 0
+		s.Lapses++
+	} else {
+		s.Reps++
+		switch s.Reps {
+		case 1:
+			days = 1
+		case 2:
+			days = 6
+		default:
+			days = int(math.Round(float64(max(s.IntervalDays, 1)) * s.Ease))
+		}
+	}
+	s.Ease = math.Max(sm2MinEase, s.Ease+0.1-(5-q)*(0.08+(5-q)*0.02))
+	return schedule(s, min(days, a.maxInterval), now)
+}
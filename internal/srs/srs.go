@@ -0,0 +1,106 @@
+// Package srs schedules flashcard reviews with spaced repetition. The
+// scheduling algorithm is pluggable: SM-2 and FSRS are provided, and each
+// card remembers which one it is scheduled with.
+package srs
+
+import (
+	"ai-learn-english/config"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Grade is how well the learner recalled a card.
+type Grade int
+
+const (
+	Again Grade = 1 // forgotten
+	Hard  Grade = 2 // recalled with serious difficulty
+	Good  Grade = 3 // recalled after some hesitation
+	Easy  Grade = 4 // recalled perfectly
+)
+
+func (g Grade) Valid() bool {
+	return g >= Again && g <= Easy
+}
+
+// State is the scheduling state of a card. Ease and IntervalDays belong to
+// SM-2, Stability and Difficulty to FSRS; each algorithm keeps the fields of
+// the other as they are.
+type State struct {
+	Reps         int
+	Lapses       int
+	Ease         float64
+	IntervalDays int
+	Stability    float64
+	Difficulty   float64
+	Due          time.Time
+	LastReview   *time.Time
+}
+
+// Algorithm computes the state of a card after a review.
+type Algorithm interface {
+	Name() string
+	// Next returns s after a review graded g at now.
+	Next(s State, g Grade, now time.Time) State
+}
+
+// Algorithm names.
+const (
+	SM2  = "sm2"
+	FSRS = "fsrs"
+)
+
+var ErrUnknownAlgorithm = errors.New("unknown srs algorithm")
+
+// New returns the algorithm called name configured from cfg.
+func New(name string, cfg config.SRSConfig) (Algorithm, error) {
+	maxInterval := cfg.MaxIntervalDays
+	if maxInterval <= 0 {
+		maxInterval = 365
+	}
+	switch name {
+	case SM2:
+		return &sm2{maxInterval: maxInterval}, nil
+	case FSRS:
+		retention := cfg.DesiredRetention
+		if retention <= 0 || retention >= 1 {
+			retention = 0.9
+		}
+		return &fsrs{w: fsrsWeights, retention: retention, maxInterval: maxInterval}, nil
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnknownAlgorithm, name)
+	}
+}
+
+// Clock tells the time. It is injected wherever cards are scheduled so
+// schedules can be reproduced at fixed times.
+type Clock func() time.Time
+
+// SystemClock is the wall clock.
+func SystemClock() time.Time { return time.Now() }
+
+// Fixed returns a clock stopped at t.
+func Fixed(t time.Time) Clock {
+	return func() time.Time { return t }
+}
+
+const day = 24 * time.Hour
+
+// elapsedDays returns the days between the last review of s and now, 0 for
+// a card never reviewed.
+func elapsedDays(s State, now time.Time) float64 {
+	if s.LastReview == nil {
+		return 0
+	}
+	return math.Max(0, now.Sub(*s.LastReview).Hours()/24)
+}
+
+// schedule sets the interval of s to days and moves its due date there.
+func schedule(s State, days int, now time.Time) State {
+	s.IntervalDays = days
+	s.Due = now.Add(time.Duration(days) * day)
+	s.LastReview = &now
+	return s
+}
@@ -0,0 +1,201 @@
+package srs
+
+import (
+	"ai-learn-english/config"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+// step is the expected state after one review.
+type step struct {
+	grade      Grade
+	days       int
+	ease       float64 // SM-2
+	stability  float64 // FSRS
+	difficulty float64 // FSRS
+}
+
+// review grades a new card with each step in turn, every review taken
+// exactly when the card falls due, and checks the state after each one.
+func review(t *testing.T, a Algorithm, steps []step) State {
+	t.Helper()
+	var s State
+	clock := Fixed(start)
+	for i, st := range steps {
+		now := clock()
+		s = a.Next(s, st.grade, now)
+		if s.IntervalDays != st.days {
+			t.Errorf("review %d: interval = %d days, want %d", i+1, s.IntervalDays, st.days)
+		}
+		if want := now.AddDate(0, 0, st.days); !s.Due.Equal(want) {
+			t.Errorf("review %d: due %v, want %v", i+1, s.Due, want)
+		}
+		if s.LastReview == nil || !s.LastReview.Equal(now) {
+			t.Errorf("review %d: last review %v, want %v", i+1, s.LastReview, now)
+		}
+		if st.ease != 0 && !near(s.Ease, st.ease, 1e-9) {
+			t.Errorf("review %d: ease = %v, want %v", i+1, s.Ease, st.ease)
+		}
+		if st.stability != 0 && !near(s.Stability, st.stability, 1e-4) {
+			t.Errorf("review %d: stability = %.4f, want %.4f", i+1, s.Stability, st.stability)
+		}
+		if st.difficulty != 0 && !near(s.Difficulty, st.difficulty, 1e-4) {
+			t.Errorf("review %d: difficulty = %.4f, want %.4f", i+1, s.Difficulty, st.difficulty)
+		}
+		clock = Fixed(s.Due)
+	}
+	return s
+}
+
+func near(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+func mustNew(t *testing.T, name string, cfg config.SRSConfig) Algorithm {
+	t.Helper()
+	a, err := New(name, cfg)
+	if err != nil {
+		t.Fatalf("New(%q): %v", name, err)
+	}
+	return a
+}
+
+func TestSM2(t *testing.T) {
+	tests := []struct {
+		name   string
+		steps  []step
+		reps   int
+		lapses int
+	}{
+		{"good", []step{
+			{grade: Good, days: 1, ease: 2.5},
+			{grade: Good, days: 6, ease: 2.5},
+			{grade: Good, days: 15, ease: 2.5},
+			{grade: Good, days: 38, ease: 2.5},
+		}, 4, 0},
+		{"easy", []step{
+			{grade: Easy, days: 1, ease: 2.6},
+			{grade: Easy, days: 6, ease: 2.7},
+			{grade: Easy, days: 16, ease: 2.8},
+			{grade: Easy, days: 45, ease: 2.9},
+		}, 4, 0},
+		{"hard", []step{
+			{grade: Hard, days: 1, ease: 2.36},
+			{grade: Hard, days: 6, ease: 2.22},
+			{grade: Hard, days: 13, ease: 2.08},
+		}, 3, 0},
+		{"again floors the ease", []step{
+			{grade: Again, days: 1, ease: 1.96},
+			{grade: Again, days: 1, ease: 1.42},
+			{grade: Again, days: 1, ease: 1.3},
+		}, 0, 3},
+		{"lapse restarts the intervals", []step{
+			{grade: Good, days: 1, ease: 2.5},
+			{grade: Good, days: 6, ease: 2.5},
+			{grade: Good, days: 15, ease: 2.5},
+			{grade: Again, days: 1, ease: 1.96},
+			{grade: Good, days: 1, ease: 1.96},
+			{grade: Good, days: 6, ease: 1.96},
+		}, 2, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := review(t, mustNew(t, SM2, config.SRSConfig{}), tt.steps)
+			if s.Reps != tt.reps || s.Lapses != tt.lapses {
+				t.Errorf("reps %d, lapses %d; want %d, %d", s.Reps, s.Lapses, tt.reps, tt.lapses)
+			}
+		})
+	}
+}
+
+func TestFSRS(t *testing.T) {
+	tests := []struct {
+		name      string
+		retention float64
+		steps     []step
+		reps      int
+		lapses    int
+	}{
+		{"good", 0, []step{
+			{grade: Good, days: 4, stability: 3.7145, difficulty: 5.1618},
+			{grade: Good, days: 15, stability: 14.8081, difficulty: 5.1618},
+			{grade: Good, days: 49, stability: 49.4616, difficulty: 5.1618},
+			{grade: Good, days: 146, stability: 145.6706, difficulty: 5.1618},
+		}, 4, 0},
+		{"easy", 0, []step{
+			{grade: Easy, days: 14, stability: 13.8206, difficulty: 3.932},
+			{grade: Easy, days: 127, stability: 127.4815, difficulty: 3.1004},
+		}, 2, 0},
+		{"hard", 0, []step{
+			{grade: Hard, days: 1, stability: 1.4003, difficulty: 6.3916},
+			{grade: Hard, days: 2, stability: 1.9898, difficulty: 7.2232},
+		}, 2, 0},
+		{"again", 0, []step{
+			{grade: Again, days: 1, stability: 0.4872, difficulty: 7.6214},
+		}, 0, 1},
+		{"lapse", 0, []step{
+			{grade: Good, days: 4, stability: 3.7145, difficulty: 5.1618},
+			{grade: Good, days: 15, stability: 14.8081, difficulty: 5.1618},
+			{grade: Again, days: 3, stability: 3.1493, difficulty: 6.9012},
+			{grade: Good, days: 9, stability: 9.1982, difficulty: 6.8472},
+		}, 1, 1},
+		{"lower retention waits longer", 0.8, []step{
+			{grade: Good, days: 9, stability: 3.7145, difficulty: 5.1618},
+			{grade: Good, days: 62, stability: 25.8014, difficulty: 5.1618},
+		}, 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := mustNew(t, FSRS, config.SRSConfig{DesiredRetention: tt.retention})
+			s := review(t, a, tt.steps)
+			if s.Reps != tt.reps || s.Lapses != tt.lapses {
+				t.Errorf("reps %d, lapses %d; want %d, %d", s.Reps, s.Lapses, tt.reps, tt.lapses)
+			}
+		})
+	}
+}
+
+func TestMaxIntervalDays(t *testing.T) {
+	cfg := config.SRSConfig{MaxIntervalDays: 10}
+	t.Run(SM2, func(t *testing.T) {
+		review(t, mustNew(t, SM2, cfg), []step{
+			{grade: Good, days: 1},
+			{grade: Good, days: 6},
+			{grade: Good, days: 10},
+			{grade: Good, days: 10},
+		})
+	})
+	t.Run(FSRS, func(t *testing.T) {
+		review(t, mustNew(t, FSRS, cfg), []step{
+			{grade: Good, days: 4},
+			{grade: Good, days: 10},
+			{grade: Easy, days: 10},
+		})
+	})
+}
+
+func TestAlgorithmSwitch(t *testing.T) {
+	// A card scheduled by SM-2 starts over in FSRS but keeps its history.
+	sm2 := mustNew(t, SM2, config.SRSConfig{})
+	fsrs := mustNew(t, FSRS, config.SRSConfig{})
+	s := sm2.Next(State{}, Good, start)
+	s = fsrs.Next(s, Good, s.Due)
+	if s.Reps != 2 || s.IntervalDays != 4 || !near(s.Stability, 3.7145, 1e-4) || s.Ease != 2.5 {
+		t.Errorf("state after switching = %+v", s)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("leitner", config.SRSConfig{}); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("New(leitner) error = %v, want ErrUnknownAlgorithm", err)
+	}
+	for _, name := range []string{SM2, FSRS} {
+		if a := mustNew(t, name, config.SRSConfig{}); a.Name() != name {
+			t.Errorf("New(%q).Name() = %q", name, a.Name())
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Package token issues and verifies the signed JWTs used for sessions.
+// Access tokens are short lived and stateless; refresh tokens carry a jti
+// that is tracked in the refresh_tokens table so they can be rotated and
+// revoked.
+package token
+
+import (
+	"ai-learn-english/config"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	TypeAccess  = "access"
+	TypeRefresh = "refresh"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+type Claims struct {
+	jwt.RegisteredClaims
+	Type string `json:"typ"`
+}
+
+// Issued is a freshly signed token.
+type Issued struct {
+	Token     string
+	ID        string
+	ExpiresAt time.Time
+}
+
+type Manager struct {
+	secret     []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	now        func() time.Time
+}
+
+func NewManager(cfg config.AuthConfig) *Manager {
+	return &Manager{
+		secret:     []byte(cfg.JWTSecret),
+		accessTTL:  time.Duration(cfg.AccessTTLMinutes) * time.Minute,
+		refreshTTL: time.Duration(cfg.RefreshTTLHours) * time.Hour,
+		now:        time.Now,
+	}
+}
+
+func (m *Manager) IssueAccess(userID int64) (*Issued, error) {
+	return m.issue(userID, TypeAccess, m.accessTTL)
+}
+
+func (m *Manager) IssueRefresh(userID int64) (*Issued, error) {
+	return m.issue(userID, TypeRefresh, m.refreshTTL)
+}
+
+func (m *Manager) issue(userID int64, typ string, ttl time.Duration) (*Issued, error) {
+	jti, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	now := m.now()
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Subject:   strconv.FormatInt(userID, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		Type: typ,
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil {
+		return nil, fmt.Errorf("sign %s token: %w", typ, err)
+	}
+	return &Issued{Token: signed, ID: jti, ExpiresAt: claims.ExpiresAt.Time}, nil
+}
+
+// Parse verifies the signature, expiry and type of raw and returns the
+// user id and token id.
+func (m *Manager) Parse(raw, typ string) (int64, string, error) {
+	var claims Claims
+	_, err := jwt.ParseWithClaims(raw, &claims, func(*jwt.Token) (any, error) {
+		return m.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithTimeFunc(m.now), jwt.WithExpirationRequired())
+	if err != nil || claims.Type != typ {
+		return 0, "", ErrInvalidToken
+	}
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil || userID <= 0 {
+		return 0, "", ErrInvalidToken
+	}
+	return userID, claims.ID, nil
+}
+
+// AccessTTL is the lifetime of access tokens.
+func (m *Manager) AccessTTL() time.Duration { return m.accessTTL }
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,119 @@
+package token
+
+import (
+	"ai-learn-english/config"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var testConfig = config.AuthConfig{JWTSecret: "test-secret", AccessTTLMinutes: 15, RefreshTTLHours: 24}
+
+// at returns a manager whose clock reads t.
+func at(t time.Time) *Manager {
+	m := NewManager(testConfig)
+	m.now = func() time.Time { return t }
+	return m
+}
+
+func TestIssueAndParse(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m := at(now)
+
+	access, err := m.IssueAccess(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refresh, err := m.IssueRefresh(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if access.ID == refresh.ID || len(access.ID) != 32 {
+		t.Errorf("token ids %q and %q must be distinct random hex", access.ID, refresh.ID)
+	}
+	if !access.ExpiresAt.Equal(now.Add(15*time.Minute)) || !refresh.ExpiresAt.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("expiry %v and %v", access.ExpiresAt, refresh.ExpiresAt)
+	}
+
+	tests := []struct {
+		name  string
+		raw   string
+		typ   string
+		clock time.Time
+		ok    bool
+	}{
+		{"access", access.Token, TypeAccess, now, true},
+		{"refresh", refresh.Token, TypeRefresh, now, true},
+		{"access just before expiry", access.Token, TypeAccess, now.Add(15*time.Minute - time.Second), true},
+		{"refresh as access", refresh.Token, TypeAccess, now, false},
+		{"access as refresh", access.Token, TypeRefresh, now, false},
+		{"expired access", access.Token, TypeAccess, now.Add(16 * time.Minute), false},
+		{"expired refresh", refresh.Token, TypeRefresh, now.Add(25 * time.Hour), false},
+		{"tampered", access.Token[:len(access.Token)-2] + "xx", TypeAccess, now, false},
+		{"garbage", "not.a.token", TypeAccess, now, false},
+		{"empty", "", TypeAccess, now, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, jti, err := at(tt.clock).Parse(tt.raw, tt.typ)
+			if !tt.ok {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Errorf("err = %v, want ErrInvalidToken", err)
+				}
+				return
+			}
+			if err != nil || userID != 42 {
+				t.Fatalf("Parse = %d, %v", userID, err)
+			}
+			if want := map[string]string{TypeAccess: access.ID, TypeRefresh: refresh.ID}[tt.typ]; jti != want {
+				t.Errorf("jti = %q, want %q", jti, want)
+			}
+		})
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, claims Claims) string {
+	t.Helper()
+	raw, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func TestParseRejectsForgedTokens(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	claims := func(subject string, expires bool) Claims {
+		c := Claims{RegisteredClaims: jwt.RegisteredClaims{ID: "x", Subject: subject, IssuedAt: jwt.NewNumericDate(now)}, Type: TypeAccess}
+		if expires {
+			c.ExpiresAt = jwt.NewNumericDate(now.Add(time.Hour))
+		}
+		return c
+	}
+	secret := []byte(testConfig.JWTSecret)
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"other secret", sign(t, jwt.SigningMethodHS256, []byte("other-secret"), claims("42", true))},
+		{"other algorithm", sign(t, jwt.SigningMethodHS512, secret, claims("42", true))},
+		{"unsigned", sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, claims("42", true))},
+		{"no expiry", sign(t, jwt.SigningMethodHS256, secret, claims("42", false))},
+		{"subject not a number", sign(t, jwt.SigningMethodHS256, secret, claims("alice", true))},
+		{"subject not positive", sign(t, jwt.SigningMethodHS256, secret, claims("0", true))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := at(now).Parse(tt.raw, TypeAccess); !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("err = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+
+	// The same claims signed properly are accepted.
+	if id, _, err := at(now).Parse(sign(t, jwt.SigningMethodHS256, secret, claims("42", true)), TypeAccess); err != nil || id != 42 {
+		t.Errorf("valid token: %d, %v", id, err)
+	}
+}
@@ -0,0 +1,173 @@
+package vectorstore
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+)
+
+type memoryCollection struct {
+	dim     int
+	records map[int64]Record
+}
+
+// Memory is an in-process VectorStore that scores every record with cosine
+// similarity. It is meant for development and tests.
+type Memory struct {
+	mu          sync.RWMutex
+	collections map[string]*memoryCollection
+}
+
+func NewMemory() *Memory {
+	return &Memory{collections: make(map[string]*memoryCollection)}
+}
+
+func (m *Memory) CreateCollection(_ context.Context, name string, dim int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.collections[name]; !ok {
+		m.collections[name] = &memoryCollection{dim: dim, records: make(map[int64]Record)}
+	}
+	return nil
+}
+
+func (m *Memory) DropCollection(_ context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.collections, name)
+	return nil
+}
+
+func (m *Memory) Dimension(_ context.Context, name string) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c, ok := m.collections[name]
+	if !ok {
+		return 0, ErrCollectionNotFound
+	}
+	return c.dim, nil
+}
+
+func (m *Memory) Upsert(_ context.Context, collection string, records []Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.collections[collection]
+	if !ok {
+		return ErrCollectionNotFound
+	}
+	for _, r := range records {
+		if len(r.Vector) != c.dim {
+			return ErrDimensionMismatch
+		}
+	}
+	for _, r := range records {
+		r.Vector = slices.Clone(r.Vector)
+		c.records[r.ID] = r
+	}
+	return nil
+}
+
+func (m *Memory) DeleteByDocument(_ context.Context, collection string, documentID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.collections[collection]
+	if !ok {
+		return ErrCollectionNotFound
+	}
+	for id, r := range c.records {
+		if r.DocumentID == documentID {
+			delete(c.records, id)
+		}
+	}
+	return nil
+}
+
+func (m *Memory) Delete(_ context.Context, collection string, ids []int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.collections[collection]
+	if !ok {
+		return ErrCollectionNotFound
+	}
+	for _, id := range ids {
+		delete(c.records, id)
+	}
+	return nil
+}
+
+func (m *Memory) Scan(_ context.Context, collection string, fn func([]Record) error) error {
+	m.mu.RLock()
+	c, ok := m.collections[collection]
+	if !ok {
+		m.mu.RUnlock()
+		return ErrCollectionNotFound
+	}
+	records := make([]Record, 0, len(c.records))
+	for _, r := range c.records {
+		records = append(records, Record{ID: r.ID, UserID: r.UserID, DocumentID: r.DocumentID})
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+	for start := 0; start < len(records); start += scanBatch {
+		if err := fn(records[start:min(start+scanBatch, len(records))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Memory) Search(_ context.Context, collection string, vector []float32, topK int, filter Filter) ([]Result, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c, ok := m.collections[collection]
+	if !ok {
+		return nil, ErrCollectionNotFound
+	}
+	if len(vector) != c.dim {
+		return nil, ErrDimensionMismatch
+	}
+
+	results := make([]Result, 0, len(c.records))
+	for _, r := range c.records {
+		if filter.UserID != 0 && r.UserID != filter.UserID {
+			continue
+		}
+		if len(filter.DocumentIDs) > 0 && !slices.Contains(filter.DocumentIDs, r.DocumentID) {
+			continue
+		}
+		results = append(results, Result{
+			ID:         r.ID,
+			UserID:     r.UserID,
+			DocumentID: r.DocumentID,
+			Score:      cosine(vector, r.Vector),
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+	if topK > 0 && len(results) > topK {
+		results = results[:topK]
+	}
+	return results, nil
+}
+
+func (m *Memory) Close() error { return nil }
+
+func cosine(a, b []float32) float32 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return float32(dot / (math.Sqrt(na) * math.Sqrt(nb)))
+}
@@ -0,0 +1,242 @@
+package vectorstore
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+const testCollection = "chunks_test"
+
+// newTestMemory returns a store with a 3-dimensional collection holding
+// records of two users and three documents.
+func newTestMemory(t *testing.T) *Memory {
+	t.Helper()
+	ctx := context.Background()
+	m := NewMemory()
+	if err := m.CreateCollection(ctx, testCollection, 3); err != nil {
+		t.Fatal(err)
+	}
+	err := m.Upsert(ctx, testCollection, []Record{
+		{ID: 1, UserID: 1, DocumentID: 10, Vector: []float32{1, 0, 0}},
+		{ID: 2, UserID: 1, DocumentID: 10, Vector: []float32{1, 1, 0}},
+		{ID: 3, UserID: 1, DocumentID: 11, Vector: []float32{0, 1, 0}},
+		{ID: 4, UserID: 1, DocumentID: 11, Vector: []float32{-1, 0, 0}},
+		{ID: 5, UserID: 2, DocumentID: 20, Vector: []float32{2, 0, 0}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func ids(results []Result) []int64 {
+	out := make([]int64, len(results))
+	for i, r := range results {
+		out[i] = r.ID
+	}
+	return out
+}
+
+func TestMemorySearch(t *testing.T) {
+	m := newTestMemory(t)
+	tests := []struct {
+		name   string
+		topK   int
+		filter Filter
+		want   []int64
+	}{
+		// 1 and 5 point the same way and tie at 1; the lower id wins.
+		{"all by cosine", 0, Filter{}, []int64{1, 5, 2, 3, 4}},
+		{"top k", 2, Filter{}, []int64{1, 5}},
+		{"user", 0, Filter{UserID: 1}, []int64{1, 2, 3, 4}},
+		{"documents", 0, Filter{UserID: 1, DocumentIDs: []int64{11}}, []int64{3, 4}},
+		{"several documents", 3, Filter{DocumentIDs: []int64{11, 20}}, []int64{5, 3, 4}},
+		{"other user's document", 0, Filter{UserID: 2, DocumentIDs: []int64{10}}, []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := m.Search(context.Background(), testCollection, []float32{1, 0, 0}, tt.topK, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ids(res); !slices.Equal(got, tt.want) {
+				t.Errorf("ids = %v, want %v", got, tt.want)
+			}
+			for i := 1; i < len(res); i++ {
+				if res[i].Score > res[i-1].Score {
+					t.Errorf("results not best first: %+v", res)
+				}
+			}
+		})
+	}
+
+	res, _ := m.Search(context.Background(), testCollection, []float32{1, 0, 0}, 0, Filter{UserID: 1})
+	want := []float32{1, 0.70710677, 0, -1}
+	for i, r := range res {
+		if d := r.Score - want[i]; d > 1e-6 || d < -1e-6 {
+			t.Errorf("score of %d = %v, want %v", r.ID, r.Score, want[i])
+		}
+	}
+}
+
+func TestMemoryUpsertOverwrites(t *testing.T) {
+	ctx := context.Background()
+	m := newTestMemory(t)
+	vector := []float32{0, 0, 1}
+	if err := m.Upsert(ctx, testCollection, []Record{{ID: 1, UserID: 1, DocumentID: 12, Vector: vector}}); err != nil {
+		t.Fatal(err)
+	}
+	vector[2] = -1 // the store keeps its own copy
+
+	res, err := m.Search(ctx, testCollection, []float32{0, 0, 1}, 1, Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 1 || res[0].ID != 1 || res[0].DocumentID != 12 || res[0].Score < 0.999 {
+		t.Errorf("got %+v, want record 1 replaced", res)
+	}
+	if n := count(t, m); n != 5 {
+		t.Errorf("%d records after overwriting one, want 5", n)
+	}
+}
+
+func TestMemoryDelete(t *testing.T) {
+	ctx := context.Background()
+	m := newTestMemory(t)
+	if err := m.DeleteByDocument(ctx, testCollection, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Delete(ctx, testCollection, []int64{4, 99}); err != nil {
+		t.Fatal(err)
+	}
+	res, err := m.Search(ctx, testCollection, []float32{1, 0, 0}, 0, Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(res); !slices.Equal(got, []int64{5, 3}) {
+		t.Errorf("ids = %v, want [5 3]", got)
+	}
+}
+
+func count(t *testing.T, m *Memory) int {
+	t.Helper()
+	n := 0
+	err := m.Scan(context.Background(), testCollection, func(batch []Record) error {
+		n += len(batch)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestMemoryScan(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+	if err := m.CreateCollection(ctx, testCollection, 1); err != nil {
+		t.Fatal(err)
+	}
+	total := 2*scanBatch + 5
+	records := make([]Record, total)
+	for i := range records {
+		records[i] = Record{ID: int64(total - i), UserID: 1, DocumentID: int64(i % 3), Vector: []float32{1}}
+	}
+	if err := m.Upsert(ctx, testCollection, records); err != nil {
+		t.Fatal(err)
+	}
+
+	var batches []int
+	var seen []int64
+	err := m.Scan(ctx, testCollection, func(batch []Record) error {
+		batches = append(batches, len(batch))
+		for _, r := range batch {
+			if r.Vector != nil {
+				t.Fatalf("record %d scanned with its vector", r.ID)
+			}
+			seen = append(seen, r.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(batches, []int{scanBatch, scanBatch, 5}) {
+		t.Errorf("batches of %v", batches)
+	}
+	if len(seen) != total || !slices.IsSorted(seen) || seen[0] != 1 {
+		t.Errorf("scanned %d records, want ids 1..%d in order", len(seen), total)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = m.Scan(ctx, testCollection, func([]Record) error { calls++; return stop })
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("Scan returned %v after %d calls, want the callback's error after 1", err, calls)
+	}
+}
+
+func TestMemoryErrors(t *testing.T) {
+	ctx := context.Background()
+	m := newTestMemory(t)
+	noop := func([]Record) error { return nil }
+	tests := []struct {
+		name string
+		call func() error
+		want error
+	}{
+		{"upsert wrong dimension", func() error {
+			return m.Upsert(ctx, testCollection, []Record{{ID: 9, Vector: []float32{1, 0, 0}}, {ID: 10, Vector: []float32{1, 0}}})
+		}, ErrDimensionMismatch},
+		{"search wrong dimension", func() error {
+			_, err := m.Search(ctx, testCollection, []float32{1, 0}, 1, Filter{})
+			return err
+		}, ErrDimensionMismatch},
+		{"dimension of missing", func() error { _, err := m.Dimension(ctx, "missing"); return err }, ErrCollectionNotFound},
+		{"upsert missing", func() error { return m.Upsert(ctx, "missing", nil) }, ErrCollectionNotFound},
+		{"search missing", func() error {
+			_, err := m.Search(ctx, "missing", []float32{1, 0, 0}, 1, Filter{})
+			return err
+		}, ErrCollectionNotFound},
+		{"delete missing", func() error { return m.Delete(ctx, "missing", []int64{1}) }, ErrCollectionNotFound},
+		{"delete document missing", func() error { return m.DeleteByDocument(ctx, "missing", 1) }, ErrCollectionNotFound},
+		{"scan missing", func() error { return m.Scan(ctx, "missing", noop) }, ErrCollectionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	// A rejected batch is not partly applied.
+	if n := count(t, m); n != 5 {
+		t.Errorf("%d records after a rejected upsert, want 5", n)
+	}
+}
+
+func TestMemoryCollections(t *testing.T) {
+	ctx := context.Background()
+	m := newTestMemory(t)
+	// Creating an existing collection keeps it and its records.
+	if err := m.CreateCollection(ctx, testCollection, 8); err != nil {
+		t.Fatal(err)
+	}
+	if dim, err := m.Dimension(ctx, testCollection); err != nil || dim != 3 {
+		t.Errorf("Dimension = %d, %v; want 3", dim, err)
+	}
+	if n := count(t, m); n != 5 {
+		t.Errorf("%d records, want 5", n)
+	}
+	if err := m.DropCollection(ctx, testCollection); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.DropCollection(ctx, testCollection); err != nil {
+		t.Errorf("dropping a missing collection: %v", err)
+	}
+	if _, err := m.Dimension(ctx, testCollection); !errors.Is(err, ErrCollectionNotFound) {
+		t.Errorf("Dimension after drop: %v", err)
+	}
+}
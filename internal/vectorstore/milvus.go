@@ -0,0 +1,256 @@
+package vectorstore
+
+import (
+	"ai-learn-english/config"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+const (
+	fieldID         = "id"
+	fieldUserID     = "user_id"
+	fieldDocumentID = "document_id"
+	fieldVector     = "vector"
+
+	// scanBatch is the number of records Scan hands to its callback at once.
+	scanBatch = 1000
+)
+
+// Milvus is a VectorStore backed by a Milvus server. Collections use the
+// chunk id as primary key and a COSINE AUTOINDEX on the vector field.
+type Milvus struct {
+	cli client.Client
+}
+
+// NewMilvus connects to the Milvus server at cfg.Address.
+func NewMilvus(ctx context.Context, cfg config.MilvusConfig) (*Milvus, error) {
+	cli, err := client.NewClient(ctx, client.Config{
+		Address:  cfg.Address,
+		Username: cfg.Username,
+		Password: cfg.Password,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("connect milvus %s: %w", cfg.Address, err)
+	}
+	return &Milvus{cli: cli}, nil
+}
+
+func (m *Milvus) CreateCollection(ctx context.Context, name string, dim int) error {
+	exists, err := m.cli.HasCollection(ctx, name)
+	if err != nil {
+		return fmt.Errorf("check collection %s: %w", name, err)
+	}
+	if exists {
+		return nil
+	}
+
+	schema := entity.NewSchema().
+		WithName(name).
+		WithDescription("document chunk embeddings").
+		WithField(entity.NewField().WithName(fieldID).WithDataType(entity.FieldTypeInt64).WithIsPrimaryKey(true)).
+		WithField(entity.NewField().WithName(fieldUserID).WithDataType(entity.FieldTypeInt64)).
+		WithField(entity.NewField().WithName(fieldDocumentID).WithDataType(entity.FieldTypeInt64)).
+		WithField(entity.NewField().WithName(fieldVector).WithDataType(entity.FieldTypeFloatVector).WithDim(int64(dim)))
+	if err := m.cli.CreateCollection(ctx, schema, entity.DefaultShardNumber); err != nil {
+		return fmt.Errorf("create collection %s: %w", name, err)
+	}
+
+	idx, err := entity.NewIndexAUTOINDEX(entity.COSINE)
+	if err != nil {
+		return err
+	}
+	if err := m.cli.CreateIndex(ctx, name, fieldVector, idx, false); err != nil {
+		return fmt.Errorf("create index on %s: %w", name, err)
+	}
+	if err := m.cli.LoadCollection(ctx, name, false); err != nil {
+		return fmt.Errorf("load collection %s: %w", name, err)
+	}
+	return nil
+}
+
+func (m *Milvus) DropCollection(ctx context.Context, name string) error {
+	exists, err := m.cli.HasCollection(ctx, name)
+	if err != nil {
+		return fmt.Errorf("check collection %s: %w", name, err)
+	}
+	if !exists {
+		return nil
+	}
+	if err := m.cli.DropCollection(ctx, name); err != nil {
+		return fmt.Errorf("drop collection %s: %w", name, err)
+	}
+	return nil
+}
+
+func (m *Milvus) Dimension(ctx context.Context, name string) (int, error) {
+	exists, err := m.cli.HasCollection(ctx, name)
+	if err != nil {
+		return 0, fmt.Errorf("check collection %s: %w", name, err)
+	}
+	if !exists {
+		return 0, ErrCollectionNotFound
+	}
+	coll, err := m.cli.DescribeCollection(ctx, name)
+	if err != nil {
+		return 0, fmt.Errorf("describe collection %s: %w", name, err)
+	}
+	for _, f := range coll.Schema.Fields {
+		if f.Name == fieldVector {
+			return strconv.Atoi(f.TypeParams[entity.TypeParamDim])
+		}
+	}
+	return 0, fmt.Errorf("collection %s has no %s field", name, fieldVector)
+}
+
+func (m *Milvus) Upsert(ctx context.Context, collection string, records []Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	dim := len(records[0].Vector)
+	ids := make([]int64, len(records))
+	users := make([]int64, len(records))
+	docs := make([]int64, len(records))
+	vectors := make([][]float32, len(records))
+	for i, r := range records {
+		if len(r.Vector) != dim {
+			return ErrDimensionMismatch
+		}
+		ids[i], users[i], docs[i], vectors[i] = r.ID, r.UserID, r.DocumentID, r.Vector
+	}
+
+	_, err := m.cli.Upsert(ctx, collection, "",
+		entity.NewColumnInt64(fieldID, ids),
+		entity.NewColumnInt64(fieldUserID, users),
+		entity.NewColumnInt64(fieldDocumentID, docs),
+		entity.NewColumnFloatVector(fieldVector, dim, vectors),
+	)
+	if err != nil {
+		return fmt.Errorf("upsert into %s: %w", collection, err)
+	}
+	return nil
+}
+
+func (m *Milvus) DeleteByDocument(ctx context.Context, collection string, documentID int64) error {
+	expr := fmt.Sprintf("%s == %d", fieldDocumentID, documentID)
+	if err := m.cli.Delete(ctx, collection, "", expr); err != nil {
+		return fmt.Errorf("delete document %d from %s: %w", documentID, collection, err)
+	}
+	return nil
+}
+
+func (m *Milvus) Delete(ctx context.Context, collection string, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := m.cli.DeleteByPks(ctx, collection, "", entity.NewColumnInt64(fieldID, ids)); err != nil {
+		return fmt.Errorf("delete %d records from %s: %w", len(ids), collection, err)
+	}
+	return nil
+}
+
+func (m *Milvus) Scan(ctx context.Context, collection string, fn func([]Record) error) error {
+	exists, err := m.cli.HasCollection(ctx, collection)
+	if err != nil {
+		return fmt.Errorf("check collection %s: %w", collection, err)
+	}
+	if !exists {
+		return ErrCollectionNotFound
+	}
+	it, err := m.cli.QueryIterator(ctx, client.NewQueryIteratorOption(collection).
+		WithOutputFields(fieldID, fieldUserID, fieldDocumentID).
+		WithBatchSize(scanBatch))
+	if err != nil {
+		return fmt.Errorf("scan %s: %w", collection, err)
+	}
+	for {
+		rs, err := it.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("scan %s: %w", collection, err)
+		}
+		ids, users, docs := rs.GetColumn(fieldID), rs.GetColumn(fieldUserID), rs.GetColumn(fieldDocumentID)
+		if ids == nil || users == nil || docs == nil {
+			return fmt.Errorf("scan %s: missing output fields", collection)
+		}
+		batch := make([]Record, ids.Len())
+		for i := range batch {
+			batch[i].ID, _ = ids.GetAsInt64(i)
+			batch[i].UserID, _ = users.GetAsInt64(i)
+			batch[i].DocumentID, _ = docs.GetAsInt64(i)
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+	}
+}
+
+func (m *Milvus) Search(ctx context.Context, collection string, vector []float32, topK int, filter Filter) ([]Result, error) {
+	exists, err := m.cli.HasCollection(ctx, collection)
+	if err != nil {
+		return nil, fmt.Errorf("check collection %s: %w", collection, err)
+	}
+	if !exists {
+		return nil, ErrCollectionNotFound
+	}
+	sp, err := entity.NewIndexAUTOINDEXSearchParam(1)
+	if err != nil {
+		return nil, err
+	}
+	res, err := m.cli.Search(ctx, collection, nil, filterExpr(filter),
+		[]string{fieldUserID, fieldDocumentID},
+		[]entity.Vector{entity.FloatVector(vector)},
+		fieldVector, entity.COSINE, topK, sp)
+	if err != nil {
+		return nil, fmt.Errorf("search %s: %w", collection, err)
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	hits := res[0]
+	out := make([]Result, 0, hits.ResultCount)
+	for i := 0; i < hits.ResultCount; i++ {
+		id, err := hits.IDs.GetAsInt64(i)
+		if err != nil {
+			return nil, err
+		}
+		r := Result{ID: id, Score: hits.Scores[i]}
+		if col := hits.Fields.GetColumn(fieldUserID); col != nil {
+			r.UserID, _ = col.GetAsInt64(i)
+		}
+		if col := hits.Fields.GetColumn(fieldDocumentID); col != nil {
+			r.DocumentID, _ = col.GetAsInt64(i)
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+func (m *Milvus) Close() error {
+	return m.cli.Close()
+}
+
+// filterExpr renders filter as a Milvus boolean expression.
+func filterExpr(filter Filter) string {
+	var parts []string
+	if filter.UserID != 0 {
+		parts = append(parts, fmt.Sprintf("%s == %d", fieldUserID, filter.UserID))
+	}
+	if len(filter.DocumentIDs) > 0 {
+		ids := make([]string, len(filter.DocumentIDs))
+		for i, id := range filter.DocumentIDs {
+			ids[i] = strconv.FormatInt(id, 10)
+		}
+		parts = append(parts, fmt.Sprintf("%s in [%s]", fieldDocumentID, strings.Join(ids, ",")))
+	}
+	return strings.Join(parts, " && ")
+}
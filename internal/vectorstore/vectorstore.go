@@ -0,0 +1,76 @@
+// Package vectorstore stores chunk embeddings and searches them by
+// similarity. The Milvus implementation is used in production; the memory
+// implementation runs the same retrieval path without a Milvus server.
+package vectorstore
+
+import (
+	"ai-learn-english/config"
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrCollectionNotFound = errors.New("vector collection not found")
+	ErrDimensionMismatch  = errors.New("vector dimension does not match collection")
+)
+
+// Record is one embedded chunk. ID is the chunk id, which is also the
+// primary key in the vector store and the value kept in Chunk.MilvusID.
+type Record struct {
+	ID         int64
+	UserID     int64
+	DocumentID int64
+	Vector     []float32
+}
+
+// Filter restricts a search. Zero values mean "no restriction".
+type Filter struct {
+	UserID      int64
+	DocumentIDs []int64
+}
+
+// Result is a search hit. Score is the cosine similarity, higher is closer.
+type Result struct {
+	ID         int64
+	UserID     int64
+	DocumentID int64
+	Score      float32
+}
+
+// VectorStore is the storage used for chunk embeddings.
+type VectorStore interface {
+	// CreateCollection creates the named collection for vectors of dim
+	// dimensions. It is a no-op when the collection already exists.
+	CreateCollection(ctx context.Context, name string, dim int) error
+	// DropCollection removes the named collection and all of its records.
+	// It is a no-op when the collection does not exist.
+	DropCollection(ctx context.Context, name string) error
+	// Dimension returns the vector dimension of an existing collection.
+	Dimension(ctx context.Context, name string) (int, error)
+	// Upsert inserts or replaces records by id.
+	Upsert(ctx context.Context, collection string, records []Record) error
+	// DeleteByDocument removes every record of a document.
+	DeleteByDocument(ctx context.Context, collection string, documentID int64) error
+	// Delete removes records by id.
+	Delete(ctx context.Context, collection string, ids []int64) error
+	// Scan calls fn with every record of collection in batches, without
+	// vectors. Iteration stops at the first error returned by fn.
+	Scan(ctx context.Context, collection string, fn func([]Record) error) error
+	// Search returns the topK records closest to vector that match filter,
+	// best first.
+	Search(ctx context.Context, collection string, vector []float32, topK int, filter Filter) ([]Result, error)
+	Close() error
+}
+
+// New builds the vector store selected by cfg.Driver.
+func New(ctx context.Context, cfg config.VectorStoreConfig) (VectorStore, error) {
+	switch cfg.Driver {
+	case "", "milvus":
+		return NewMilvus(ctx, cfg.Milvus)
+	case "memory":
+		return NewMemory(), nil
+	default:
+		return nil, fmt.Errorf("unknown vector store driver %q", cfg.Driver)
+	}
+}
@@ -1,81 +0,0 @@
-"""init
-
-Revision ID: f9581994c712
-Revises: 
-Create Date: 2025-11-04 07:31:26.726603
-
-"""
-from alembic import op
-import sqlalchemy as sa
-
-
-# revision identifiers, used by Alembic.
-revision = 'f9581994c712'
-down_revision = None
-branch_labels = None
-depends_on = None
-
-
-def upgrade() -> None:
-    # ### commands auto generated by Alembic - please adjust! ###
-    op.create_table('users',
-    sa.Column('id', sa.BigInteger(), autoincrement=True, nullable=False),
-    sa.Column('email', sa.String(length=255), nullable=False),
-    sa.Column('username', sa.String(length=100), nullable=True),
-    sa.Column('password_hash', sa.String(length=255), nullable=True),
-    sa.Column('created_at', sa.TIMESTAMP(), server_default=sa.text('CURRENT_TIMESTAMP'), nullable=True),
-    sa.PrimaryKeyConstraint('id'),
-    sa.UniqueConstraint('email'),
-    sa.UniqueConstraint('username')
-    )
-    op.create_table('documents',
-    sa.Column('id', sa.BigInteger(), autoincrement=True, nullable=False),
-    sa.Column('user_id', sa.BigInteger(), nullable=False),
-    sa.Column('title', sa.String(length=255), nullable=True),
-    sa.Column('original_filename', sa.String(length=255), nullable=True),
-    sa.Column('file_path', sa.String(length=500), nullable=True),
-    sa.Column('language', sa.String(length=20), server_default='en', nullable=True),
-    sa.Column('page_count', sa.Integer(), nullable=True),
-    sa.Column('sha256', sa.String(length=64), nullable=True),
-    sa.Column('uploaded_at', sa.TIMESTAMP(), server_default=sa.text('CURRENT_TIMESTAMP'), nullable=True),
-    sa.ForeignKeyConstraint(['user_id'], ['users.id'], ondelete='CASCADE'),
-    sa.PrimaryKeyConstraint('id'),
-    sa.UniqueConstraint('sha256')
-    )
-    op.create_table('chunks',
-    sa.Column('id', sa.BigInteger(), autoincrement=True, nullable=False),
-    sa.Column('document_id', sa.BigInteger(), nullable=False),
-    sa.Column('chunk_index', sa.Integer(), nullable=False),
-    sa.Column('page_index', sa.Integer(), nullable=True),
-    sa.Column('content', sa.Text(), nullable=False),
-    sa.Column('content_preview', sa.String(length=512), nullable=True),
-    sa.Column('token_count', sa.Integer(), nullable=True),
-    sa.Column('milvus_collection', sa.String(length=128), nullable=False),
-    sa.Column('milvus_id', sa.BigInteger(), nullable=False),
-    sa.Column('content_hash', sa.String(length=64), nullable=False),
-    sa.Column('created_at', sa.TIMESTAMP(), server_default=sa.text('CURRENT_TIMESTAMP'), nullable=True),
-    sa.ForeignKeyConstraint(['document_id'], ['documents.id'], ondelete='CASCADE'),
-    sa.PrimaryKeyConstraint('id')
-    )
-    op.create_table('messages',
-    sa.Column('id', sa.BigInteger(), autoincrement=True, nullable=False),
-    sa.Column('user_id', sa.BigInteger(), nullable=False),
-    sa.Column('role', sa.Enum('user', 'assistant', name='role_enum'), nullable=False),
-    sa.Column('content', sa.Text(), nullable=False),
-    sa.Column('document_id', sa.BigInteger(), nullable=True),
-    sa.Column('created_at', sa.TIMESTAMP(), server_default=sa.text('CURRENT_TIMESTAMP'), nullable=True),
-    sa.ForeignKeyConstraint(['document_id'], ['documents.id'], ondelete='SET NULL'),
-    sa.ForeignKeyConstraint(['user_id'], ['users.id'], ondelete='CASCADE'),
-    sa.PrimaryKeyConstraint('id')
-    )
-    # ### end Alembic commands ###
-
-
-def downgrade() -> None:
-    # ### commands auto generated by Alembic - please adjust! ###
-    op.drop_table('messages')
-    op.drop_table('chunks')
-    op.drop_table('documents')
-    op.drop_table('users')
-    # ### end Alembic commands ###
-
@@ -1,6 +1,9 @@
 package apperror
 
+import "net/http"
+
 type ErrorResponse struct {
+	Status  int    `json:"-"`
 	Code    string `json:"code,omitempty"`
 	Message string `json:"message"`
 	Data    any    `json:"data,omitempty"`
@@ -9,10 +12,16 @@ type ErrorResponse struct {
 func (e *ErrorResponse) Error() string { return e.Message }
 
 func New(code, message string) *ErrorResponse {
-	return &ErrorResponse{Code: code, Message: message}
+	return &ErrorResponse{Status: http.StatusBadRequest, Code: code, Message: message}
 }
 
 func (e *ErrorResponse) WithData(data any) *ErrorResponse {
 	e.Data = data
 	return e
 }
+
+// WithStatus sets the HTTP status code used when the error is sent to a client.
+func (e *ErrorResponse) WithStatus(status int) *ErrorResponse {
+	e.Status = status
+	return e
+}
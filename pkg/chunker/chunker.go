@@ -0,0 +1,220 @@
+// Package chunker splits extracted document text into overlapping windows
+// sized for embedding and retrieval. Windows are built from whole sentences
+// and prefer to end at paragraph breaks.
+package chunker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Chunk is one window of text ready to be stored as a chunk row.
+type Chunk struct {
+	Content     string
+	ContentHash string
+	Preview     string
+	TokenCount  int
+}
+
+// Chunker splits text into chunks bounded by a token budget.
+type Chunker struct {
+	maxTokens     int
+	overlapTokens int
+	previewChars  int
+	count         TokenCounter
+}
+
+// Options sizes the chunks. MaxTokens bounds a chunk, OverlapTokens bounds
+// the sentences repeated at the start of the next chunk and PreviewChars
+// bounds Chunk.Preview.
+type Options struct {
+	MaxTokens     int
+	OverlapTokens int
+	PreviewChars  int
+}
+
+// New returns a chunker sized by opts. Missing or inconsistent values fall
+// back to sensible defaults.
+func New(opts Options) *Chunker {
+	if opts.MaxTokens <= 0 {
+		opts.MaxTokens = 400
+	}
+	if opts.OverlapTokens < 0 || opts.OverlapTokens >= opts.MaxTokens {
+		opts.OverlapTokens = opts.MaxTokens / 8
+	}
+	if opts.PreviewChars <= 0 {
+		opts.PreviewChars = 200
+	}
+	return &Chunker{
+		maxTokens:     opts.MaxTokens,
+		overlapTokens: opts.OverlapTokens,
+		previewChars:  opts.PreviewChars,
+		count:         EstimateTokens,
+	}
+}
+
+// WithTokenCounter replaces the token estimator, e.g. with a model specific
+// tokenizer.
+func (c *Chunker) WithTokenCounter(count TokenCounter) *Chunker {
+	c.count = count
+	return c
+}
+
+// CountTokens returns the token count of s as seen by this chunker.
+func (c *Chunker) CountTokens(s string) int {
+	return c.count(s)
+}
+
+type sentence struct {
+	text          string
+	tokens        int
+	paragraphEnds bool
+}
+
+// Split cuts text into chunks of at most MaxTokens tokens. Consecutive
+// chunks share up to OverlapTokens tokens of whole sentences. A sentence is
+// only ever cut when it alone exceeds the budget.
+func (c *Chunker) Split(text string) []Chunk {
+	units := c.units(text)
+	if len(units) == 0 {
+		return nil
+	}
+
+	var (
+		chunks []Chunk
+		window []sentence
+		tokens int
+		fresh  int // sentences in window not yet emitted in a chunk
+	)
+	flush := func() {
+		chunks = append(chunks, c.newChunk(window))
+		window, tokens = c.overlap(window)
+		fresh = 0
+	}
+
+	for i, s := range units {
+		if tokens+s.tokens > c.maxTokens {
+			if fresh > 0 {
+				flush()
+			}
+			// The overlap alone plus this sentence may still be too large.
+			for tokens+s.tokens > c.maxTokens && len(window) > 0 {
+				tokens -= window[0].tokens
+				window = window[1:]
+			}
+		}
+		window = append(window, s)
+		tokens += s.tokens
+		fresh++
+
+		// Close the window at a paragraph break once it is mostly full so
+		// chunks line up with the structure of the text.
+		if s.paragraphEnds && i < len(units)-1 && tokens >= c.maxTokens*3/4 {
+			flush()
+		}
+	}
+	if fresh > 0 {
+		chunks = append(chunks, c.newChunk(window))
+	}
+	return chunks
+}
+
+// units returns the sentences of text, with sentences longer than the
+// budget cut on word boundaries.
+func (c *Chunker) units(text string) []sentence {
+	var out []sentence
+	for _, para := range paragraphs(text) {
+		sents := sentences(para)
+		for i, s := range sents {
+			pieces := c.splitLong(s)
+			for j, p := range pieces {
+				out = append(out, sentence{
+					text:          p,
+					tokens:        c.count(p),
+					paragraphEnds: i == len(sents)-1 && j == len(pieces)-1,
+				})
+			}
+		}
+	}
+	return out
+}
+
+// splitLong cuts s on spaces into pieces within the token budget.
+func (c *Chunker) splitLong(s string) []string {
+	if c.count(s) <= c.maxTokens {
+		return []string{s}
+	}
+	var (
+		out    []string
+		cur    []string
+		tokens int
+	)
+	for _, w := range strings.Fields(s) {
+		t := c.count(w)
+		if tokens+t > c.maxTokens && len(cur) > 0 {
+			out = append(out, strings.Join(cur, " "))
+			cur, tokens = nil, 0
+		}
+		cur = append(cur, w)
+		tokens += t
+	}
+	if len(cur) > 0 {
+		out = append(out, strings.Join(cur, " "))
+	}
+	return out
+}
+
+// overlap returns the trailing sentences of window that fit in the overlap
+// budget, to be repeated at the start of the next chunk.
+func (c *Chunker) overlap(window []sentence) ([]sentence, int) {
+	tokens := 0
+	i := len(window)
+	for i > 0 && tokens+window[i-1].tokens <= c.overlapTokens {
+		tokens += window[i-1].tokens
+		i--
+	}
+	// Never carry the whole window over, or the next chunk would repeat it.
+	if i == 0 {
+		return nil, 0
+	}
+	return append([]sentence(nil), window[i:]...), tokens
+}
+
+func (c *Chunker) newChunk(window []sentence) Chunk {
+	var b strings.Builder
+	tokens := 0
+	for i, s := range window {
+		if i > 0 {
+			if window[i-1].paragraphEnds {
+				b.WriteString("\n\n")
+			} else {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(s.text)
+		tokens += s.tokens
+	}
+	content := b.String()
+	sum := sha256.Sum256([]byte(content))
+	return Chunk{
+		Content:     content,
+		ContentHash: hex.EncodeToString(sum[:]),
+		Preview:     preview(content, c.previewChars),
+		TokenCount:  tokens,
+	}
+}
+
+// preview returns at most the first n characters of s, cut back to a word
+// boundary when possible.
+func preview(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	cut := string(r[:n])
+	if i := strings.LastIndexAny(cut, " \n"); i > n/2 {
+		cut = cut[:i]
+	}
+	return strings.TrimSpace(cut)
+}
@@ -0,0 +1,160 @@
+package chunker
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// countWords counts whitespace separated words, which keeps token budgets
+// in tests easy to follow.
+func countWords(s string) int {
+	return len(strings.Fields(s))
+}
+
+func TestSplitOverlap(t *testing.T) {
+	text := "One two three. Four five six. Seven eight nine. Ten eleven twelve. Thirteen fourteen fifteen. Sixteen seventeen eighteen."
+	c := New(Options{MaxTokens: 9, OverlapTokens: 3}).WithTokenCounter(countWords)
+
+	want := []string{
+		"One two three. Four five six. Seven eight nine.",
+		"Seven eight nine. Ten eleven twelve. Thirteen fourteen fifteen.",
+		"Thirteen fourteen fifteen. Sixteen seventeen eighteen.",
+	}
+	chunks := c.Split(text)
+	got := make([]string, len(chunks))
+	for i, ch := range chunks {
+		got[i] = ch.Content
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Split:\ngot  %q\nwant %q", got, want)
+	}
+}
+
+func TestSplitKeepsSentencesWhole(t *testing.T) {
+	var b strings.Builder
+	for i := range 40 {
+		fmt.Fprintf(&b, "Sentence number %d has a few words in it.", i)
+		if i%7 == 6 {
+			b.WriteString("\n\n")
+		} else {
+			b.WriteString(" ")
+		}
+	}
+	text := b.String()
+	whole := Sentences(text)
+
+	for _, opts := range []Options{
+		{MaxTokens: 30, OverlapTokens: 10},
+		{MaxTokens: 50, OverlapTokens: 0},
+		{MaxTokens: 100, OverlapTokens: 40},
+	} {
+		t.Run(fmt.Sprintf("max%d_overlap%d", opts.MaxTokens, opts.OverlapTokens), func(t *testing.T) {
+			c := New(opts)
+			chunks := c.Split(text)
+			if len(chunks) < 2 {
+				t.Fatalf("got %d chunks, want several", len(chunks))
+			}
+			var seen []string
+			for i, ch := range chunks {
+				if ch.TokenCount > opts.MaxTokens {
+					t.Errorf("chunk %d: %d tokens, budget is %d", i, ch.TokenCount, opts.MaxTokens)
+				}
+				sents := Sentences(ch.Content)
+				tokens := 0
+				for _, s := range sents {
+					if !slices.Contains(whole, s) {
+						t.Errorf("chunk %d: %q is not a whole sentence of the text", i, s)
+					}
+					tokens += EstimateTokens(s)
+				}
+				if ch.TokenCount != tokens {
+					t.Errorf("chunk %d: TokenCount = %d, sentences hold %d", i, ch.TokenCount, tokens)
+				}
+				if i > 0 {
+					overlap := 0
+					for _, s := range sents {
+						if !slices.Contains(Sentences(chunks[i-1].Content), s) {
+							break
+						}
+						overlap += EstimateTokens(s)
+					}
+					if overlap > opts.OverlapTokens {
+						t.Errorf("chunk %d repeats %d tokens, overlap is %d", i, overlap, opts.OverlapTokens)
+					}
+				}
+				for _, s := range sents {
+					if !slices.Contains(seen, s) {
+						seen = append(seen, s)
+					}
+				}
+			}
+			if !slices.Equal(seen, whole) {
+				t.Errorf("chunks cover %d sentences in order, text has %d", len(seen), len(whole))
+			}
+		})
+	}
+}
+
+func TestSplitLongSentence(t *testing.T) {
+	text := strings.Repeat("word ", 25) + "end."
+	c := New(Options{MaxTokens: 10, OverlapTokens: 2}).WithTokenCounter(countWords)
+	for i, ch := range c.Split(text) {
+		if ch.TokenCount > 10 {
+			t.Errorf("chunk %d: %d tokens, budget is 10", i, ch.TokenCount)
+		}
+	}
+}
+
+func TestParagraphsJoinHyphenation(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"learn-\ning is fun", []string{"learning is fun"}},
+		{"the naï-\nve reader", []string{"the naïve reader"}},
+		{"well-\nKnown", []string{"well- Known"}},
+		{"first line\nsecond line\n\nnext paragraph", []string{"first line second line", "next paragraph"}},
+	}
+	for _, tt := range tests {
+		if got := paragraphs(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("paragraphs(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSentences(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"Dr. Smith arrived. He sat down.", []string{"Dr. Smith arrived.", "He sat down."}},
+		{"It costs 3.5 dollars. Cheap!", []string{"It costs 3.5 dollars.", "Cheap!"}},
+		{"J. K. Rowling wrote it. \"Really?\" she asked.", []string{"J. K. Rowling wrote it.", "\"Really?\" she asked."}},
+		{"no ending", []string{"no ending"}},
+	}
+	for _, tt := range tests {
+		if got := Sentences(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("Sentences(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"cat", 1},
+		{"understanding", 4},
+		{"Hello, world!", 6},
+		{"don't", 2},
+	}
+	for _, tt := range tests {
+		if got := EstimateTokens(tt.in); got != tt.want {
+			t.Errorf("EstimateTokens(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
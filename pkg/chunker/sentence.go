@@ -0,0 +1,123 @@
+package chunker
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// abbreviations end with a period but do not end a sentence.
+var abbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true, "sr": true, "jr": true,
+	"st": true, "vs": true, "etc": true, "e.g": true, "i.e": true, "cf": true, "fig": true,
+	"no": true, "vol": true, "p": true, "pp": true, "ch": true, "ed": true, "approx": true,
+	"inc": true, "ltd": true, "co": true, "u.s": true, "u.k": true, "a.m": true, "p.m": true,
+	"jan": true, "feb": true, "mar": true, "apr": true, "jun": true, "jul": true, "aug": true,
+	"sep": true, "sept": true, "oct": true, "nov": true, "dec": true,
+}
+
+// paragraphs splits text on blank lines and re-joins the lines of each
+// paragraph, undoing the hard wraps and hyphenation left by PDF extraction.
+func paragraphs(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	var out []string
+	for _, block := range strings.Split(text, "\n\n") {
+		var b strings.Builder
+		for _, line := range strings.Split(block, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			if b.Len() > 0 {
+				prev := b.String()
+				before, _ := utf8.DecodeLastRuneInString(prev[:len(prev)-1])
+				if strings.HasSuffix(prev, "-") && len(prev) > 1 && unicode.IsLetter(before) &&
+					unicode.IsLower([]rune(line)[0]) {
+					// "learn-\ning" was a word broken across lines.
+					s := prev[:len(prev)-1]
+					b.Reset()
+					b.WriteString(s)
+				} else {
+					b.WriteByte(' ')
+				}
+			}
+			b.WriteString(line)
+		}
+		if b.Len() > 0 {
+			out = append(out, b.String())
+		}
+	}
+	return out
+}
+
+// sentences splits a paragraph into sentences. A sentence ends at '.', '!'
+// or '?' (optionally followed by closing quotes or brackets) when the next
+// word starts with an upper-case letter, a digit or an opening quote, and
+// the word before the period is not a known abbreviation or an initial.
+func sentences(paragraph string) []string {
+	runes := []rune(paragraph)
+	var out []string
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r != '.' && r != '!' && r != '?' {
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && strings.ContainsRune(".!?\"')]”’", runes[end]) {
+			end++
+		}
+		if end < len(runes) && !unicode.IsSpace(runes[end]) {
+			continue
+		}
+		next := end
+		for next < len(runes) && unicode.IsSpace(runes[next]) {
+			next++
+		}
+		if next < len(runes) {
+			n := runes[next]
+			if !unicode.IsUpper(n) && !unicode.IsDigit(n) && !strings.ContainsRune("\"'(“‘", n) {
+				continue
+			}
+		}
+		if r == '.' && isAbbreviation(runes[start:i]) {
+			continue
+		}
+		if s := strings.TrimSpace(string(runes[start:end])); s != "" {
+			out = append(out, s)
+		}
+		start = end
+		i = end - 1
+	}
+	if s := strings.TrimSpace(string(runes[start:])); s != "" {
+		out = append(out, s)
+	}
+	return out
+}
+
+// isAbbreviation reports whether the last word of text, which is followed
+// by a period, is an abbreviation or a single-letter initial.
+func isAbbreviation(text []rune) bool {
+	i := len(text)
+	for i > 0 && !unicode.IsSpace(text[i-1]) && text[i-1] != '(' && text[i-1] != '"' {
+		i--
+	}
+	word := strings.ToLower(string(text[i:]))
+	if word == "" {
+		return false
+	}
+	if len([]rune(word)) == 1 && unicode.IsLetter([]rune(word)[0]) && word != "i" {
+		return true
+	}
+	return abbreviations[word]
+}
+
+// Sentences splits text into sentences, joining the lines of each paragraph
+// first the way Split does.
+func Sentences(text string) []string {
+	var out []string
+	for _, para := range paragraphs(text) {
+		out = append(out, sentences(para)...)
+	}
+	return out
+}
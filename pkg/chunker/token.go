@@ -0,0 +1,39 @@
+package chunker
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenCounter returns the number of model tokens in s.
+type TokenCounter func(s string) int
+
+// EstimateTokens approximates the token count of English text for BPE
+// tokenizers such as the ones used by OpenAI and Gemini: short words are a
+// single token, longer words cost roughly one token per four characters and
+// every punctuation mark is a token of its own.
+func EstimateTokens(s string) int {
+	tokens := 0
+	wordLen := 0
+	flush := func() {
+		if wordLen > 0 {
+			tokens += (wordLen + 3) / 4
+			wordLen = 0
+		}
+	}
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		s = s[size:]
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\'':
+			wordLen++
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			flush()
+			tokens++
+		}
+	}
+	flush()
+	return tokens
+}
@@ -0,0 +1,274 @@
+// Package cloze turns a passage into a fill-in-the-blank exercise without a
+// language model. Target words are picked by part of speech or from a list
+// of lemmas the learner is studying, and each blank gets distractors from a
+// pool of words, usually the vocabulary of the same document. The output
+// depends only on the input and the seed, so an exercise can be rebuilt to
+// check its answers.
+package cloze
+
+import (
+	"ai-learn-english/pkg/vocab"
+	"cmp"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	DefaultBlanks      = 5
+	DefaultDistractors = 3
+	// minGap is the least number of words between two blanks, so each
+	// blank keeps enough context to be answerable.
+	minGap = 3
+)
+
+// Word is an entry of the distractor pool.
+type Word struct {
+	Lemma string
+	Form  string
+	Band  int
+}
+
+// Options controls how an exercise is built.
+type Options struct {
+	Blanks      int
+	Distractors int
+	// POS restricts the blanks to one of vocab.PartsOfSpeech; "" allows
+	// any content word.
+	POS string
+	// Targets are lemmas to blank first, e.g. the learner's word bank.
+	// With OnlyTargets no other word is blanked.
+	Targets     map[string]bool
+	OnlyTargets bool
+	Seed        uint64
+}
+
+// Blank is a gap in the exercise text. Start and End are rune offsets of
+// its placeholder in Exercise.Text; Options holds the answer and its
+// distractors in random order.
+type Blank struct {
+	Number  int      `json:"number"`
+	Start   int      `json:"start"`
+	End     int      `json:"end"`
+	POS     string   `json:"pos"`
+	Options []string `json:"options"`
+	Answer  string   `json:"-"`
+	Lemma   string   `json:"-"`
+}
+
+type Exercise struct {
+	Text   string  `json:"text"`
+	Blanks []Blank `json:"blanks"`
+}
+
+// token is a word of the passage at text[start:end].
+type token struct {
+	start, end int
+	word       string // lower case
+	lemma      string
+	pos        string
+}
+
+// Generate builds an exercise from text with distractors taken from pool.
+// It has fewer blanks than asked for when the text has too few suitable
+// words, and none when it has no suitable word at all.
+func Generate(text string, pool []Word, opts Options) *Exercise {
+	if opts.Blanks <= 0 {
+		opts.Blanks = DefaultBlanks
+	}
+	if opts.Distractors <= 0 {
+		opts.Distractors = DefaultDistractors
+	}
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15))
+
+	tokens := tokenize(text)
+	chosen := choose(tokens, opts, rng)
+
+	inText := make(map[string]bool, len(tokens))
+	for _, t := range tokens {
+		inText[t.lemma] = true
+	}
+
+	ex := &Exercise{Blanks: make([]Blank, 0, len(chosen))}
+	var b strings.Builder
+	pos := 0
+	for n, i := range chosen {
+		t := tokens[i]
+		b.WriteString(text[pos:t.start])
+		placeholder := fmt.Sprintf("(%d) ______", n+1)
+		start := utf8.RuneCountInString(b.String())
+		b.WriteString(placeholder)
+		pos = t.end
+
+		answer := text[t.start:t.end]
+		options := append([]string{answer}, distractors(t, pool, inText, opts.Distractors, rng)...)
+		rng.Shuffle(len(options), func(i, j int) { options[i], options[j] = options[j], options[i] })
+		ex.Blanks = append(ex.Blanks, Blank{
+			Number:  n + 1,
+			Start:   start,
+			End:     start + utf8.RuneCountInString(placeholder),
+			POS:     t.pos,
+			Options: options,
+			Answer:  answer,
+			Lemma:   t.lemma,
+		})
+	}
+	b.WriteString(text[pos:])
+	ex.Text = b.String()
+	return ex
+}
+
+// Check reports whether answer fills blank, ignoring case and surrounding
+// spaces.
+func (b Blank) Check(answer string) bool {
+	return strings.EqualFold(strings.TrimSpace(answer), b.Answer)
+}
+
+// Fingerprint identifies the exercise, so that a client can tell whether a
+// rebuilt exercise is still the one it was shown.
+func (ex *Exercise) Fingerprint() string {
+	h := fnv.New64a()
+	h.Write([]byte(ex.Text))
+	for _, b := range ex.Blanks {
+		h.Write([]byte{0})
+		h.Write([]byte(b.Answer))
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// tokenize returns the words of text with their byte offsets. Words joined
+// by an apostrophe, capitalized words and words next to digits are not
+// candidates and are skipped, but still separate the words around them.
+func tokenize(text string) []token {
+	var out []token
+	prev := ""
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if !unicode.IsLetter(r) {
+			if !unicode.IsSpace(r) {
+				prev = ""
+			}
+			i += size
+			continue
+		}
+		start := i
+		for i < len(text) {
+			r, size = utf8.DecodeRuneInString(text[i:])
+			if !unicode.IsLetter(r) {
+				break
+			}
+			i += size
+		}
+		word := text[start:i]
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[i:])
+		first, _ := utf8.DecodeRuneInString(word)
+		lower := strings.ToLower(word)
+		joined := isJoiner(before) || isJoiner(after) || unicode.IsDigit(before) || unicode.IsDigit(after)
+		if lemma := vocab.Lemma(lower); !joined && !unicode.IsUpper(first) && vocab.Studyable(lemma) {
+			out = append(out, token{start: start, end: i, word: lower, lemma: lemma, pos: vocab.Guess(prev, lower)})
+		}
+		prev = lower
+	}
+	return out
+}
+
+func isJoiner(r rune) bool {
+	return r == '\'' || r == '’' || r == '-'
+}
+
+// choose returns the indexes of the tokens to blank in text order.
+func choose(tokens []token, opts Options, rng *rand.Rand) []int {
+	var preferred, rest []int
+	for i, t := range tokens {
+		if opts.POS != "" && t.pos != opts.POS {
+			continue
+		}
+		switch {
+		case opts.Targets[t.lemma]:
+			preferred = append(preferred, i)
+		case !opts.OnlyTargets:
+			rest = append(rest, i)
+		}
+	}
+	rng.Shuffle(len(preferred), func(i, j int) { preferred[i], preferred[j] = preferred[j], preferred[i] })
+	rng.Shuffle(len(rest), func(i, j int) { rest[i], rest[j] = rest[j], rest[i] })
+
+	var chosen []int
+	lemmas := make(map[string]bool)
+	for _, i := range append(preferred, rest...) {
+		if len(chosen) == opts.Blanks {
+			break
+		}
+		if lemmas[tokens[i].lemma] || slices.ContainsFunc(chosen, func(j int) bool { return abs(i-j) <= minGap }) {
+			continue
+		}
+		chosen = append(chosen, i)
+		lemmas[tokens[i].lemma] = true
+	}
+	slices.Sort(chosen)
+	return chosen
+}
+
+// distractors picks n words of pool that could plausibly fill the blank of
+// t: same part of speech, same inflection and similar frequency first. Words
+// of the passage itself are left out so that no distractor fits a blank
+// just because the text uses it.
+func distractors(t token, pool []Word, inText map[string]bool, n int, rng *rand.Rand) []string {
+	type scored struct {
+		form  string
+		score int
+		tie   uint64
+	}
+	band := vocab.Band(t.lemma)
+	var candidates []scored
+	for _, w := range pool {
+		form := strings.ToLower(w.Form)
+		if inText[w.Lemma] || form == t.word || form == "" {
+			continue
+		}
+		score := abs(w.Band - band)
+		if vocab.Guess("", form) != t.pos {
+			score += 10
+		}
+		if inflection(form) != inflection(t.word) {
+			score += 3
+		}
+		candidates = append(candidates, scored{form: form, score: score, tie: rng.Uint64()})
+	}
+	slices.SortFunc(candidates, func(a, b scored) int {
+		return cmp.Or(cmp.Compare(a.score, b.score), cmp.Compare(a.tie, b.tie))
+	})
+
+	out := make([]string, 0, n)
+	for _, c := range candidates {
+		if len(out) == n {
+			break
+		}
+		if !slices.Contains(out, c.form) {
+			out = append(out, c.form)
+		}
+	}
+	return out
+}
+
+// inflection returns the inflectional ending of word, if any.
+func inflection(word string) string {
+	for _, s := range []string{"ing", "ed", "ly", "es", "s"} {
+		if strings.HasSuffix(word, s) && !strings.HasSuffix(word, "ss") {
+			return s
+		}
+	}
+	return ""
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,178 @@
+package cloze
+
+import (
+	"ai-learn-english/pkg/vocab"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const passage = `The old fisherman walked slowly along the quiet river every morning. He carried a heavy basket and a long wooden rod. Near the bridge he watched small birds searching the water for food. His daughter worked in a busy market selling fresh vegetables and bright flowers. In the evening they cooked dinner together and talked about the weather, the garden and their noisy neighbours.`
+
+var pool = []Word{
+	{Lemma: "mountain", Form: "mountain", Band: 3},
+	{Lemma: "forest", Form: "forest", Band: 2},
+	{Lemma: "lake", Form: "lakes", Band: 2},
+	{Lemma: "run", Form: "running", Band: 1},
+	{Lemma: "jump", Form: "jumped", Band: 2},
+	{Lemma: "climb", Form: "climbed", Band: 3},
+	{Lemma: "cheap", Form: "cheap", Band: 2},
+	{Lemma: "empty", Form: "empty", Band: 2},
+	{Lemma: "quickly", Form: "quickly", Band: 2},
+	{Lemma: "teacher", Form: "teachers", Band: 1},
+	// Words of the passage itself, which must never be offered.
+	{Lemma: "river", Form: "rivers", Band: 2},
+	{Lemma: "walk", Form: "walking", Band: 1},
+	{Lemma: "basket", Form: "basket", Band: 3},
+	{Lemma: "flower", Form: "flower", Band: 2},
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	opts := Options{Blanks: 4, Seed: 42}
+	a := Generate(passage, pool, opts)
+	b := Generate(passage, pool, opts)
+	if !reflect.DeepEqual(a, b) || a.Fingerprint() != b.Fingerprint() {
+		t.Fatalf("same seed built different exercises:\n%+v\n%+v", a, b)
+	}
+	if len(a.Blanks) != 4 {
+		t.Fatalf("got %d blanks, want 4", len(a.Blanks))
+	}
+
+	opts.Seed = 43
+	c := Generate(passage, pool, opts)
+	if reflect.DeepEqual(a, c) || a.Fingerprint() == c.Fingerprint() {
+		t.Errorf("seeds 42 and 43 built the same exercise %q", a.Text)
+	}
+}
+
+func TestGeneratePlaceholders(t *testing.T) {
+	ex := Generate(passage, pool, Options{Blanks: 5, Seed: 7})
+	text := []rune(ex.Text)
+	restored := ex.Text
+	for _, b := range ex.Blanks {
+		placeholder := string(text[b.Start:b.End])
+		if want := "(" + string(rune('0'+b.Number)) + ") ______"; placeholder != want {
+			t.Errorf("blank %d spans %q, want %q", b.Number, placeholder, want)
+		}
+		restored = strings.Replace(restored, placeholder, b.Answer, 1)
+	}
+	if restored != passage {
+		t.Errorf("filling in the answers gives %q", restored)
+	}
+}
+
+func TestGenerateSpacesBlanks(t *testing.T) {
+	for seed := range uint64(50) {
+		ex := Generate(passage, pool, Options{Blanks: 10, Seed: seed})
+		text := []rune(ex.Text)
+		for i := 1; i < len(ex.Blanks); i++ {
+			between := string(text[ex.Blanks[i-1].End:ex.Blanks[i].Start])
+			if n := len(strings.Fields(between)); n < minGap {
+				t.Errorf("seed %d: only %d words between blanks %d and %d: %q", seed, n, i, i+1, between)
+			}
+		}
+		lemmas := make(map[string]bool)
+		for _, b := range ex.Blanks {
+			if lemmas[b.Lemma] {
+				t.Errorf("seed %d: %q blanked twice", seed, b.Lemma)
+			}
+			lemmas[b.Lemma] = true
+		}
+	}
+}
+
+func TestGenerateSelection(t *testing.T) {
+	targets := map[string]bool{"river": true, "basket": true, "garden": true}
+	tests := []struct {
+		name string
+		opts Options
+		want func(Blank) bool
+		n    int
+	}{
+		{"nouns", Options{Blanks: 4, POS: vocab.Noun}, func(b Blank) bool { return b.POS == vocab.Noun }, 4},
+		{"verbs", Options{Blanks: 3, POS: vocab.Verb}, func(b Blank) bool { return b.POS == vocab.Verb }, 3},
+		{"targets first", Options{Blanks: 3, Targets: targets}, func(b Blank) bool { return targets[b.Lemma] }, 3},
+		{"only targets", Options{Blanks: 5, Targets: targets, OnlyTargets: true}, func(b Blank) bool { return targets[b.Lemma] }, 3},
+		{"no target in the text", Options{Blanks: 5, Targets: map[string]bool{"castle": true}, OnlyTargets: true}, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for seed := range uint64(20) {
+				tt.opts.Seed = seed
+				ex := Generate(passage, pool, tt.opts)
+				if len(ex.Blanks) != tt.n {
+					t.Fatalf("seed %d: got %d blanks, want %d", seed, len(ex.Blanks), tt.n)
+				}
+				for _, b := range ex.Blanks {
+					if !tt.want(b) {
+						t.Errorf("seed %d: blanked %q (%s)", seed, b.Answer, b.POS)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateDistractors(t *testing.T) {
+	inPassage := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(passage), func(r rune) bool { return r < 'a' || r > 'z' }) {
+		inPassage[vocab.Lemma(w)] = true
+	}
+	poolLemma := make(map[string]string)
+	for _, w := range pool {
+		poolLemma[w.Form] = w.Lemma
+	}
+
+	for seed := range uint64(20) {
+		ex := Generate(passage, pool, Options{Blanks: 5, Distractors: 3, Seed: seed})
+		for _, b := range ex.Blanks {
+			if len(b.Options) != 4 {
+				t.Errorf("seed %d: blank %q has options %q, want the answer and 3 distractors", seed, b.Answer, b.Options)
+			}
+			for _, o := range b.Options {
+				if o == b.Answer {
+					continue
+				}
+				if b.Check(o) {
+					t.Errorf("seed %d: distractor %q of blank %q is the answer", seed, o, b.Answer)
+				}
+				if lemma, ok := poolLemma[o]; !ok || inPassage[lemma] {
+					t.Errorf("seed %d: distractor %q of blank %q is not a pool word outside the passage", seed, o, b.Answer)
+				}
+			}
+			if n := slices.Index(b.Options, b.Answer); n < 0 || slices.Contains(b.Options[n+1:], b.Answer) {
+				t.Errorf("seed %d: options %q must hold the answer %q once", seed, b.Options, b.Answer)
+			}
+		}
+	}
+}
+
+func TestGenerateNothingToBlank(t *testing.T) {
+	for _, text := range []string{"", "It is 5 o'clock.", "Paris, London and Rome."} {
+		ex := Generate(text, pool, Options{Seed: 1})
+		if len(ex.Blanks) != 0 || ex.Text != text {
+			t.Errorf("Generate(%q) = %+v, want the text unchanged", text, ex)
+		}
+	}
+}
+
+func TestBlankCheck(t *testing.T) {
+	b := Blank{Answer: "walked"}
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{"walked", true},
+		{"Walked", true},
+		{"  WALKED\t", true},
+		{"walk", false},
+		{"walked.", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := b.Check(tt.answer); got != tt.want {
+			t.Errorf("Check(%q) = %v, want %v", tt.answer, got, tt.want)
+		}
+	}
+}
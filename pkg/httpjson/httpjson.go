@@ -0,0 +1,64 @@
+// Package httpjson is a small helper for calling JSON HTTP APIs such as the
+// OpenAI and Gemini endpoints.
+package httpjson
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// StatusError is returned when a service answers with a non-2xx status.
+type StatusError struct {
+	Service    string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: status %d: %s", e.Service, e.StatusCode, e.Body)
+}
+
+// Do sends body as JSON and returns the response when its status is 2xx.
+// The caller must close the response body.
+func Do(ctx context.Context, client *http.Client, service, url string, headers map[string]string, body any) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", service, err)
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return nil, &StatusError{Service: service, StatusCode: resp.StatusCode, Body: string(msg)}
+	}
+	return resp, nil
+}
+
+// Post sends body as JSON and decodes the JSON response into out.
+func Post(ctx context.Context, client *http.Client, service, url string, headers map[string]string, body, out any) error {
+	resp, err := Do(ctx, client, service, url, headers, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s: decode response: %w", service, err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Package pdftext extracts plain text from PDF files page by page using a
+// pure-Go parser, so no external tools are needed at runtime.
+package pdftext
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Page is the text of one PDF page. Number starts at 1, matching the page
+// numbers shown by PDF viewers.
+type Page struct {
+	Number int
+	Text   string
+}
+
+// ExtractFile opens the PDF at path and returns the text of every page.
+func ExtractFile(path string) ([]Page, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return Extract(f, info.Size())
+}
+
+// Extract returns the text of every page of the PDF read from r. Pages
+// without text (scans, blank pages) are returned with an empty Text so the
+// result always has one entry per page.
+func Extract(r io.ReaderAt, size int64) (pages []Page, err error) {
+	// The parser panics on some malformed files instead of returning errors.
+	defer func() {
+		if rec := recover(); rec != nil {
+			pages, err = nil, fmt.Errorf("parse pdf: %v", rec)
+		}
+	}()
+
+	reader, err := pdf.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("open pdf: %w", err)
+	}
+
+	n := reader.NumPage()
+	pages = make([]Page, 0, n)
+	for i := 1; i <= n; i++ {
+		p := reader.Page(i)
+		var text string
+		if !p.V.IsNull() {
+			text, err = p.GetPlainText(nil)
+			if err != nil {
+				return nil, fmt.Errorf("read page %d: %w", i, err)
+			}
+		}
+		pages = append(pages, Page{Number: i, Text: normalize(text)})
+	}
+	return pages, nil
+}
+
+// normalize collapses runs of spaces and tabs, trims every line and drops
+// repeated blank lines while keeping paragraph breaks.
+func normalize(s string) string {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	out := make([]string, 0, len(lines))
+	blank := true
+	for _, line := range lines {
+		line = strings.Join(strings.FieldsFunc(line, func(r rune) bool {
+			return unicode.IsSpace(r) || r == 0
+		}), " ")
+		if line == "" {
+			if !blank {
+				out = append(out, "")
+			}
+			blank = true
+			continue
+		}
+		out = append(out, line)
+		blank = false
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
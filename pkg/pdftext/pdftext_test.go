@@ -0,0 +1,64 @@
+package pdftext
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractFile(t *testing.T) {
+	tests := []struct {
+		file  string
+		pages []string
+	}{
+		{"single.pdf", []string{"The cat sat on the mat."}},
+		{"multipage.pdf", []string{
+			"Chapter one begins here.",
+			"The second page has two lines.\nThis is the second line.",
+			"The third page ends the story.",
+		}},
+		{"empty_page.pdf", []string{"Text before the blank page.", "", "Text after the blank page."}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			pages, err := ExtractFile(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatalf("ExtractFile: %v", err)
+			}
+			if len(pages) != len(tt.pages) {
+				t.Fatalf("got %d pages, want %d", len(pages), len(tt.pages))
+			}
+			for i, p := range pages {
+				if p.Number != i+1 {
+					t.Errorf("page %d: Number = %d, want %d", i, p.Number, i+1)
+				}
+				if p.Text != tt.pages[i] {
+					t.Errorf("page %d: Text = %q, want %q", p.Number, p.Text, tt.pages[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExtractRejectsInvalidPDF(t *testing.T) {
+	data := []byte("this is not a pdf")
+	if _, err := Extract(bytes.NewReader(data), int64(len(data))); err == nil {
+		t.Fatal("Extract accepted a file that is not a PDF")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"  one   two\tthree  ", "one two three"},
+		{"first\r\n\r\n\r\n\r\nsecond", "first\n\nsecond"},
+		{"\n\nline\x00one\n  \nline two\n\n", "line one\n\nline two"},
+	}
+	for _, tt := range tests {
+		if got := normalize(tt.in); got != tt.want {
+			t.Errorf("normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
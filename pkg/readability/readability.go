@@ -0,0 +1,141 @@
+// Package readability scores how hard an English text is to read and maps
+// the scores to an estimated CEFR level. Scores come from counts that can be
+// added up, so a document is scored from the counts of its chunks.
+package readability
+
+import (
+	"ai-learn-english/pkg/chunker"
+	"ai-learn-english/pkg/vocab"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Counts are the raw measurements of a text.
+type Counts struct {
+	Words     int
+	Sentences int
+	Syllables int
+	// Difficult words are outside the common word list, as in the
+	// Dale-Chall formula, which uses a list of about 3000 familiar words.
+	Difficult int
+	// Rare words are outside the 2000 most common lemmas.
+	Rare int
+}
+
+// Measure counts the words, sentences and syllables of text. Names, i.e.
+// capitalized words inside a sentence, are never difficult or rare.
+func Measure(text string) Counts {
+	var c Counts
+	for _, sent := range chunker.Sentences(text) {
+		tokens := words(sent)
+		for i, w := range tokens {
+			c.Syllables += syllables(w)
+			first, _ := utf8.DecodeRuneInString(w)
+			if i > 0 && unicode.IsUpper(first) {
+				continue
+			}
+			switch vocab.Band(vocab.Lemma(strings.ToLower(w))) {
+			case 5:
+				c.Difficult++
+				c.Rare++
+			case 4:
+				c.Rare++
+			}
+		}
+		if len(tokens) > 0 {
+			c.Words += len(tokens)
+			c.Sentences++
+		}
+	}
+	return c
+}
+
+// Add returns the counts of c and o together.
+func (c Counts) Add(o Counts) Counts {
+	return Counts{
+		Words:     c.Words + o.Words,
+		Sentences: c.Sentences + o.Sentences,
+		Syllables: c.Syllables + o.Syllables,
+		Difficult: c.Difficult + o.Difficult,
+		Rare:      c.Rare + o.Rare,
+	}
+}
+
+// Score is the readability of a text.
+type Score struct {
+	// FleschKincaidGrade is the US school grade of the text.
+	FleschKincaidGrade float64
+	// DaleChall is the New Dale-Chall score: under 5 is easy for a
+	// 10-year-old native reader, 10 and over is for graduates.
+	DaleChall         float64
+	AvgSentenceLength float64
+	RareWordRatio     float64
+	Level             string
+}
+
+// Score computes the readability of the measured text. ok is false when
+// the text has no words.
+func (c Counts) Score() (s Score, ok bool) {
+	if c.Words == 0 || c.Sentences == 0 {
+		return Score{}, false
+	}
+	words := float64(c.Words)
+	s.AvgSentenceLength = words / float64(c.Sentences)
+	s.FleschKincaidGrade = 0.39*s.AvgSentenceLength + 11.8*float64(c.Syllables)/words - 15.59
+	difficult := 100 * float64(c.Difficult) / words
+	s.DaleChall = 0.1579*difficult + 0.0496*s.AvgSentenceLength
+	if difficult > 5 {
+		s.DaleChall += 3.6365
+	}
+	s.RareWordRatio = float64(c.Rare) / words
+	s.Level = level(s)
+	return s, true
+}
+
+// level maps a score to a CEFR level: the Dale-Chall score and the
+// Flesch-Kincaid grade each give a level, and the text gets their average,
+// rounded down.
+func level(s Score) string {
+	dc := bucket(s.DaleChall, 5, 6, 7, 8, 9)
+	fk := bucket(s.FleschKincaidGrade, 2, 4, 7, 10, 13)
+	return vocab.Levels[(dc+fk)/2]
+}
+
+// bucket returns how many of the ascending limits v reaches.
+func bucket(v float64, limits ...float64) int {
+	n := 0
+	for _, l := range limits {
+		if v >= l {
+			n++
+		}
+	}
+	return n
+}
+
+// words returns the words of a sentence, without numbers or punctuation.
+// Hyphenated words count as one word.
+func words(sentence string) []string {
+	return strings.FieldsFunc(sentence, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\'' && r != '’' && r != '-'
+	})
+}
+
+// syllables estimates the syllables of word from its vowel groups.
+func syllables(word string) int {
+	w := strings.ToLower(strings.Trim(word, "'’-"))
+	n := 0
+	prevVowel := false
+	for _, r := range w {
+		v := strings.ContainsRune("aeiouy", r)
+		if v && !prevVowel {
+			n++
+		}
+		prevVowel = v
+	}
+	// A final silent e, as in "make", but not "le" as in "table".
+	if strings.HasSuffix(w, "e") && !strings.HasSuffix(w, "le") && !strings.HasSuffix(w, "ee") && n > 1 {
+		n--
+	}
+	return max(n, 1)
+}
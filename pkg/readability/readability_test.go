@@ -0,0 +1,132 @@
+package readability
+
+import (
+	"math"
+	"testing"
+)
+
+const (
+	easyText = "I like my dog. My dog is big. He runs in the park every day."
+	newsText = "The government announced a new policy on housing last week. Critics argue that the plan will not reduce prices, because it does not increase the supply of homes."
+	hardText = "Notwithstanding considerable methodological heterogeneity, the meta-analysis demonstrated statistically significant associations between socioeconomic deprivation and cardiovascular morbidity. Consequently, epidemiologists increasingly advocate comprehensive interventions addressing structural determinants."
+)
+
+func near(a, b float64) bool { return math.Abs(a-b) < 1e-3 }
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		counts Counts
+		fk, dc float64
+		level  string
+	}{
+		{"easy", easyText, Counts{Words: 15, Sentences: 3, Syllables: 17}, -0.267, 0.248, "A1"},
+		{"news", newsText, Counts{Words: 28, Sentences: 2, Syllables: 42}, 7.57, 0.694, "A2"},
+		{"academic", hardText, Counts{Words: 25, Sentences: 2, Syllables: 101, Difficult: 14, Rare: 18}, 36.957, 13.099, "C2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Measure(tt.text)
+			if c != tt.counts {
+				t.Errorf("Measure = %+v, want %+v", c, tt.counts)
+			}
+			s, ok := c.Score()
+			if !ok {
+				t.Fatal("no score")
+			}
+			if !near(s.FleschKincaidGrade, tt.fk) || !near(s.DaleChall, tt.dc) || s.Level != tt.level {
+				t.Errorf("Score = %+v, want grade %v, Dale-Chall %v, level %s", s, tt.fk, tt.dc, tt.level)
+			}
+			if want := float64(c.Words) / float64(c.Sentences); s.AvgSentenceLength != want {
+				t.Errorf("average sentence length %v, want %v", s.AvgSentenceLength, want)
+			}
+			if want := float64(c.Rare) / float64(c.Words); s.RareWordRatio != want {
+				t.Errorf("rare word ratio %v, want %v", s.RareWordRatio, want)
+			}
+		})
+	}
+}
+
+func TestScoreNoWords(t *testing.T) {
+	for _, text := range []string{"", "   ", "123 456. !!!", "—"} {
+		c := Measure(text)
+		if c != (Counts{}) {
+			t.Errorf("Measure(%q) = %+v, want nothing", text, c)
+		}
+		if s, ok := c.Score(); ok || s != (Score{}) {
+			t.Errorf("Score of %q = %+v, %v; want not ok", text, s, ok)
+		}
+	}
+	if _, ok := (Counts{Words: 3}).Score(); ok {
+		t.Error("words without a sentence scored")
+	}
+}
+
+func TestMeasureNames(t *testing.T) {
+	// Capitalized words inside a sentence are names, not difficult words;
+	// the same words in lower case are.
+	names := Measure("We met Obama and Merkel in Berlin.")
+	if names.Difficult != 0 || names.Rare != 0 || names.Words != 7 {
+		t.Errorf("names counted as difficult: %+v", names)
+	}
+	lower := Measure("We met obama and merkel in berlin.")
+	if lower.Difficult != 3 || lower.Rare != 3 {
+		t.Errorf("lower case: %+v, want 3 difficult and rare words", lower)
+	}
+	// The first word of a sentence is capitalized anyway, so it counts.
+	if c := Measure("Merkel met us."); c.Difficult != 1 {
+		t.Errorf("first word: %+v, want 1 difficult word", c)
+	}
+}
+
+func TestCountsAdd(t *testing.T) {
+	whole := Measure(easyText + " " + newsText)
+	if sum := Measure(easyText).Add(Measure(newsText)); sum != whole {
+		t.Errorf("Add = %+v, want the counts of the whole text %+v", sum, whole)
+	}
+}
+
+func TestLevel(t *testing.T) {
+	tests := []struct {
+		dc, fk float64
+		want   string
+	}{
+		{0, -5, "A1"},
+		{4.9, 1.9, "A1"},
+		{5, 2, "A2"}, // one step up on each scale
+		{5, 4, "A2"}, // (1+2)/2 rounds down
+		{6, 4, "B1"},
+		{7, 7, "B2"},
+		{8, 10, "C1"},
+		{9, 13, "C2"},
+		{20, 40, "C2"},
+		{20, 0, "B1"}, // (5+0)/2: the scales are averaged
+	}
+	for _, tt := range tests {
+		if got := level(Score{DaleChall: tt.dc, FleschKincaidGrade: tt.fk}); got != tt.want {
+			t.Errorf("level(Dale-Chall %v, grade %v) = %s, want %s", tt.dc, tt.fk, got, tt.want)
+		}
+	}
+}
+
+func TestSyllables(t *testing.T) {
+	tests := []struct {
+		word string
+		want int
+	}{
+		{"I", 1},
+		{"day", 1},
+		{"make", 1},
+		{"see", 1},
+		{"table", 2},
+		{"beautiful", 3},
+		{"well-known", 2},
+		{"readability", 5},
+	}
+	for _, tt := range tests {
+		if got := syllables(tt.word); got != tt.want {
+			t.Errorf("syllables(%q) = %d, want %d", tt.word, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Package sse writes Server-Sent Events to a buffered response stream.
+package sse
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Writer sends events on a stream and flushes after each one so that the
+// client receives them immediately. A write or flush error means the client
+// has gone away.
+type Writer struct {
+	w *bufio.Writer
+}
+
+func NewWriter(w *bufio.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Event sends an event named name with data encoded as JSON.
+func (s *Writer) Event(name string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if name != "" {
+		if _, err := fmt.Fprintf(s.w, "event: %s\n", name); err != nil {
+			return err
+		}
+	}
+	for _, line := range strings.Split(string(payload), "\n") {
+		if _, err := fmt.Fprintf(s.w, "data: %s\n", line); err != nil {
+			return err
+		}
+	}
+	if _, err := s.w.WriteString("\n"); err != nil {
+		return err
+	}
+	return s.w.Flush()
+}
+
+// Comment sends an SSE comment line, useful as a keep-alive.
+func (s *Writer) Comment(text string) error {
+	if _, err := fmt.Fprintf(s.w, ": %s\n\n", text); err != nil {
+		return err
+	}
+	return s.w.Flush()
+}
+
+// Accepts reports whether an Accept header value lists text/event-stream,
+// ignoring case, parameters and any other media ranges.
+func Accepts(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, _ := strings.Cut(part, ";")
+		if strings.EqualFold(strings.TrimSpace(mediaType), "text/event-stream") {
+			return true
+		}
+	}
+	return false
+}
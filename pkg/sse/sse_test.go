@@ -0,0 +1,25 @@
+package sse
+
+import "testing"
+
+func TestAccepts(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{"text/event-stream", true},
+		{"text/event-stream, */*", true},
+		{"application/json, text/event-stream;q=0.9", true},
+		{"Text/Event-Stream", true},
+		{" text/event-stream ; charset=utf-8", true},
+		{"", false},
+		{"*/*", false},
+		{"application/json", false},
+		{"text/event-stream-x", false},
+	}
+	for _, tt := range tests {
+		if got := Accepts(tt.accept); got != tt.want {
+			t.Errorf("Accepts(%q) = %v, want %v", tt.accept, got, tt.want)
+		}
+	}
+}
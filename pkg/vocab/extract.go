@@ -0,0 +1,178 @@
+package vocab
+
+import (
+	"ai-learn-english/pkg/chunker"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// stopwords are function words learners do not need to study.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "the": true, "and": true, "or": true, "but": true, "nor": true,
+	"if": true, "of": true, "in": true, "on": true, "at": true, "to": true, "for": true,
+	"from": true, "by": true, "with": true, "as": true, "into": true, "onto": true, "upon": true,
+	"about": true, "than": true, "then": true, "so": true, "not": true, "no": true, "yes": true,
+	"be": true, "have": true, "do": true, "will": true, "would": true, "can": true, "could": true,
+	"shall": true, "should": true, "may": true, "might": true, "must": true,
+	"i": true, "me": true, "my": true, "mine": true, "myself": true, "you": true, "your": true,
+	"yours": true, "yourself": true, "he": true, "him": true, "his": true, "himself": true,
+	"she": true, "her": true, "hers": true, "herself": true, "it": true, "its": true, "itself": true,
+	"we": true, "us": true, "our": true, "ours": true, "ourselves": true, "they": true, "them": true,
+	"their": true, "theirs": true, "themselves": true, "this": true, "that": true, "these": true,
+	"those": true, "who": true, "whom": true, "whose": true, "which": true, "what": true,
+	"when": true, "where": true, "why": true, "how": true, "there": true, "here": true,
+	"all": true, "any": true, "some": true, "each": true, "every": true, "both": true,
+	"either": true, "neither": true, "such": true, "very": true, "too": true, "also": true,
+	"just": true, "only": true, "own": true, "same": true, "other": true, "more": true,
+	"most": true, "much": true, "many": true, "few": true, "less": true, "least": true,
+	"oh": true, "ok": true, "yeah": true, "hey": true, "mr": true, "mrs": true, "ms": true,
+}
+
+const (
+	// Example sentences between these lengths in words are preferred.
+	minExampleWords = 6
+	maxExampleWords = 30
+	maxExampleRunes = 300
+	maxLemmaRunes   = 64
+)
+
+// Source is a piece of text to extract vocabulary from, with the chunk
+// and page it came from.
+type Source struct {
+	ChunkID   int64
+	PageIndex *int32
+	Text      string
+}
+
+// Candidate is a word worth studying found in the sources.
+type Candidate struct {
+	Lemma       string
+	Form        string // the most frequent spelling in the text
+	Occurrences int
+	Band        int
+	Level       string
+	Example     string
+	ChunkID     int64
+	PageIndex   *int32
+}
+
+type tally struct {
+	Candidate
+	forms       map[string]int
+	lower       bool // seen in lower case at least once
+	capitalized bool // seen capitalized inside a sentence
+	exampleFit  bool
+}
+
+// Extract returns the vocabulary of sources, most frequent first. Each
+// sentence is counted once even when sources overlap. Function words,
+// numbers and names (words only ever written capitalized inside a
+// sentence) are left out.
+func Extract(sources []Source) []Candidate {
+	words := make(map[string]*tally)
+	seen := make(map[string]bool)
+	for _, src := range sources {
+		for _, sent := range chunker.Sentences(src.Text) {
+			if seen[sent] {
+				continue
+			}
+			seen[sent] = true
+			tokens := tokenize(sent)
+			fit := len(tokens) >= minExampleWords && len(tokens) <= maxExampleWords &&
+				utf8.RuneCountInString(sent) <= maxExampleRunes
+			for i, tok := range tokens {
+				lower := strings.ToLower(tok)
+				lemma := Lemma(lower)
+				if !Studyable(lemma) {
+					continue
+				}
+				t := words[lemma]
+				if t == nil {
+					t = &tally{Candidate: Candidate{Lemma: lemma}, forms: make(map[string]int)}
+					words[lemma] = t
+				}
+				t.Occurrences++
+				t.forms[lower]++
+				first, _ := utf8.DecodeRuneInString(tok)
+				switch {
+				case !unicode.IsUpper(first):
+					t.lower = true
+				case i > 0:
+					t.capitalized = true
+				}
+				if t.Example == "" || fit && !t.exampleFit {
+					t.Example, t.ChunkID, t.PageIndex, t.exampleFit = clipExample(sent), src.ChunkID, src.PageIndex, fit
+				}
+			}
+		}
+	}
+
+	out := make([]Candidate, 0, len(words))
+	for _, t := range words {
+		if !t.lower && t.capitalized {
+			continue
+		}
+		c := t.Candidate
+		c.Form = mostFrequent(t.forms)
+		c.Band = Band(c.Lemma)
+		c.Level = Level(c.Lemma)
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Occurrences != out[j].Occurrences {
+			return out[i].Occurrences > out[j].Occurrences
+		}
+		return out[i].Lemma < out[j].Lemma
+	})
+	return out
+}
+
+// tokenize returns the words of a sentence. Hyphenated words are split,
+// possessive 's is dropped and contractions are skipped.
+func tokenize(sentence string) []string {
+	var out []string
+	for _, w := range strings.FieldsFunc(sentence, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '’'
+	}) {
+		w = strings.Trim(strings.ReplaceAll(w, "’", "'"), "'")
+		w = strings.TrimSuffix(strings.TrimSuffix(w, "'s"), "'S")
+		if w == "" || strings.ContainsRune(w, '\'') {
+			continue
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+// Studyable reports whether lemma is an English content word.
+func Studyable(lemma string) bool {
+	n := utf8.RuneCountInString(lemma)
+	if n < 3 || n > maxLemmaRunes || stopwords[lemma] {
+		return false
+	}
+	for _, r := range lemma {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func mostFrequent(forms map[string]int) string {
+	best, count := "", 0
+	for f, n := range forms {
+		if n > count || n == count && f < best {
+			best, count = f, n
+		}
+	}
+	return best
+}
+
+func clipExample(s string) string {
+	if utf8.RuneCountInString(s) <= maxExampleRunes {
+		return s
+	}
+	return string([]rune(s)[:maxExampleRunes-1]) + "…"
+}
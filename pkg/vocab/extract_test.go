@@ -0,0 +1,123 @@
+package vocab
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func find(candidates []Candidate, lemma string) *Candidate {
+	for i := range candidates {
+		if candidates[i].Lemma == lemma {
+			return &candidates[i]
+		}
+	}
+	return nil
+}
+
+func TestExtract(t *testing.T) {
+	page := int32(4)
+	got := Extract([]Source{
+		{ChunkID: 1, Text: "The committee approved the budget after a long debate. Budgets matter."},
+		// Chunks overlap: the repeated sentences are counted once.
+		{ChunkID: 2, PageIndex: &page, Text: "Budgets matter. We visited Paris and then Tokyo in May last year. The children were reading quietly in the library all afternoon."},
+		{ChunkID: 3, Text: "In 2024 it's 42 degrees! Rome... Reading is fun."},
+	})
+
+	budget := find(got, "budget")
+	if budget == nil || budget.Occurrences != 2 || budget.Form != "budget" || budget.ChunkID != 1 {
+		t.Errorf("budget = %+v, want 2 occurrences from chunk 1", budget)
+	}
+	if c := find(got, "approve"); c == nil || c.Form != "approved" {
+		t.Errorf("approve = %+v", c)
+	}
+	if c := find(got, "child"); c == nil || c.Form != "children" || c.ChunkID != 2 || c.PageIndex == nil || *c.PageIndex != 4 {
+		t.Errorf("child = %+v", c)
+	}
+
+	// Names capitalized inside a sentence are left out; so are function
+	// words, numbers and contractions.
+	for _, lemma := range []string{"paris", "tokyo", "may", "the", "and", "it", "2024", "42"} {
+		if c := find(got, lemma); c != nil {
+			t.Errorf("%q should not be extracted: %+v", lemma, c)
+		}
+	}
+	// A word seen in lower case somewhere is kept even when it also starts
+	// a sentence.
+	if c := find(got, "reading"); c == nil || c.Occurrences != 2 {
+		t.Errorf("reading = %+v, want 2 occurrences", c)
+	}
+
+	for i := 1; i < len(got); i++ {
+		a, b := got[i-1], got[i]
+		if a.Occurrences < b.Occurrences || a.Occurrences == b.Occurrences && a.Lemma > b.Lemma {
+			t.Fatalf("not sorted by frequency, then lemma: %q before %q", a.Lemma, b.Lemma)
+		}
+	}
+	for _, c := range got {
+		if c.Band != Band(c.Lemma) || c.Level != Level(c.Lemma) {
+			t.Errorf("%s: band %d level %s", c.Lemma, c.Band, c.Level)
+		}
+	}
+}
+
+func TestExtractExamples(t *testing.T) {
+	long := "The " + strings.Repeat("very ", 40) + "patient sailor waited."
+	got := Extract([]Source{
+		{ChunkID: 1, Text: "Sailors rest."},
+		{ChunkID: 2, Text: long},
+		{ChunkID: 3, Text: "A patient sailor always waits for the spring wind."},
+		{ChunkID: 4, Text: "Every sailor knows the value of good weather and patience."},
+	})
+	// The first sentence of a good length is the example, not a fragment or
+	// a run-on sentence seen earlier.
+	for _, lemma := range []string{"sailor", "patient", "wait"} {
+		c := find(got, lemma)
+		if c == nil || c.ChunkID != 3 || c.Example != "A patient sailor always waits for the spring wind." {
+			t.Errorf("%s = %+v, want the example from chunk 3", lemma, c)
+		}
+	}
+	if c := find(got, "sailor"); c == nil || c.Occurrences != 4 {
+		t.Errorf("sailor = %+v, want 4 occurrences", c)
+	}
+	// A word only seen in a poor sentence still gets it as its example.
+	if c := find(got, "rest"); c == nil || c.ChunkID != 1 || c.Example != "Sailors rest." {
+		t.Errorf("rest = %+v", c)
+	}
+
+	got = Extract([]Source{{ChunkID: 1, Text: "Tiny " + strings.Repeat("word ", 100) + "end."}})
+	c := find(got, "tiny")
+	if c == nil || len([]rune(c.Example)) != maxExampleRunes || !strings.HasSuffix(c.Example, "…") {
+		t.Errorf("a long example is not clipped to %d runes: %+v", maxExampleRunes, c)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	got := tokenize("The well-known author’s book isn't cheap, it's 'great' — 3D art!")
+	// "it's" loses its 's like a possessive; "isn't" is skipped.
+	want := []string{"The", "well", "known", "author", "book", "cheap", "it", "great", "3D", "art"}
+	if !slices.Equal(got, want) {
+		t.Errorf("tokenize = %q, want %q", got, want)
+	}
+}
+
+func TestStudyable(t *testing.T) {
+	tests := []struct {
+		lemma string
+		want  bool
+	}{
+		{"budget", true},
+		{"cat", true},
+		{"go", false},
+		{"the", false},
+		{"themselves", false},
+		{"café", false},
+		{"3d", false},
+		{strings.Repeat("a", maxLemmaRunes+1), false},
+	}
+	for _, tt := range tests {
+		if got := Studyable(tt.lemma); got != tt.want {
+			t.Errorf("Studyable(%q) = %v, want %v", tt.lemma, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package vocab
+
+import "strings"
+
+// irregular maps inflected forms that suffix rules cannot undo to their
+// lemma.
+var irregular = map[string]string{
+	"am": "be", "is": "be", "are": "be", "was": "be", "were": "be", "been": "be", "being": "be",
+	"has": "have", "had": "have", "having": "have",
+	"does": "do", "did": "do", "done": "do",
+	"went": "go", "gone": "go", "goes": "go",
+	"said": "say", "made": "make", "took": "take", "taken": "take", "came": "come",
+	"saw": "see", "seen": "see", "knew": "know", "known": "know", "got": "get", "gotten": "get",
+	"gave": "give", "given": "give", "found": "find", "thought": "think", "told": "tell",
+	"became": "become", "left": "leave", "felt": "feel", "brought": "bring", "began": "begin",
+	"begun": "begin", "kept": "keep", "held": "hold", "wrote": "write", "written": "write",
+	"stood": "stand", "heard": "hear", "meant": "mean", "met": "meet", "ran": "run", "paid": "pay",
+	"sat": "sit", "spoke": "speak", "spoken": "speak", "led": "lead", "grew": "grow", "grown": "grow",
+	"lost": "lose", "fell": "fall", "fallen": "fall", "sent": "send", "built": "build",
+	"understood": "understand", "drew": "draw", "drawn": "draw", "broke": "break", "broken": "break",
+	"spent": "spend", "rose": "rise", "risen": "rise", "drove": "drive", "driven": "drive",
+	"bought": "buy", "wore": "wear", "worn": "wear", "chose": "choose", "chosen": "choose",
+	"sought": "seek", "threw": "throw", "thrown": "throw", "caught": "catch", "dealt": "deal",
+	"won": "win", "forgot": "forget", "forgotten": "forget", "taught": "teach", "fought": "fight",
+	"ate": "eat", "eaten": "eat", "sold": "sell", "flew": "fly", "flown": "fly", "hid": "hide",
+	"hidden": "hide", "sang": "sing", "sung": "sing", "swam": "swim", "swum": "swim", "slept": "sleep",
+	"fed": "feed", "shook": "shake", "shaken": "shake", "stole": "steal", "stolen": "steal",
+	"woke": "wake", "woken": "wake", "froze": "freeze", "frozen": "freeze", "bitten": "bite",
+	"rode": "ride", "ridden": "ride", "struck": "strike", "hung": "hang", "laid": "lay",
+	"shot": "shoot", "dug": "dig", "bent": "bend", "lent": "lend", "fled": "flee", "forbade": "forbid",
+	"forbidden": "forbid", "forgave": "forgive", "forgiven": "forgive", "undertook": "undertake",
+	"undertaken": "undertake", "underwent": "undergo", "undergone": "undergo", "arose": "arise",
+	"arisen": "arise", "bore": "bear", "borne": "bear", "swore": "swear", "sworn": "swear",
+	"tore": "tear", "torn": "tear", "blew": "blow", "blown": "blow", "drank": "drink", "drunk": "drink",
+	"rang": "ring", "rung": "ring", "sank": "sink", "sunk": "sink", "shone": "shine", "slid": "slide",
+	"stuck": "stick", "swept": "sweep", "wept": "weep", "wound": "wind", "withdrew": "withdraw",
+	"withdrawn": "withdraw", "overcame": "overcome", "mistook": "mistake", "mistaken": "mistake",
+	"men": "man", "women": "woman", "children": "child", "feet": "foot", "teeth": "tooth",
+	"mice": "mouse", "geese": "goose", "lives": "life", "wives": "wife", "knives": "knife",
+	"leaves": "leaf", "halves": "half", "wolves": "wolf", "shelves": "shelf", "thieves": "thief",
+	"criteria": "criterion", "phenomena": "phenomenon", "analyses": "analysis", "crises": "crisis",
+	"theses": "thesis", "hypotheses": "hypothesis", "bases": "basis", "media": "medium",
+	"better": "good", "best": "good", "worse": "bad", "worst": "bad", "further": "far",
+	"farther": "far", "furthest": "far", "farthest": "far",
+}
+
+// Lemma returns the dictionary form of a lowercased English word. Forms in
+// the word list are kept as they are; otherwise common inflections are
+// removed, preferring a result that is in the word list.
+func Lemma(word string) string {
+	if l, ok := irregular[word]; ok {
+		return l
+	}
+	if Rank(word) > 0 || len(word) <= 3 {
+		return word
+	}
+	candidates := stems(word)
+	for _, c := range candidates {
+		if Rank(c) > 0 {
+			return c
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates[0]
+	}
+	return word
+}
+
+// stems returns the possible lemmas of word from its suffix, most likely
+// first.
+func stems(word string) []string {
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		return []string{word[:len(word)-3] + "y"}
+	case strings.HasSuffix(word, "ied") && len(word) > 4:
+		return []string{word[:len(word)-3] + "y"}
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "shes"), strings.HasSuffix(word, "ches"),
+		strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "zzes"):
+		return []string{word[:len(word)-2], word[:len(word)-1]}
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+		return nil
+	case strings.HasSuffix(word, "s"):
+		return []string{word[:len(word)-1]}
+	case strings.HasSuffix(word, "ed"):
+		return verbStems(word[:len(word)-2])
+	case strings.HasSuffix(word, "ing") && len(word) > 5:
+		return verbStems(word[:len(word)-3])
+	case strings.HasSuffix(word, "est") && len(word) > 5:
+		return adjectiveStems(word[:len(word)-3])
+	case strings.HasSuffix(word, "er") && len(word) > 4:
+		return adjectiveStems(word[:len(word)-2])
+	}
+	return nil
+}
+
+// verbStems undoes the spelling changes before -ed and -ing: "stopp" is
+// "stop" and "mak" is "make".
+func verbStems(stem string) []string {
+	n := len(stem)
+	// Two letters are too short for a verb stem: "used" is "use", not "us".
+	if n <= 2 {
+		return []string{stem + "e"}
+	}
+	var out []string
+	if stem[n-1] == stem[n-2] && !strings.ContainsRune("aeiousl", rune(stem[n-1])) {
+		out = append(out, stem[:n-1])
+	}
+	// A one-syllable stem ending in a single consonant would have doubled
+	// it ("hopping"), so "hop" from "hoping" is really "hope". English words
+	// do not end in v or z either.
+	if strings.IndexByte("vz", stem[n-1]) >= 0 || vowels(stem) == 1 &&
+		!isVowel(stem[n-1]) && isVowel(stem[n-2]) && !isVowel(stem[n-3]) {
+		return append(out, stem+"e", stem)
+	}
+	return append(out, stem, stem+"e")
+}
+
+func vowels(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if isVowel(s[i]) {
+			n++
+		}
+	}
+	return n
+}
+
+func isVowel(b byte) bool {
+	return strings.IndexByte("aeiou", b) >= 0
+}
+
+// adjectiveStems undoes -er and -est: "bigg" is "big", "earli" is "early"
+// and "nic" is "nice". The bare stem is only returned when it is a known
+// word, since most words ending in -er are not comparatives.
+func adjectiveStems(stem string) []string {
+	var out []string
+	n := len(stem)
+	if n > 2 && stem[n-1] == stem[n-2] {
+		out = append(out, stem[:n-1])
+	}
+	if strings.HasSuffix(stem, "i") {
+		out = append(out, stem[:n-1]+"y")
+	}
+	out = append(out, stem, stem+"e")
+	for _, s := range out {
+		if Rank(s) > 0 {
+			return []string{s}
+		}
+	}
+	return nil
+}
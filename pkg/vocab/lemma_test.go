@@ -0,0 +1,122 @@
+package vocab
+
+import "testing"
+
+func TestLemma(t *testing.T) {
+	tests := []struct{ word, want string }{
+		// Irregular forms.
+		{"went", "go"},
+		{"was", "be"},
+		{"children", "child"},
+		{"better", "good"},
+		{"analyses", "analysis"},
+		// Words in the list are kept.
+		{"reading", "reading"},
+		{"computer", "computer"},
+		{"status", "status"},
+		{"thing", "thing"},
+		// Plurals and third person.
+		{"gives", "give"},
+		{"houses", "house"},
+		{"studies", "study"},
+		{"boxes", "box"},
+		{"watches", "watch"},
+		{"classes", "class"},
+		{"zebras", "zebra"},
+		{"cats", "cat"},
+		// -ed and -ing.
+		{"walked", "walk"},
+		{"loved", "love"},
+		{"loving", "love"},
+		{"carried", "carry"},
+		{"stopped", "stop"},
+		{"running", "run"},
+		{"making", "make"},
+		{"hoping", "hope"},
+		{"hopping", "hop"},
+		{"sized", "size"},
+		{"used", "use"},
+		{"tied", "tie"},
+		{"aged", "age"},
+		// Comparatives.
+		{"bigger", "big"},
+		{"biggest", "big"},
+		{"earlier", "early"},
+		{"nicer", "nice"},
+		// Short words are left alone.
+		{"bus", "bus"},
+		{"ran", "run"},
+	}
+	for _, tt := range tests {
+		if got := Lemma(tt.word); got != tt.want {
+			t.Errorf("Lemma(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestGuess(t *testing.T) {
+	tests := []struct{ prev, word, want string }{
+		{"", "quickly", Adverb},
+		{"", "family", Noun},
+		{"an", "early", Adjective},
+		{"", "happiness", Noun},
+		{"", "beautiful", Adjective},
+		{"a", "beautiful", Adjective},
+		{"", "organize", Verb},
+		{"", "running", Verb},
+		{"the", "run", Noun},
+		{"to", "run", Verb},
+		{"will", "careful", Adjective},
+		{"the", "running", Noun},
+		{"", "table", Noun},
+		{"", "fly", Noun},
+	}
+	for _, tt := range tests {
+		if got := Guess(tt.prev, tt.word); got != tt.want {
+			t.Errorf("Guess(%q, %q) = %s, want %s", tt.prev, tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestBandAndLevel(t *testing.T) {
+	byRank := make(map[int]string, len(ranks))
+	for lemma, r := range ranks {
+		byRank[r] = lemma
+	}
+	if byRank[1] != "the" {
+		t.Fatalf("rank 1 is %q, want \"the\"", byRank[1])
+	}
+	tests := []struct {
+		rank  int
+		band  int
+		level string
+	}{
+		{1, 1, A1},
+		{500, 1, A1},
+		{501, 2, A1},
+		{600, 2, A1},
+		{601, 2, A2},
+		{1000, 2, A2},
+		{1001, 3, A2},
+		{1200, 3, A2},
+		{1201, 3, B1},
+		{2000, 3, B1},
+		{2001, 4, B2},
+		{len(ranks), 4, B2},
+	}
+	for _, tt := range tests {
+		lemma := byRank[tt.rank]
+		if lemma == "" {
+			t.Fatalf("no lemma at rank %d", tt.rank)
+		}
+		if got := Band(lemma); got != tt.band {
+			t.Errorf("Band(%q) at rank %d = %d, want %d", lemma, tt.rank, got, tt.band)
+		}
+		if got := Level(lemma); got != tt.level {
+			t.Errorf("Level(%q) at rank %d = %s, want %s", lemma, tt.rank, got, tt.level)
+		}
+	}
+	if Rank("zyzzyva") != 0 || Band("zyzzyva") != 5 || Level("zyzzyva") != C1 {
+		t.Errorf("a word outside the list: rank %d band %d level %s", Rank("zyzzyva"), Band("zyzzyva"), Level("zyzzyva"))
+	}
+}
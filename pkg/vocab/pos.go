@@ -0,0 +1,81 @@
+package vocab
+
+import "strings"
+
+// Parts of speech returned by Guess.
+const (
+	Noun      = "noun"
+	Verb      = "verb"
+	Adjective = "adjective"
+	Adverb    = "adverb"
+)
+
+// PartsOfSpeech lists the parts of speech Guess can return.
+var PartsOfSpeech = []string{Noun, Verb, Adjective, Adverb}
+
+// determiners are followed by a noun phrase.
+var determiners = map[string]bool{
+	"a": true, "an": true, "the": true, "this": true, "that": true, "these": true, "those": true,
+	"my": true, "your": true, "his": true, "her": true, "its": true, "our": true, "their": true,
+	"some": true, "any": true, "each": true, "every": true, "no": true,
+}
+
+// verbMarkers are followed by a bare verb.
+var verbMarkers = map[string]bool{
+	"to": true, "will": true, "would": true, "can": true, "could": true, "shall": true,
+	"should": true, "may": true, "might": true, "must": true, "do": true, "does": true, "did": true,
+}
+
+// lyWords end in -ly without being adverbs.
+var lyWords = map[string]string{
+	"family": Noun, "supply": Noun, "reply": Verb, "apply": Verb, "rely": Verb, "ally": Noun,
+	"friendly": Adjective, "likely": Adjective, "lovely": Adjective, "lonely": Adjective,
+	"silly": Adjective, "ugly": Adjective, "early": Adjective, "daily": Adjective,
+	"elderly": Adjective, "costly": Adjective, "lively": Adjective, "deadly": Adjective,
+	"holy": Adjective, "only": Adjective,
+}
+
+var (
+	nounSuffixes      = []string{"tion", "sion", "ment", "ness", "ity", "ance", "ence", "ism", "ship", "hood", "ist", "ure", "age"}
+	adjectiveSuffixes = []string{"ous", "ful", "ive", "able", "ible", "al", "ic", "less", "ish", "ary"}
+	verbSuffixes      = []string{"ize", "ise", "ify", "ate", "ing", "ed"}
+)
+
+// Guess guesses the part of speech of a lower case word from its ending
+// and from prev, the lower case word before it ("" at the start of a
+// sentence). It is a heuristic good enough to pick exercise words, not a
+// tagger: words it cannot place are nouns.
+func Guess(prev, word string) string {
+	if pos, ok := lyWords[word]; ok {
+		return pos
+	}
+	if strings.HasSuffix(word, "ly") && len(word) > 4 {
+		return Adverb
+	}
+	adjective := hasSuffix(word, adjectiveSuffixes)
+	switch {
+	case determiners[prev]:
+		if adjective {
+			return Adjective
+		}
+		return Noun
+	case verbMarkers[prev] && !adjective:
+		return Verb
+	case hasSuffix(word, nounSuffixes):
+		return Noun
+	case adjective:
+		return Adjective
+	case hasSuffix(word, verbSuffixes):
+		return Verb
+	}
+	return Noun
+}
+
+func hasSuffix(word string, suffixes []string) bool {
+	for _, s := range suffixes {
+		if len(word) > len(s)+2 && strings.HasSuffix(word, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+// Package vocab picks study vocabulary out of English text: words are
+// reduced to their lemma and given a frequency band and an estimated CEFR
+// level from an embedded list of common lemmas.
+package vocab
+
+import (
+	_ "embed"
+	"strings"
+)
+
+//go:embed wordlist.txt
+var wordlist string
+
+// ranks maps each lemma of the word list to its position, from 1.
+var ranks = func() map[string]int {
+	out := make(map[string]int)
+	for _, line := range strings.Split(wordlist, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, ok := out[line]; !ok {
+			out[line] = len(out) + 1
+		}
+	}
+	return out
+}()
+
+// CEFR levels, from beginner to mastery.
+const (
+	A1 = "A1"
+	A2 = "A2"
+	B1 = "B1"
+	B2 = "B2"
+	C1 = "C1"
+	C2 = "C2"
+)
+
+// Levels lists the CEFR levels in order.
+var Levels = []string{A1, A2, B1, B2, C1, C2}
+
+// Rank returns the position of lemma in the word list, or 0 when the word
+// is not among the common lemmas.
+func Rank(lemma string) int {
+	return ranks[lemma]
+}
+
+// Band returns the frequency band of lemma: 1 for the 500 most common
+// lemmas, 2 up to 1000, 3 up to 2000, 4 for the rest of the word list and 5
+// for words outside it.
+func Band(lemma string) int {
+	switch r := Rank(lemma); {
+	case r == 0:
+		return 5
+	case r <= 500:
+		return 1
+	case r <= 1000:
+		return 2
+	case r <= 2000:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// Level estimates the CEFR level at which learners usually meet lemma.
+// The estimate only comes from frequency, so words outside the list are
+// all C1; there is not enough information to tell C1 from C2.
+func Level(lemma string) string {
+	switch r := Rank(lemma); {
+	case r == 0:
+		return C1
+	case r <= 600:
+		return A1
+	case r <= 1200:
+		return A2
+	case r <= 2000:
+		return B1
+	default:
+		return B2
+	}
+}